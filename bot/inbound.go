@@ -0,0 +1,125 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"liveChat/log"
+	"liveChat/rpc"
+	"liveChat/webhook"
+	"time"
+)
+
+const (
+	MaxPollTimeout = time.Second * 30
+	MaxPollLimit   = 100
+)
+
+// InitBot 将机器人注册为 webhook 分发器的投递目标，需在 webhook.InitWebhook 之后调用
+func InitBot() {
+	if dispatcher := webhook.GetDispatcher(); dispatcher != nil {
+		dispatcher.SetBotTarget(botTarget{})
+	}
+}
+
+type botTarget struct{}
+
+func (botTarget) GetBotWebhook(botId int64) (string, string, error) {
+	info, err := controllers.GetBotInfo(botId, false)
+	if err != nil {
+		return "", "", err
+	} else if info == nil || info.IsDisabled || info.WebhookUrl == "" {
+		return "", "", webhook.ErrorSubscriptionNotExist
+	}
+	return info.WebhookUrl, info.WebhookSecret, nil
+}
+
+func (botTarget) OnDeliveryDead(d *entities.WebhookDelivery) {
+	log.Error(fmt.Sprintf("机器人 %d webhook 投递失败，转入收件箱: %s", d.BotId, d.LastError))
+	pushToInbox(d.BotId, []byte(d.Payload))
+}
+
+// DeliverMessage 将消息投递给接收者中的机器人，设置了 webhook 的机器人优先通过 webhook 接收，
+// 重试均失败或未设置时放入收件箱等待机器人拉取
+func DeliverMessage(m *rpc.Message, recipients []int64) {
+	deliver(recipients, m.Sender, webhook.EventMessageCreated, m.Receiver, webhook.NewMessageData(m))
+}
+
+// DeliverNotification 将发给机器人的通知（如好友申请）投递给机器人，以便其调用审批接口
+func DeliverNotification(n *rpc.NotificationRequest, recipients []int64) {
+	eventType, chatId := webhook.NotificationEvent(n)
+	if eventType == "" {
+		return
+	}
+	deliver(recipients, n.Sender, eventType, chatId, webhook.NewNotificationData(n))
+}
+
+func deliver(recipients []int64, senderId int64, eventType string, chatId int64, data interface{}) {
+	for _, userId := range recipients {
+		if userId == senderId {
+			continue
+		}
+
+		info, err := controllers.GetBotInfo(userId, false)
+		if err != nil {
+			log.Error(fmt.Sprintf("获取机器人信息失败: %s", err.Error()))
+			continue
+		} else if info == nil || info.IsDisabled {
+			continue
+		}
+
+		event := &webhook.Event{
+			Id:        webhook.GenerateId(),
+			Type:      eventType,
+			Timestamp: time.Now().UnixMilli(),
+			ChatId:    chatId,
+			Data:      data,
+		}
+		payload, err := json.Marshal(event)
+		if err != nil {
+			log.Error(fmt.Sprintf("序列化机器人事件失败: %s", err.Error()))
+			return
+		}
+
+		if info.WebhookUrl == "" {
+			pushToInbox(userId, payload)
+			continue
+		}
+
+		// 由分发器的协程投递与重试，不阻塞消息队列的消费
+		if err = webhook.PublishToBot(userId, eventType, event.Id, payload); err != nil {
+			log.Error(fmt.Sprintf("机器人 %d webhook 投递失败，转入收件箱: %s", userId, err.Error()))
+			pushToInbox(userId, payload)
+		}
+	}
+}
+
+func pushToInbox(botId int64, data []byte) {
+	if err := db.PushBotInbox(botId, data); err != nil {
+		log.Error(fmt.Sprintf("机器人 %d 收件箱写入失败: %s", botId, err.Error()))
+	}
+}
+
+// Poll 从机器人收件箱中取出事件，没有事件时最多等待 timeout
+func Poll(ctx context.Context, botId int64, timeout time.Duration, limit int) ([]json.RawMessage, error) {
+	if timeout > MaxPollTimeout {
+		timeout = MaxPollTimeout
+	}
+	if limit <= 0 || limit > MaxPollLimit {
+		limit = MaxPollLimit
+	}
+
+	list, err := db.PopBotInbox(ctx, botId, timeout, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]json.RawMessage, len(list))
+	for i, data := range list {
+		events[i] = data
+	}
+	return events, nil
+}
@@ -182,6 +182,8 @@ func (cfg *MysqlConfig) Format() string {
 type GeneralConfig struct {
 	HttpListenAddresses []string `json:"http_listen_addresses"`
 	TcpListenAddress    string   `json:"tcp_listen_address"`
+	// 可信的反向代理地址或网段，只有来自这些地址的请求才会使用 X-Forwarded-For 中的客户端 IP，为空时不信任任何代理
+	TrustedProxies []string `json:"trusted_proxies,omitempty"`
 
 	MessageQueueConfig      MessageQueueConfig `json:"message_queue_config"`
 	NotificationQueueConfig MessageQueueConfig `json:"notification_queue_config"`
@@ -194,6 +196,134 @@ type GeneralConfig struct {
 	MysqlConfig   MysqlConfig   `json:"mysql_config"`
 	MongoDBConfig MongoDBConfig `json:"mongo_db_config"`
 	RedisConfig   RedisConfig   `json:"redis_config"`
+
+	// 可选 mongodb 与 inverted，为空时使用 mongodb 文本索引
+	SearchEngine string `json:"search_engine,omitempty"`
+
+	PushConfig PushConfig `json:"push_config,omitempty"`
+
+	WebhookConfig WebhookConfig `json:"webhook_config,omitempty"`
+
+	NotificationConfig NotificationConfig `json:"notification_config,omitempty"`
+
+	RetentionConfig RetentionConfig `json:"retention_config,omitempty"`
+
+	ExportConfig ExportConfig `json:"export_config,omitempty"`
+
+	TLSConfig ServerTLSConfig `json:"tls_config,omitempty"`
+
+	CorsConfig CorsConfig `json:"cors_config,omitempty"`
+
+	RateLimitConfig RateLimitConfig `json:"rate_limit_config,omitempty"`
+
+	ModerationConfig ModerationConfig `json:"moderation_config,omitempty"`
+
+	MetricsConfig MetricsConfig `json:"metrics_config,omitempty"`
+	// 调用运维接口时需在请求头中携带的密钥，为空时运维接口不可用
+	OperatorKeys []string `json:"operator_keys,omitempty"`
+}
+
+type PushConfig struct {
+	// 为空时不启用任何推送通道，设备仍可注册
+	WebhookUrl         string `json:"webhook_url,omitempty"`
+	RateLimitPerMinute int    `json:"rate_limit_per_minute,omitempty"`
+	Workers            int    `json:"workers,omitempty"`
+}
+
+type WebhookConfig struct {
+	MaxAttempts        int `json:"max_attempts,omitempty"`
+	BaseBackoffSeconds int `json:"base_backoff_seconds,omitempty"`
+	MaxBackoffSeconds  int `json:"max_backoff_seconds,omitempty"`
+	TimeoutSeconds     int `json:"timeout_seconds,omitempty"`
+	Workers            int `json:"workers,omitempty"`
+}
+
+// NotificationConfig 中 ExpireAfterHours 不大于 0 时好友申请、入群申请与入群邀请不会过期
+type NotificationConfig struct {
+	ExpireAfterHours     int `json:"expire_after_hours,omitempty"`
+	SweepIntervalSeconds int `json:"sweep_interval_seconds,omitempty"`
+}
+
+// RetentionConfig 为全局默认的消息保留策略，DefaultKeepDays 与 DefaultKeepLast 均为 0 时默认永久保留。
+// Archive 为真时清理的消息会转存到归档集合而不是直接删除
+type RetentionConfig struct {
+	DefaultKeepDays      int64 `json:"default_keep_days,omitempty"`
+	DefaultKeepLast      int64 `json:"default_keep_last,omitempty"`
+	PurgeIntervalSeconds int   `json:"purge_interval_seconds,omitempty"`
+	Archive              bool  `json:"archive,omitempty"`
+}
+
+// ExportConfig 中 Directory 为导出文件的存放目录，为空时使用系统临时目录
+// ExportConfig 中 Directory 为空时导出文件保存在 MongoDB 中，ExpireHours 为导出文件的保存小时数
+type ExportConfig struct {
+	Directory   string `json:"directory,omitempty"`
+	Workers     int    `json:"workers,omitempty"`
+	ExpireHours int    `json:"expire_hours,omitempty"`
+}
+
+// TLSConfig 中 CertFile 与 KeyFile 为空时不启用 TLS，ClientCaFile 不为空时要求对端提供由该 CA 签发的证书
+type TLSConfig struct {
+	CertFile     string `json:"cert_file,omitempty"`
+	KeyFile      string `json:"key_file,omitempty"`
+	ClientCaFile string `json:"client_ca_file,omitempty"`
+}
+
+func (cfg TLSConfig) IsEnabled() bool {
+	return cfg.CertFile != "" && cfg.KeyFile != ""
+}
+
+// ServerTLSConfig 中 Grpc 用于节点间的双向 TLS，ClientCaFile 为集群 CA，同时用于校验被调用节点的证书，
+// 节点证书需要同时可用于服务端与客户端认证。WebSocket 启用 TLS 后服务实际监听 WebSocketInternalAddress，
+// 由 tcp_listen_address 上的 TLS 连接解密后转发。证书文件变化后会在 ReloadIntervalSeconds 内重新加载
+type ServerTLSConfig struct {
+	Http      TLSConfig `json:"http,omitempty"`
+	WebSocket TLSConfig `json:"websocket,omitempty"`
+	Grpc      TLSConfig `json:"grpc,omitempty"`
+
+	WebSocketInternalAddress string `json:"websocket_internal_address,omitempty"`
+	ReloadIntervalSeconds    int    `json:"reload_interval_seconds,omitempty"`
+}
+
+// CorsConfig 为 HTTP 接口与 WebSocket 握手允许的来源，支持 * 通配，如 https://*.example.com，列表为空时不做限制
+type CorsConfig struct {
+	AllowedOrigins []string `json:"allowed_origins,omitempty"`
+	AllowedHosts   []string `json:"allowed_hosts,omitempty"`
+}
+
+// RateLimitRule 为令牌桶参数，Rate 为每秒补充的令牌数，Burst 为桶容量，Rate 不大于 0 时不限制
+type RateLimitRule struct {
+	Rate  float64 `json:"rate"`
+	Burst int64   `json:"burst"`
+}
+
+// RateLimitConfig 中 Backend 可选 memory 与 redis，为 redis 时各节点共享计数。
+// Routes 以 HTTP 路由为键，Loads 以 WebSocket 请求类型的名称为键。
+// 在 BanWindowSeconds 内超限 BanThreshold 次的 IP 或用户会被封禁 BanSeconds 秒，BanThreshold 为 0 时不封禁
+type RateLimitConfig struct {
+	Backend          string                   `json:"backend,omitempty"`
+	Routes           map[string]RateLimitRule `json:"routes,omitempty"`
+	Loads            map[string]RateLimitRule `json:"loads,omitempty"`
+	BanThreshold     int64                    `json:"ban_threshold,omitempty"`
+	BanWindowSeconds int                      `json:"ban_window_seconds,omitempty"`
+	BanSeconds       int                      `json:"ban_seconds,omitempty"`
+}
+
+// ModerationDictionary 中 Action 可选 block、mask 与 flag
+type ModerationDictionary struct {
+	Path   string `json:"path"`
+	Action string `json:"action"`
+}
+
+// ModerationConfig 中 BlockOnError 为真时审核出错的内容会被拒绝，否则跳过出错的分类器
+type ModerationConfig struct {
+	Dictionaries          []ModerationDictionary `json:"dictionaries,omitempty"`
+	ReloadIntervalSeconds int                    `json:"reload_interval_seconds,omitempty"`
+	BlockOnError          bool                   `json:"block_on_error,omitempty"`
+}
+
+// MetricsConfig 中 ListenAddress 为监控指标的监听地址，为空时不提供指标
+type MetricsConfig struct {
+	ListenAddress string `json:"listen_address,omitempty"`
 }
 
 type MessageQueueConfig struct {
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"liveChat/containers"
+	"liveChat/db"
+	"strconv"
+	"time"
+)
+
+const blockUpdateIntervalInMilli = 2000
+
+var (
+	blockCache          containers.ConcurrentMap
+	blockCacheTimestamp containers.ConcurrentMap
+)
+
+func init() {
+	blockCache = containers.New()
+	blockCacheTimestamp = containers.New()
+}
+
+// CheckIsUserBlocked 判断 userId 是否将 blockedId 加入了黑名单，黑名单是单向的
+func CheckIsUserBlocked(userId, blockedId int64, isEnforceDb bool) (bool, error) {
+	key := getBlockCacheKey(userId, blockedId)
+	if err := checkBlockCache(key, userId, blockedId, isEnforceDb); err != nil {
+		return false, err
+	}
+
+	flag, _ := blockCache.Get(key)
+	return flag.(bool), nil
+}
+
+func getBlockCacheKey(userId, blockedId int64) string {
+	return strconv.FormatInt(userId, 10) + "_" + strconv.FormatInt(blockedId, 10)
+}
+
+func checkBlockCache(key string, userId, blockedId int64, isEnforceDb bool) error {
+	updateFlag := false
+	if ret, ok := blockCacheTimestamp.Get(key); isEnforceDb || !ok || ret == nil || time.Now().UnixMilli()-ret.(int64) >= blockUpdateIntervalInMilli {
+		updateFlag = true
+	}
+
+	if !updateFlag {
+		return nil
+	}
+
+	if ret, ok := blockCacheTimestamp.Get(key); isEnforceDb || !ok || ret == nil {
+		flag, t, err := db.TellIsBlocked(nil, userId, blockedId)
+		if err != nil {
+			return err
+		}
+
+		err = db.SetBlockCache(key, t, flag)
+		if err != nil {
+			return err
+		}
+	}
+
+	flag, err := db.PullBlockCache(key)
+	if err != nil {
+		return err
+	}
+
+	blockCache.Set(key, flag)
+	blockCacheTimestamp.Set(key, time.Now().UnixMilli())
+	return nil
+}
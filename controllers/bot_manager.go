@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"liveChat/containers"
+	"liveChat/db"
+	"liveChat/entities"
+	"time"
+)
+
+const (
+	botInfoUpdateIntervalInMilli = 10000
+	botApiKeyPrefix              = "bot_"
+)
+
+type botCacheEntry struct {
+	info      *entities.BotInfo
+	timestamp int64
+}
+
+type botBanCacheEntry struct {
+	bannedUntil int64
+	timestamp   int64
+}
+
+// botInfoCache 同时缓存非机器人用户（info 为 nil），避免每条消息都查询数据库
+var botInfoCache *containers.ThreadSafeContainer
+
+// botBanCache 缓存机器人账号的封禁截止时间，避免每次 API Key 鉴权都查询数据库
+var botBanCache *containers.ThreadSafeContainer
+
+func init() {
+	botInfoCache = containers.NewThreadSafeContainer()
+	botBanCache = containers.NewThreadSafeContainer()
+}
+
+func GenerateBotApiKey() (key, hash string) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+
+	key = botApiKeyPrefix + hex.EncodeToString(buf)
+	return key, HashBotApiKey(key)
+}
+
+func HashBotApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func GetBotInfoByApiKey(key string) (*entities.BotInfo, error) {
+	return db.GetBotInfoByApiKeyHash(nil, HashBotApiKey(key))
+}
+
+// GetBotInfo 返回机器人信息，userId 不是机器人时返回 nil
+func GetBotInfo(userId int64, isEnforceDb bool) (*entities.BotInfo, error) {
+	now := time.Now().UnixMilli()
+	if ret, ok := botInfoCache.Get(userId); ok && !isEnforceDb {
+		if entry := ret.(botCacheEntry); now-entry.timestamp < botInfoUpdateIntervalInMilli {
+			return entry.info, nil
+		}
+	}
+
+	info, err := db.GetBotInfo(nil, userId)
+	if err == db.MysqlErrorBotNotExist {
+		info, err = nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	botInfoCache.Set(userId, botCacheEntry{info: info, timestamp: now})
+	return info, nil
+}
+
+// GetBotBannedUntil 返回机器人账号的封禁截止时间（毫秒），为 0 时未被封禁
+func GetBotBannedUntil(botId int64, isEnforceDb bool) (int64, error) {
+	now := time.Now().UnixMilli()
+	if ret, ok := botBanCache.Get(botId); ok && !isEnforceDb {
+		if entry := ret.(botBanCacheEntry); now-entry.timestamp < botInfoUpdateIntervalInMilli {
+			return entry.bannedUntil, nil
+		}
+	}
+
+	bannedUntil, err := db.GetUserBannedUntil(nil, botId)
+	if err != nil {
+		return 0, err
+	}
+
+	botBanCache.Set(botId, botBanCacheEntry{bannedUntil: bannedUntil, timestamp: now})
+	return bannedUntil, nil
+}
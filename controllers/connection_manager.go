@@ -4,6 +4,7 @@ import (
 	"github.com/panjf2000/gnet/v2"
 	"liveChat/containers"
 	"sync"
+	"time"
 )
 
 var connectionMap *containers.ThreadSafeContainer
@@ -38,19 +39,36 @@ func GetConnection(userId int64) []gnet.Conn {
 	return nil
 }
 
+// ConnectionInfo 为用户在本节点上某一平台的连接，ConnectedAt 单位为毫秒
+type ConnectionInfo struct {
+	Platform      int
+	RemoteAddress string
+	ConnectedAt   int64
+}
+
+// GetConnectionInfos 返回用户在本节点上的全部连接
+func GetConnectionInfos(userId int64) []ConnectionInfo {
+	if ret, ok := connectionMap.Get(userId); ok && ret != nil {
+		return ret.(*connectionForUser).getConnectionInfos()
+	}
+	return nil
+}
+
 type connectionForUser struct {
-	rwLock   *sync.RWMutex
-	conns    []gnet.Conn
-	size     int
-	isClosed bool
+	rwLock      *sync.RWMutex
+	conns       []gnet.Conn
+	connectedAt []int64
+	size        int
+	isClosed    bool
 }
 
 func newConnectionForUser() *connectionForUser {
 	return &connectionForUser{
-		rwLock:   &sync.RWMutex{},
-		conns:    make([]gnet.Conn, 2, 2),
-		size:     0,
-		isClosed: false,
+		rwLock:      &sync.RWMutex{},
+		conns:       make([]gnet.Conn, 2, 2),
+		connectedAt: make([]int64, 2, 2),
+		size:        0,
+		isClosed:    false,
 	}
 }
 
@@ -66,8 +84,10 @@ func (c *connectionForUser) addConnection(conn gnet.Conn, platform int) bool {
 		c.conns[platform].Close()
 	} else {
 		c.size++
+		connectionCount.With(platformName(platform)).Inc()
 	}
 	c.conns[platform] = conn
+	c.connectedAt[platform] = time.Now().UnixMilli()
 	return true
 }
 
@@ -82,6 +102,7 @@ func (c *connectionForUser) deleteConnection(platform int) (bool, bool) {
 	c.conns[platform].Close()
 	c.conns[platform] = nil
 	c.size--
+	connectionCount.With(platformName(platform)).Dec()
 
 	if c.size == 0 {
 		c.isClosed = true
@@ -107,3 +128,26 @@ func (c *connectionForUser) getConnections() []gnet.Conn {
 	}
 	return ret
 }
+
+func (c *connectionForUser) getConnectionInfos() []ConnectionInfo {
+	c.rwLock.RLock()
+	defer c.rwLock.RUnlock()
+
+	if c.isClosed {
+		return nil
+	}
+
+	ret := make([]ConnectionInfo, 0)
+	for platform, conn := range c.conns {
+		if conn == nil {
+			continue
+		}
+
+		info := ConnectionInfo{Platform: platform, ConnectedAt: c.connectedAt[platform]}
+		if addr := conn.RemoteAddr(); addr != nil {
+			info.RemoteAddress = addr.String()
+		}
+		ret = append(ret, info)
+	}
+	return ret
+}
@@ -44,6 +44,9 @@ func checkFriendshipCache(key string, userId1, userId2 int64, isEnforceDb bool)
 	if ret, ok := friendshipCacheTimestamp.Get(key); isEnforceDb || !ok || ret == nil || time.Now().UnixMilli()-ret.(int64) >= friendshipUpdateIntervalInMilli {
 		updateFlag = true
 	}
+	if !isEnforceDb {
+		db.RecordCacheAccess(db.CacheFriendship, !updateFlag)
+	}
 
 	if !updateFlag {
 		return nil
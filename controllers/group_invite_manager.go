@@ -0,0 +1,15 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// GenerateInviteCode 生成 32 位十六进制的邀请码
+func GenerateInviteCode() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf)
+}
@@ -65,6 +65,70 @@ func GetUserListInGroup(groupId int64, isEnforceDb bool) ([]entities.GroupMember
 	return ret, nil
 }
 
+// GetUserIdListInGroup 返回群组内未被删除成员的 id，onlyManager 为 true 时只返回有权处理入群申请的成员
+func GetUserIdListInGroup(groupId int64, onlyManager bool) (userList []int64, err error) {
+	info, err := getGroupInfoFromCache(groupId, false)
+	if err != nil {
+		return nil, err
+	} else if info.IsDeleted {
+		return nil, nil
+	}
+
+	for _, member := range info.Members {
+		if member.IsDeleted {
+			continue
+		}
+
+		if !onlyManager {
+			userList = append(userList, member.MemberId)
+		} else if permissions, _ := info.PermissionsOf(member.MemberId); permissions.Has(entities.PermissionApproveJoin) {
+			userList = append(userList, member.MemberId)
+		}
+	}
+
+	return userList, nil
+}
+
+// GetGroupNotificationReceivers 返回群组通知的接收者
+func GetGroupNotificationReceivers(groupId int64, opType byte) ([]int64, error) {
+	return GetUserIdListInGroup(groupId, !entities.IsGroupWideNotification(opType))
+}
+
+// GetGroupPermissions 返回用户在群组内的权限以及用户是否在群组内
+func GetGroupPermissions(userId, groupId int64, isEnforceDb bool) (entities.GroupPermission, bool, error) {
+	info, err := getGroupInfoFromCache(groupId, isEnforceDb)
+	if err != nil {
+		return 0, false, err
+	}
+
+	permissions, isIn := info.PermissionsOf(userId)
+	return permissions, isIn, nil
+}
+
+// CheckGroupPermission 检查用户在群组内是否拥有指定的权限
+func CheckGroupPermission(userId, groupId int64, permission entities.GroupPermission) (bool, error) {
+	permissions, isIn, err := GetGroupPermissions(userId, groupId, false)
+	if err != nil {
+		return false, err
+	}
+
+	return isIn && permissions.Has(permission), nil
+}
+
+// RefreshGroupInfoCache 从数据库重新加载群组信息并写入 Redis 与本地缓存
+func RefreshGroupInfoCache(groupId int64) error {
+	return checkGroupInfoCache(groupId, true)
+}
+
+func getGroupInfoFromCache(groupId int64, isEnforceDb bool) (entities.GroupInfo, error) {
+	if err := checkGroupInfoCache(groupId, isEnforceDb); err != nil {
+		return entities.GroupInfo{}, err
+	}
+
+	tmp, _ := groupInfoCache.Get(groupId)
+	return tmp.(entities.GroupInfo), nil
+}
+
 func checkGroupInfoCache(groupId int64, isEnforceDb bool) error {
 	updateFlag := false
 	if ret, ok := cacheTimestampMap.Get(groupId); isEnforceDb || !ok || ret == nil || time.Now().UnixMilli()-ret.(int64) >= groupInfoUpdateIntervalInMilli {
@@ -72,6 +136,7 @@ func checkGroupInfoCache(groupId int64, isEnforceDb bool) error {
 	}
 
 	if !updateFlag {
+		db.RecordCacheAccess(db.CacheGroup, true)
 		return nil
 	}
 
@@ -99,6 +164,11 @@ func checkGroupInfoCache(groupId int64, isEnforceDb bool) error {
 		}
 	}
 
+	// 本地缓存过期后与 Redis 中的摘要一致时仍视为命中
+	if !isEnforceDb {
+		db.RecordCacheAccess(db.CacheGroup, flag)
+	}
+
 	if !flag {
 		info, err := db.PullGroupInfoCache(groupId)
 		if err != nil {
@@ -113,7 +183,7 @@ func checkGroupInfoCache(groupId int64, isEnforceDb bool) error {
 		md5Buf := md5.Sum(data)
 		md5Str := string(md5Buf[:])
 		cacheMd5StringMap.Set(groupId, md5Str)
-		groupInfoCache.Set(groupId, info)
+		groupInfoCache.Set(groupId, *info)
 	}
 
 	cacheTimestampMap.Set(groupId, time.Now().UnixMilli())
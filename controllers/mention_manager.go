@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"errors"
+	"liveChat/entities"
+)
+
+var (
+	ErrorMentionInPrivateChat = errors.New("私聊消息中不允许提及用户")
+	ErrorMentionNotInGroup    = errors.New("被提及的用户不在群组中")
+	ErrorMentionAllNoAuth     = errors.New("没有提及所有人的权限")
+)
+
+// ValidateMentions 校验消息中的提及列表，并返回需要写入提及索引的用户 id 列表（已去重且不包含发送者）
+func ValidateMentions(senderId, receiverId int64, mentions []int64, mentionAll bool) ([]int64, error) {
+	if len(mentions) == 0 && !mentionAll {
+		return nil, nil
+	}
+
+	if receiverId >= 0 {
+		return nil, ErrorMentionInPrivateChat
+	}
+
+	info, err := getGroupInfoFromCache(receiverId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[int64]struct{}, len(info.Members))
+	for _, member := range info.Members {
+		if !member.IsDeleted {
+			members[member.MemberId] = struct{}{}
+		}
+	}
+
+	if mentionAll {
+		if permissions, _ := info.PermissionsOf(senderId); !permissions.Has(entities.PermissionMentionAll) {
+			return nil, ErrorMentionAllNoAuth
+		}
+
+		ret := make([]int64, 0, len(members))
+		for memberId := range members {
+			if memberId != senderId {
+				ret = append(ret, memberId)
+			}
+		}
+		return ret, nil
+	}
+
+	ret := make([]int64, 0, len(mentions))
+	visited := make(map[int64]struct{}, len(mentions))
+	for _, userId := range mentions {
+		if _, ok := members[userId]; !ok {
+			return nil, ErrorMentionNotInGroup
+		}
+
+		if _, ok := visited[userId]; ok || userId == senderId {
+			continue
+		}
+		visited[userId] = struct{}{}
+		ret = append(ret, userId)
+	}
+	return ret, nil
+}
@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"liveChat/metrics"
+	"liveChat/rpc"
+	"path"
+)
+
+var (
+	connectionCount = metrics.NewGaugeVec("livechat_connections",
+		"本节点上的 WebSocket 连接数", "platform")
+	rpcCalls = metrics.NewCounterVec("livechat_grpc_client_calls_total",
+		"向其他节点发起的 rpc 调用次数", "peer", "method")
+	rpcErrors = metrics.NewCounterVec("livechat_grpc_client_errors_total",
+		"向其他节点发起的 rpc 调用失败次数", "peer", "method", "code")
+)
+
+func platformName(platform int) string {
+	return rpc.KickOffRequest_PlatformType(platform).String()
+}
+
+// observeRpcCall 按对端地址记录 rpc 调用次数与失败次数
+func observeRpcCall(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	method = path.Base(method)
+	rpcCalls.With(cc.Target(), method).Inc()
+	if err != nil {
+		rpcErrors.With(cc.Target(), method, status.Code(err).String()).Inc()
+	}
+	return err
+}
@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"errors"
+	"liveChat/db"
+	"time"
+)
+
+var (
+	ErrorUserMuted       = errors.New("用户在群组中被禁言")
+	ErrorUserGlobalMuted = errors.New("用户已被禁言")
+)
+
+// CheckIsUserMuted 检查用户能否在会话中发言，全局禁言作用于全部会话，群组禁言不影响私聊
+func CheckIsUserMuted(senderId, receiverId int64) error {
+	if isMuted, err := db.IsUserMuted(senderId); err != nil {
+		return err
+	} else if isMuted {
+		return ErrorUserGlobalMuted
+	}
+
+	if receiverId >= 0 {
+		return nil
+	}
+
+	info, err := getGroupInfoFromCache(receiverId, false)
+	if err != nil {
+		return err
+	}
+
+	if info.IsMutedAt(senderId, time.Now().UnixMilli()) {
+		return ErrorUserMuted
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"liveChat/config"
+	"liveChat/db"
+	"liveChat/log"
+	"time"
+)
+
+const defaultNotificationSweepInterval = 10 * time.Minute
+
+// InitNotificationExpiry 设置申请的有效期，并定期将超时未处理的申请关闭为已过期
+func InitNotificationExpiry(cfg config.NotificationConfig) {
+	expireAfter := time.Duration(cfg.ExpireAfterHours) * time.Hour
+	db.SetNotificationExpiry(expireAfter)
+	if expireAfter <= 0 {
+		return
+	}
+
+	interval := time.Duration(cfg.SweepIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultNotificationSweepInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		for range ticker.C {
+			if _, err := db.ExpirePendingNotifications(context.Background()); err != nil {
+				log.Error(fmt.Sprintf("关闭过期申请失败: %s", err.Error()))
+			}
+		}
+	}()
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"liveChat/config"
+	"liveChat/db"
+	"liveChat/entities"
+	"liveChat/log"
+	"time"
+)
+
+const defaultRetentionPurgeInterval = time.Hour
+
+var (
+	defaultRetentionPolicy entities.RetentionPolicy
+	isArchiveOnPurge       bool
+)
+
+// InitMessageRetention 设置全局默认的消息保留策略，并定期清理超出保留策略的消息
+func InitMessageRetention(cfg config.RetentionConfig) {
+	defaultRetentionPolicy = entities.RetentionPolicy{KeepDays: cfg.DefaultKeepDays, KeepLast: cfg.DefaultKeepLast}
+	isArchiveOnPurge = cfg.Archive
+
+	interval := time.Duration(cfg.PurgeIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultRetentionPurgeInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		for t := range ticker.C {
+			if err := PurgeExpiredMessages(t); err != nil {
+				log.Error(fmt.Sprintf("清理过期消息失败: %s", err.Error()))
+			}
+		}
+	}()
+}
+
+func GetDefaultRetentionPolicy() entities.RetentionPolicy {
+	return defaultRetentionPolicy
+}
+
+// PurgeExpiredMessages 遍历全部会话并清理超出保留策略的消息，单个会话清理失败时记录日志后继续
+func PurgeExpiredMessages(now time.Time) error {
+	return db.ScanChats(context.Background(), func(chat *entities.Chat) error {
+		if err := purgeChat(chat, now); err != nil {
+			log.Error(fmt.Sprintf("清理会话 %d 的消息失败: %s", chat.Id, err.Error()))
+		}
+		return nil
+	})
+}
+
+func purgeChat(chat *entities.Chat, now time.Time) error {
+	policy, err := GetChatRetentionPolicy(chat.Id)
+	if err != nil || policy.IsUnlimited() {
+		return err
+	}
+
+	purgeSeq := policy.PurgeSeqByCount(chat.Sequence)
+	if before := policy.ExpireBefore(now); before != 0 {
+		seq, err := db.GetLastMessageSeqBefore(context.Background(), chat.Id, before)
+		if err != nil {
+			return err
+		} else if seq > purgeSeq {
+			purgeSeq = seq
+		}
+	}
+
+	// 上次清理中途失败时，已标记为空洞的范围仍需继续删除
+	if purgeSeq < chat.PurgedSeq {
+		purgeSeq = chat.PurgedSeq
+	}
+	if purgeSeq <= chat.PurgeCompletedSeq {
+		return nil
+	}
+
+	if _, err = db.PurgeMessages(context.Background(), chat.Id, purgeSeq, isArchiveOnPurge); err != nil {
+		return err
+	}
+
+	// 缓存中的消息会被优先读取，缓存清除成功后才算清理完成，否则下次重试
+	if err = db.DeleteMessageCacheInRange(chat.Id, chat.PurgeCompletedSeq+1, purgeSeq); err != nil {
+		return err
+	}
+	return db.MarkPurgeCompleted(context.Background(), chat.Id, purgeSeq)
+}
+
+// GetChatRetentionPolicy 返回会话实际生效的保留策略。群聊使用群组的设置，
+// 私聊消息按接收者存放，使用接收者的设置
+func GetChatRetentionPolicy(chatId int64) (entities.RetentionPolicy, error) {
+	var custom *entities.RetentionPolicy
+	if chatId < 0 {
+		info, err := getGroupInfoFromCache(chatId, false)
+		if err != nil {
+			return entities.RetentionPolicy{}, err
+		}
+		custom = info.RetentionPolicy
+	} else {
+		var err error
+		if custom, err = db.GetUserRetentionPolicy(nil, chatId); err != nil {
+			return entities.RetentionPolicy{}, err
+		}
+	}
+	return entities.ResolveRetentionPolicy(defaultRetentionPolicy, custom), nil
+}
@@ -1,8 +1,11 @@
 package controllers
 
 import (
+	"context"
+	"crypto/tls"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"liveChat/db"
 	"liveChat/log"
 	"liveChat/rpc"
@@ -20,13 +23,22 @@ type connectionEntry struct {
 var (
 	rpcConnections []connectionEntry
 	opRpcLock      sync.RWMutex
+
+	dialCredentials grpc.DialOption
 )
 
 func newRpcConnection(id int64, conn *grpc.ClientConn) connectionEntry {
 	return connectionEntry{id, conn}
 }
 
-func InitServerInterconnection(etcdUrls []string, serverHost string) {
+// InitServerInterconnection tlsConfig 不为 nil 时使用双向 TLS 连接其他节点
+func InitServerInterconnection(etcdUrls []string, serverHost string, tlsConfig *tls.Config) {
+	if tlsConfig != nil {
+		dialCredentials = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	} else {
+		dialCredentials = grpc.WithInsecure()
+	}
+
 	db.InitEtcd(etcdUrls)
 	db.RegisterService(serverHost)
 	keys, values, err := db.GetAllKV(db.EtcdNodePrefix)
@@ -50,6 +62,58 @@ func GetAllServerClients() (ret []rpc.ServerNodeClient) {
 	return
 }
 
+// KickUserOff 断开用户在所有节点、所有平台上的连接，失败时只记录日志
+func KickUserOff(userId int64) {
+	for platform := range rpc.KickOffRequest_PlatformType_name {
+		DeleteConnection(userId, int(platform))
+		for _, c := range GetAllServerClients() {
+			ctx, cfn := context.WithTimeout(context.Background(), time.Second*3)
+			_, err := c.KickUserOffOnSpecificPlatform(ctx, &rpc.KickOffRequest{
+				UserId:   userId,
+				Platform: rpc.KickOffRequest_PlatformType(platform),
+			})
+			if err != nil {
+				log.Error(err.Error())
+			}
+			cfn()
+		}
+	}
+}
+
+// NodeConnections 为用户在某个节点上的连接，Error 不为空时表示查询该节点失败
+type NodeConnections struct {
+	NodeId      int64
+	Connections []*rpc.ConnectionInfo
+	Error       string
+}
+
+// ListUserConnections 通过 rpc 查询用户在各个节点上的连接，包括本节点
+func ListUserConnections(userId int64) []NodeConnections {
+	opRpcLock.RLock()
+	entries := make([]connectionEntry, len(rpcConnections))
+	copy(entries, rpcConnections)
+	opRpcLock.RUnlock()
+
+	ret := make([]NodeConnections, 0, len(entries))
+	for _, entry := range entries {
+		ctx, cfn := context.WithTimeout(context.Background(), time.Second*3)
+		resp, err := rpc.NewServerNodeClient(entry.conn).ListUserConnections(ctx, &rpc.ConnectionsRequest{UserId: userId})
+		cfn()
+
+		nodeConnections := NodeConnections{NodeId: entry.id}
+		if err != nil {
+			log.Error(err.Error())
+			nodeConnections.Error = err.Error()
+		} else if len(resp.Connections) == 0 {
+			continue
+		} else {
+			nodeConnections.Connections = resp.Connections
+		}
+		ret = append(ret, nodeConnections)
+	}
+	return ret
+}
+
 func watchHook(response clientv3.WatchResponse) {
 	for _, event := range response.Events {
 		switch event.Type {
@@ -80,7 +144,7 @@ func generateServerList(keys, values []string) (failedKeys, failedValues []strin
 	for i := 0; i < len(keys); i++ {
 		nodeId := stripNodeIdFromKey(keys[i])
 		host := values[i]
-		conn, err := grpc.Dial(host, grpc.WithInsecure())
+		conn, err := grpc.Dial(host, dialCredentials, grpc.WithChainUnaryInterceptor(observeRpcCall))
 		if err != nil {
 			log.Error(err.Error())
 			failedKeys = append(failedKeys, keys[i])
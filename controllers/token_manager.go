@@ -14,3 +14,7 @@ func GetToken(userId int64) (token string, err error) {
 func GetUserIdByToken(token string) (userId int64, err error) {
 	return db.RedisCheckAndResetToken(token)
 }
+
+func RevokeToken(userId int64) error {
+	return db.RevokeToken(userId)
+}
@@ -64,6 +64,7 @@ func (kgc kafkaGroupConsumer) Cleanup(session sarama.ConsumerGroupSession) error
 
 func (kgc kafkaGroupConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	for msg := range claim.Messages() {
+		observeKafkaConsume(claim, msg)
 		kgc.asyncHandleChan[msg.Offset%kgc.asyncHandlerNumber] <- msg
 		session.MarkMessage(msg, "")
 	}
@@ -107,6 +108,8 @@ func NewKafkaAsyncProducer(addresses []string) (*KafkaAsyncProducer, error) {
 
 func (ap *KafkaAsyncProducer) AsyncSendMessage(userId int64, bytes []byte) {
 	key := strconv.FormatInt(userId, 10)
+	start := time.Now()
 	ap.producer.Input() <- &sarama.ProducerMessage{Topic: ap.topic, Key: sarama.StringEncoder(key), Value: sarama.ByteEncoder(bytes)}
 	<-ap.producer.Successes()
+	kafkaProduceSeconds.With().ObserveSince(start)
 }
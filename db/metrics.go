@@ -0,0 +1,133 @@
+package db
+
+import (
+	"context"
+	"github.com/Shopify/sarama"
+	"github.com/go-redis/redis/v8"
+	"go.mongodb.org/mongo-driver/event"
+	"gorm.io/gorm"
+	"liveChat/metrics"
+	"strconv"
+	"time"
+)
+
+const (
+	CacheMessage    = "message"
+	CacheFriendship = "friendship"
+	CacheGroup      = "group"
+)
+
+const (
+	databaseMysql = "mysql"
+	databaseMongo = "mongo"
+	databaseRedis = "redis"
+
+	mysqlStartTimeKey = "metrics:start"
+)
+
+var (
+	dbOperationSeconds = metrics.NewHistogramVec("livechat_db_operation_seconds",
+		"数据库操作耗时", nil, "database", "operation")
+	cacheRequests = metrics.NewCounterVec("livechat_cache_requests_total",
+		"缓存查询次数，result 为 hit 或 miss", "cache", "result")
+	kafkaProduceSeconds = metrics.NewHistogramVec("livechat_kafka_produce_seconds",
+		"消息写入 Kafka 至收到确认的耗时", nil)
+	kafkaConsumeLag = metrics.NewGaugeVec("livechat_kafka_consume_lag",
+		"分区中尚未被本节点消费的消息数", "topic", "partition")
+	kafkaConsumeDelaySeconds = metrics.NewHistogramVec("livechat_kafka_consume_delay_seconds",
+		"消息写入 Kafka 至被消费的时间", nil, "topic")
+)
+
+// RecordCacheAccess 记录一次缓存查询是否命中
+func RecordCacheAccess(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheRequests.With(cache, result).Inc()
+}
+
+func observeKafkaConsume(claim sarama.ConsumerGroupClaim, msg *sarama.ConsumerMessage) {
+	kafkaConsumeLag.With(msg.Topic, strconv.FormatInt(int64(msg.Partition), 10)).
+		Set(float64(claim.HighWaterMarkOffset() - msg.Offset - 1))
+	if !msg.Timestamp.IsZero() {
+		kafkaConsumeDelaySeconds.With(msg.Topic).ObserveSince(msg.Timestamp)
+	}
+}
+
+// registerMysqlMetrics 通过 gorm 回调记录各类操作的耗时
+func registerMysqlMetrics(db *gorm.DB) error {
+	before := func(tx *gorm.DB) {
+		tx.InstanceSet(mysqlStartTimeKey, time.Now())
+	}
+	after := func(operation string) func(tx *gorm.DB) {
+		return func(tx *gorm.DB) {
+			if start, ok := tx.InstanceGet(mysqlStartTimeKey); ok {
+				dbOperationSeconds.With(databaseMysql, operation).ObserveSince(start.(time.Time))
+			}
+		}
+	}
+
+	callback := db.Callback()
+	for _, err := range []error{
+		callback.Create().Before("gorm:create").Register("metrics:before_create", before),
+		callback.Create().After("gorm:create").Register("metrics:after_create", after("create")),
+		callback.Query().Before("gorm:query").Register("metrics:before_query", before),
+		callback.Query().After("gorm:query").Register("metrics:after_query", after("query")),
+		callback.Update().Before("gorm:update").Register("metrics:before_update", before),
+		callback.Update().After("gorm:update").Register("metrics:after_update", after("update")),
+		callback.Delete().Before("gorm:delete").Register("metrics:before_delete", before),
+		callback.Delete().After("gorm:delete").Register("metrics:after_delete", after("delete")),
+		callback.Row().Before("gorm:row").Register("metrics:before_row", before),
+		callback.Row().After("gorm:row").Register("metrics:after_row", after("row")),
+		callback.Raw().Before("gorm:raw").Register("metrics:before_raw", before),
+		callback.Raw().After("gorm:raw").Register("metrics:after_raw", after("raw")),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newMongoCommandMonitor 按命令名记录 MongoDB 操作的耗时，失败的命令同样计入
+func newMongoCommandMonitor() *event.CommandMonitor {
+	observe := func(e event.CommandFinishedEvent) {
+		dbOperationSeconds.With(databaseMongo, e.CommandName).Observe(time.Duration(e.DurationNanos).Seconds())
+	}
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			observe(e.CommandFinishedEvent)
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			observe(e.CommandFinishedEvent)
+		},
+	}
+}
+
+type redisStartTimeKey struct{}
+
+// redisMetricsHook 按命令名记录 Redis 操作的耗时，管道操作记为 pipeline
+type redisMetricsHook struct{}
+
+func (redisMetricsHook) BeforeProcess(ctx context.Context, _ redis.Cmder) (context.Context, error) {
+	return context.WithValue(ctx, redisStartTimeKey{}, time.Now()), nil
+}
+
+func (redisMetricsHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	if start, ok := ctx.Value(redisStartTimeKey{}).(time.Time); ok {
+		dbOperationSeconds.With(databaseRedis, cmd.Name()).ObserveSince(start)
+	}
+	return nil
+}
+
+func (redisMetricsHook) BeforeProcessPipeline(ctx context.Context, _ []redis.Cmder) (context.Context, error) {
+	return context.WithValue(ctx, redisStartTimeKey{}, time.Now()), nil
+}
+
+func (redisMetricsHook) AfterProcessPipeline(ctx context.Context, _ []redis.Cmder) error {
+	if start, ok := ctx.Value(redisStartTimeKey{}).(time.Time); ok {
+		dbOperationSeconds.With(databaseRedis, "pipeline").ObserveSince(start)
+	}
+	return nil
+}
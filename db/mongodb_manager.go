@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -34,13 +35,28 @@ var (
 	notificationCollection *mongo.Collection
 	// 存放某个对象最新通知序号的集合对象
 	notiSeqCollection *mongo.Collection
+	// 存放用户被提及记录的集合对象
+	mentionCollection *mongo.Collection
+	// 存放 webhook 投递记录的集合对象
+	webhookDeliveryCollection *mongo.Collection
+	// 存放会话置顶消息的集合对象
+	pinnedMessageCollection *mongo.Collection
+	// 存放按保留策略归档的消息的集合对象
+	messageArchiveCollection *mongo.Collection
 
 	isMongodbInitiated bool = false
 )
 
 const (
-	mongoQueueCollectionName   = "queue"
-	mongoMessageCollectionName = "message"
+	mongoQueueCollectionName        = "queue"
+	mongoMessageCollectionName      = "message"
+	mongoNotificationCollectionName = "notification"
+	mongoNotiSeqCollectionName      = "notification_sequence"
+	mongoMentionCollectionName      = "mention"
+	mongoWebhookCollectionName      = "webhook_delivery"
+	mongoPinnedCollectionName       = "pinned_message"
+	mongoArchiveCollectionName      = "message_archive"
+	mongoExportBucketName           = "export"
 )
 
 const (
@@ -49,8 +65,10 @@ const (
 	mongoDbPush        = "$push"
 	mongoDbPull        = "$pull"
 	mongoDbSetInInsert = "$setOnInsert"
+	mongoDbMax         = "$max"
 
 	mongoDbIn           = "$in"
+	mongoDbNotEqual     = "$ne"
 	mongoDbMatch        = "$match"
 	mongoDbAnd          = "$and"
 	mongoDbOr           = "$or"
@@ -58,20 +76,56 @@ const (
 	mongoDbGreaterEqual = "$gte"
 	mongoDbLess         = "$lt"
 	mongoDbLessEqual    = "$lte"
+
+	mongoDbGroup = "$group"
+	mongoDbSum   = "$sum"
+	mongoDbMin   = "$min"
+
+	mongoDbText   = "$text"
+	mongoDbSearch = "$search"
 )
 
 const (
-	ChatId       = "id"
-	ChatSequence = "sequence"
-
-	MessageId       = "id"
-	MessageReceiver = "receiver"
+	ChatId                     = "id"
+	ChatSequence               = "sequence"
+	ChatPurgedSequence         = "purged_sequence"
+	ChatPurgeCompletedSequence = "purge_completed_sequence"
+
+	MessageId        = "id"
+	MessageReceiver  = "receiver"
+	MessageSender    = "sender"
+	MessageTimestamp = "timestamp"
+	MessageContent   = "content"
+	MessageType      = "type"
 
 	NotificationId           = "receiver_id"
 	NotificationSequence     = "sequence"
+	NotificationSenderId     = "sender_id"
+	NotificationTimestamp    = "timestamp"
+	NotificationOpType       = "op_type"
+	NotificationReceiveType  = "receive_type"
 	NotificationHandleUserId = "handle_user_id"
 	NotificationIsHandled    = "is_handled"
 	NotificationIsAgree      = "is_agree"
+	NotificationIsExpired    = "is_expired"
+	NotificationMessage      = "message"
+
+	MentionUserId    = "user_id"
+	MentionChatId    = "chat_id"
+	MentionSequence  = "sequence"
+	MentionTimestamp = "timestamp"
+	MentionIsRead    = "is_read"
+
+	WebhookDeliveryId             = "_id"
+	WebhookDeliverySubscriptionId = "subscription_id"
+	WebhookDeliveryStatus         = "status"
+	WebhookDeliveryCreatedAt      = "created_at"
+	WebhookDeliveryUpdatedAt      = "updated_at"
+
+	PinnedMessageChatId    = "chat_id"
+	PinnedMessageSequence  = "sequence"
+	PinnedMessagePeerId    = "peer_id"
+	PinnedMessageTimestamp = "timestamp"
 )
 
 const (
@@ -82,9 +136,20 @@ const (
 const chatTypeMask = 1 << 63
 
 var (
-	MongoErrorNoNotification = errors.New("无匹配通知")
+	MongoErrorNoNotification      = errors.New("无匹配通知")
+	MongoErrorNotificationExpired = errors.New("通知已过期")
+	MongoErrorNoDelivery          = errors.New("无匹配投递记录")
+	MongoErrorMessagePinned       = errors.New("消息已置顶")
+	MongoErrorNoPinned            = errors.New("消息未置顶")
 )
 
+// 申请类通知的有效期，不大于 0 时申请不会过期
+var notificationExpireAfter time.Duration
+
+func SetNotificationExpiry(expireAfter time.Duration) {
+	notificationExpireAfter = expireAfter
+}
+
 func InitMongoDBConnection(url, databaseName string) {
 	if isMongodbInitiated {
 		return
@@ -93,7 +158,7 @@ func InitMongoDBConnection(url, databaseName string) {
 	mongoDbDatabaseName = databaseName
 
 	var err error
-	mongoConnection, err = mongo.Connect(context.TODO(), options.Client().ApplyURI(url), getDefaultMongoConcern())
+	mongoConnection, err = mongo.Connect(context.TODO(), options.Client().ApplyURI(url).SetMonitor(newMongoCommandMonitor()), getDefaultMongoConcern())
 	if err != nil {
 		panic(err)
 	}
@@ -155,21 +220,36 @@ func GetAndAddChatSequence(ctx context.Context, chatId int64) (uint64, error) {
 	return chat.Sequence, nil
 }
 
-func GetMessageInSeqRange(ctx context.Context, chatId int64, bottom, top uint64) ([]entities.Message, error) {
+// GetMessageInSeqRange 返回 [bottom, top] 范围内的消息，范围内已按保留策略清理的部分以 gap 返回，没有时 gap 为 nil
+func GetMessageInSeqRange(ctx context.Context, chatId int64, bottom, top uint64) ([]entities.Message, *entities.MessageGap, error) {
+	purgedSeq, err := GetChatPurgedSequence(ctx, chatId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gap := entities.PurgedGapIn(bottom, top, purgedSeq)
+	if gap != nil {
+		bottom = gap.TopId + 1
+	}
+
+	messageSlice := make([]entities.Message, 0)
+	if bottom > top {
+		return messageSlice, gap, nil
+	}
+
 	cursor, err := messageCollection.Find(ctx,
 		bson.D{{MessageReceiver, chatId}, {MessageId, bson.D{{mongoDbGreaterEqual, bottom}, {mongoDbLessEqual, top}}}},
 		nil,
 	)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	messageSlice := make([]entities.Message, 0)
 	if err = decodeDataInCursor(cursor, &messageSlice); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return messageSlice, nil
+	return messageSlice, gap, nil
 }
 
 func GetMessageInSeq(ctx context.Context, chatId int64, seq uint64) (*entities.Message, error) {
@@ -180,6 +260,171 @@ func GetMessageInSeq(ctx context.Context, chatId int64, seq uint64) (*entities.M
 	return message, nil
 }
 
+// GetChatPurgedSequence 返回会话已按保留策略清理到的序号，会话不存在或未清理过时返回 0
+func GetChatPurgedSequence(ctx context.Context, chatId int64) (uint64, error) {
+	chat := entities.NewEmptyChat()
+	if err := findDocumentOne(ctx, getBson(ChatId, chatId), queueCollection, chat); err == mongo.ErrNoDocuments {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return chat.PurgedSeq, nil
+}
+
+// ScanChats 遍历全部会话，用于按保留策略清理消息
+func ScanChats(ctx context.Context, fn func(chat *entities.Chat) error) error {
+	cursor, err := queueCollection.Find(ctx, bson.D{}, nil)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		chat := entities.NewEmptyChat()
+		if err = cursor.Decode(chat); err != nil {
+			return err
+		}
+		if err = fn(chat); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// GetLastMessageSeqBefore 返回会话中时间早于 timestamp 的消息的最大序号，没有时返回 0
+func GetLastMessageSeqBefore(ctx context.Context, chatId int64, timestamp uint64) (uint64, error) {
+	message := entities.NewEmptyMessage()
+	err := messageCollection.FindOne(ctx,
+		bson.D{{MessageReceiver, chatId}, {MessageTimestamp, bson.D{{mongoDbLess, timestamp}}}},
+		options.FindOne().SetSort(bson.D{{MessageId, -1}}),
+	).Decode(message)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return message.Id, nil
+}
+
+const purgeBatchSize = 1000
+
+var purgeHooks []func(chatId int64, purgedSeq uint64)
+
+// RegisterPurgeHook 注册消息被清理后的回调，需要在服务启动时调用
+func RegisterPurgeHook(hook func(chatId int64, purgedSeq uint64)) {
+	purgeHooks = append(purgeHooks, hook)
+}
+
+// PurgeMessages 清理会话中序号不大于 seq 的消息及其提及与置顶记录，archive 为真时先将消息转存到归档集合。
+// 清理位置会先写入会话记录，此后读取该范围时按空洞返回，即使清理中途失败也不会读到部分数据。
+// 完成位置由调用方在清除缓存等后续步骤成功后通过 MarkPurgeCompleted 写入，中途失败时下次以相同的 seq 重试
+func PurgeMessages(ctx context.Context, chatId int64, seq uint64, archive bool) (int64, error) {
+	if _, err := queueCollection.UpdateOne(ctx, getBson(ChatId, chatId), getOpBson(mongoDbMax, ChatPurgedSequence, seq)); err != nil {
+		return 0, err
+	}
+
+	var (
+		deleted int64
+		filter  = bson.D{{MessageReceiver, chatId}, {MessageId, bson.D{{mongoDbLessEqual, seq}}}}
+	)
+	if archive {
+		for {
+			count, err := archiveMessages(ctx, chatId, seq)
+			if err != nil {
+				return deleted, err
+			} else if count == 0 {
+				break
+			}
+			deleted += count
+		}
+	} else {
+		result, err := messageCollection.DeleteMany(ctx, filter)
+		if err != nil {
+			return 0, err
+		}
+		deleted = result.DeletedCount
+	}
+
+	if _, err := mentionCollection.DeleteMany(ctx, bson.D{{MentionChatId, chatId}, {MentionSequence, bson.D{{mongoDbLessEqual, seq}}}}); err != nil {
+		return deleted, err
+	}
+	if _, err := pinnedMessageCollection.DeleteMany(ctx, bson.D{{PinnedMessageChatId, chatId}, {PinnedMessageSequence, bson.D{{mongoDbLessEqual, seq}}}}); err != nil {
+		return deleted, err
+	}
+
+	for _, hook := range purgeHooks {
+		hook(chatId, seq)
+	}
+	return deleted, nil
+}
+
+// MarkPurgeCompleted 记录会话中序号不大于 seq 的消息已全部清理完成，包括数据库与缓存
+func MarkPurgeCompleted(ctx context.Context, chatId int64, seq uint64) error {
+	_, err := queueCollection.UpdateOne(ctx, getBson(ChatId, chatId), getOpBson(mongoDbMax, ChatPurgeCompletedSequence, seq))
+	return err
+}
+
+var deleteHooks []func(chatId int64, seq uint64)
+
+// RegisterDeleteHook 注册单条消息被删除后的回调，需要在服务启动时调用
+func RegisterDeleteHook(hook func(chatId int64, seq uint64)) {
+	deleteHooks = append(deleteHooks, hook)
+}
+
+// DeleteMessage 删除会话中的一条消息及其提及与置顶记录，消息不存在时返回 mongo.ErrNoDocuments
+func DeleteMessage(ctx context.Context, chatId int64, seq uint64) error {
+	result, err := messageCollection.DeleteOne(ctx, bson.D{{MessageReceiver, chatId}, {MessageId, seq}})
+	if err != nil {
+		return err
+	} else if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	if _, err = mentionCollection.DeleteMany(ctx, bson.D{{MentionChatId, chatId}, {MentionSequence, seq}}); err != nil {
+		return err
+	}
+	if _, err = pinnedMessageCollection.DeleteMany(ctx, bson.D{{PinnedMessageChatId, chatId}, {PinnedMessageSequence, seq}}); err != nil {
+		return err
+	}
+
+	for _, hook := range deleteHooks {
+		hook(chatId, seq)
+	}
+	return nil
+}
+
+// archiveMessages 将一批待清理的消息转存到归档集合后删除，返回本批处理的数量
+func archiveMessages(ctx context.Context, chatId int64, seq uint64) (int64, error) {
+	cursor, err := messageCollection.Find(ctx,
+		bson.D{{MessageReceiver, chatId}, {MessageId, bson.D{{mongoDbLessEqual, seq}}}},
+		options.Find().SetSort(bson.D{{MessageId, 1}}).SetLimit(purgeBatchSize),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	messageSlice := make([]entities.Message, 0)
+	if err = decodeDataInCursor(cursor, &messageSlice); err != nil || len(messageSlice) == 0 {
+		return 0, err
+	}
+
+	docs := make([]interface{}, len(messageSlice))
+	for i := range messageSlice {
+		docs[i] = &messageSlice[i]
+	}
+	// 上次归档中途失败时部分消息可能已经存在于归档集合中
+	if _, err = messageArchiveCollection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false)); err != nil && !mongo.IsDuplicateKeyError(err) {
+		return 0, err
+	}
+
+	lastSeq := messageSlice[len(messageSlice)-1].Id
+	result, err := messageCollection.DeleteMany(ctx, bson.D{{MessageReceiver, chatId}, {MessageId, bson.D{{mongoDbLessEqual, lastSeq}}}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
 func CreateMessageWithSeq(ctx context.Context, m *rpc.Message) (*entities.Message, error) {
 	seq, err := GetAndAddChatSequence(ctx, m.Receiver)
 	if err != nil {
@@ -191,6 +436,7 @@ func CreateMessageWithSeq(ctx context.Context, m *rpc.Message) (*entities.Messag
 	return message, nil
 }
 
+// AddMessage 在消息入库后会将分配到的序号回写到 m.Id 中，以便后续的分发与缓存
 func AddMessage(ctx context.Context, m *rpc.Message) error {
 	message, err := CreateMessageWithSeq(ctx, m)
 	if err != nil {
@@ -199,6 +445,311 @@ func AddMessage(ctx context.Context, m *rpc.Message) error {
 	if err = insertDocumentOne(ctx, message, messageCollection); err != nil {
 		return err
 	}
+	m.Id = message.Id
+
+	for _, hook := range messageHooks {
+		hook(message)
+	}
+	return nil
+}
+
+var messageHooks []func(m *entities.Message)
+
+// RegisterMessageHook 注册消息入库后的回调，需要在服务启动时调用，回调中不应阻塞
+func RegisterMessageHook(hook func(m *entities.Message)) {
+	messageHooks = append(messageHooks, hook)
+}
+
+// MessageFilter 描述消息检索的范围。UserId 与 GroupIds 限定了用户有权访问的会话，
+// ChatId 为 0 时不限定会话，Sender 为 0 时不限定发送者，From 与 To 为 0 时不限定时间
+type MessageFilter struct {
+	Keyword  string
+	UserId   int64
+	GroupIds []int64
+	ChatId   int64
+	Sender   int64
+	From     uint64
+	To       uint64
+}
+
+func EnsureMessageTextIndex(ctx context.Context) error {
+	_, err := messageCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{MessageContent, "text"}},
+	})
+	return err
+}
+
+func SearchMessageByText(ctx context.Context, filter *MessageFilter, skip, limit int64) ([]entities.Message, error) {
+	// 加密消息的内容为密文，不参与搜索
+	cond := bson.D{{mongoDbText, bson.D{{mongoDbSearch, filter.Keyword}}}, {MessageType, bson.D{{mongoDbNotEqual, entities.Encrypted}}}}
+	cond = append(cond, generateMessageFilterBson(filter)...)
+
+	cursor, err := messageCollection.Find(ctx, cond,
+		options.Find().SetSort(bson.D{{MessageTimestamp, -1}, {MessageId, -1}}).SetSkip(skip).SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	messageSlice := make([]entities.Message, 0)
+	if err = decodeDataInCursor(cursor, &messageSlice); err != nil {
+		return nil, err
+	}
+	return messageSlice, nil
+}
+
+// ScanMessages 按写入顺序遍历全部消息，用于重建内存索引
+func ScanMessages(ctx context.Context, fn func(m *entities.Message) error) error {
+	cursor, err := messageCollection.Find(ctx, bson.D{}, nil)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		message := entities.NewEmptyMessage()
+		if err = cursor.Decode(message); err != nil {
+			return err
+		}
+		if err = fn(message); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func generateMessageFilterBson(filter *MessageFilter) bson.D {
+	var scope bson.A
+	if filter.ChatId < 0 {
+		scope = bson.A{bson.D{{MessageReceiver, filter.ChatId}}}
+	} else if filter.ChatId > 0 {
+		scope = bson.A{
+			bson.D{{MessageSender, filter.UserId}, {MessageReceiver, filter.ChatId}},
+			bson.D{{MessageSender, filter.ChatId}, {MessageReceiver, filter.UserId}},
+		}
+	} else {
+		scope = bson.A{
+			bson.D{{MessageReceiver, filter.UserId}},
+			bson.D{{MessageSender, filter.UserId}, {MessageReceiver, bson.D{{mongoDbGreater, 0}}}},
+		}
+		if len(filter.GroupIds) != 0 {
+			scope = append(scope, bson.D{{MessageReceiver, bson.D{{mongoDbIn, filter.GroupIds}}}})
+		}
+	}
+
+	cond := bson.D{{mongoDbOr, scope}}
+	if filter.Sender != 0 {
+		cond = append(cond, bson.E{Key: MessageSender, Value: filter.Sender})
+	}
+
+	if filter.From != 0 || filter.To != 0 {
+		timeCond := bson.D{}
+		if filter.From != 0 {
+			timeCond = append(timeCond, bson.E{Key: mongoDbGreaterEqual, Value: filter.From})
+		}
+		if filter.To != 0 {
+			timeCond = append(timeCond, bson.E{Key: mongoDbLessEqual, Value: filter.To})
+		}
+		cond = append(cond, bson.E{Key: MessageTimestamp, Value: timeCond})
+	}
+	return cond
+}
+
+func AddMentions(ctx context.Context, m *entities.Message, userIds []int64) error {
+	if len(userIds) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(userIds), len(userIds))
+	for i, userId := range userIds {
+		docs[i] = entities.NewMention(userId, m)
+	}
+
+	_, err := mentionCollection.InsertMany(ctx, docs, nil)
+	return err
+}
+
+// GetMentionsForUser 按时间倒序返回用户被提及的记录，chatId 为 0 时不限定会话，beforeTimestamp 为 0 时从最新的记录开始。
+// 返回的 nextTimestamp 作为下一页的 beforeTimestamp，为 0 时没有更多记录
+func GetMentionsForUser(ctx context.Context, userId, chatId int64, onlyUnread bool, beforeTimestamp uint64, limit int64) (mentions []entities.Mention, nextTimestamp uint64, err error) {
+	filter := bson.D{{MentionUserId, userId}}
+	if chatId != 0 {
+		filter = append(filter, bson.E{Key: MentionChatId, Value: chatId})
+	}
+	if onlyUnread {
+		filter = append(filter, bson.E{Key: MentionIsRead, Value: false})
+	}
+
+	cond := filter
+	if beforeTimestamp != 0 {
+		cond = append(cond[:len(cond):len(cond)], bson.E{Key: MentionTimestamp, Value: bson.D{{mongoDbLess, beforeTimestamp}}})
+	}
+
+	sort := bson.D{{MentionTimestamp, -1}, {MentionChatId, -1}, {MentionSequence, -1}}
+	cursor, err := mentionCollection.Find(ctx, cond, options.Find().SetSort(sort).SetLimit(limit))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	mentions = make([]entities.Mention, 0)
+	if err = decodeDataInCursor(cursor, &mentions); err != nil {
+		return nil, 0, err
+	}
+	if int64(len(mentions)) < limit || len(mentions) == 0 {
+		return mentions, 0, nil
+	}
+
+	// 游标只有时间戳，同一时间戳的记录必须在同一页返回，否则下一页会跳过它们
+	last := mentions[len(mentions)-1].Timestamp
+	cond = append(filter[:len(filter):len(filter)], bson.E{Key: MentionTimestamp, Value: last})
+	cursor, err = mentionCollection.Find(ctx, cond, options.Find().SetSort(sort))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sameTime := make([]entities.Mention, 0)
+	if err = decodeDataInCursor(cursor, &sameTime); err != nil {
+		return nil, 0, err
+	}
+
+	i := len(mentions)
+	for i > 0 && mentions[i-1].Timestamp == last {
+		i--
+	}
+	return append(mentions[:i], sameTime...), last, nil
+}
+
+func CountUnreadMentions(ctx context.Context, userId int64) ([]entities.MentionCount, error) {
+	cursor, err := mentionCollection.Aggregate(ctx, mongo.Pipeline{
+		bson.D{{mongoDbMatch, bson.D{{MentionUserId, userId}, {MentionIsRead, false}}}},
+		bson.D{{mongoDbGroup, bson.D{
+			{"_id", "$" + MentionChatId},
+			{"unread_count", bson.D{{mongoDbSum, 1}}},
+			{"first_unread_seq", bson.D{{mongoDbMin, "$" + MentionSequence}}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]entities.MentionCount, 0)
+	if err = decodeDataInCursor(cursor, &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// ReadMentions 将会话内序号不大于 seq 的提及全部标记为已读
+func ReadMentions(ctx context.Context, userId, chatId int64, seq uint64) (int64, error) {
+	result, err := mentionCollection.UpdateMany(ctx,
+		bson.D{{MentionUserId, userId}, {MentionChatId, chatId}, {MentionSequence, bson.D{{mongoDbLessEqual, seq}}}, {MentionIsRead, false}},
+		getOpBson(mongoDbSet, MentionIsRead, true),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func SaveWebhookDelivery(ctx context.Context, d *entities.WebhookDelivery) error {
+	_, err := webhookDeliveryCollection.ReplaceOne(ctx, getBson(WebhookDeliveryId, d.Id), d, options.Replace().SetUpsert(true))
+	return err
+}
+
+func GetWebhookDelivery(ctx context.Context, id string) (*entities.WebhookDelivery, error) {
+	d := &entities.WebhookDelivery{}
+	if err := findDocumentOne(ctx, getBson(WebhookDeliveryId, id), webhookDeliveryCollection, d); err == mongo.ErrNoDocuments {
+		return nil, MongoErrorNoDelivery
+	} else if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// SwapWebhookDelivery 仅在记录的状态与更新时间仍为 status 与 updatedAt 时保存 d，返回是否保存成功，
+// 用于多个节点同时认领同一条投递记录时只有一个节点成功
+func SwapWebhookDelivery(ctx context.Context, d *entities.WebhookDelivery, status string, updatedAt int64) (bool, error) {
+	result, err := webhookDeliveryCollection.ReplaceOne(ctx,
+		bson.D{{WebhookDeliveryId, d.Id}, {WebhookDeliveryStatus, status}, {WebhookDeliveryUpdatedAt, updatedAt}}, d)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount == 1, nil
+}
+
+// GetStaleWebhookDeliveries 按更新时间顺序返回更新时间早于 updatedBefore 的待投递记录
+func GetStaleWebhookDeliveries(ctx context.Context, updatedBefore, limit int64) ([]entities.WebhookDelivery, error) {
+	cursor, err := webhookDeliveryCollection.Find(ctx,
+		bson.D{{WebhookDeliveryStatus, entities.WebhookDeliveryPending}, {WebhookDeliveryUpdatedAt, bson.D{{mongoDbLess, updatedBefore}}}},
+		options.Find().SetSort(bson.D{{WebhookDeliveryUpdatedAt, 1}}).SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]entities.WebhookDelivery, 0)
+	if err = decodeDataInCursor(cursor, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetWebhookDeliveries 按创建时间倒序返回投递记录，status 为空或 subscriptionId 为 0 时不作对应限定
+func GetWebhookDeliveries(ctx context.Context, status string, subscriptionId, skip, limit int64) ([]entities.WebhookDelivery, error) {
+	filter := bson.D{}
+	if status != "" {
+		filter = append(filter, bson.E{Key: WebhookDeliveryStatus, Value: status})
+	}
+	if subscriptionId != 0 {
+		filter = append(filter, bson.E{Key: WebhookDeliverySubscriptionId, Value: subscriptionId})
+	}
+
+	cursor, err := webhookDeliveryCollection.Find(ctx, filter,
+		options.Find().SetSort(bson.D{{WebhookDeliveryCreatedAt, -1}}).SetSkip(skip).SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]entities.WebhookDelivery, 0)
+	if err = decodeDataInCursor(cursor, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func getExportBucket() (*gridfs.Bucket, error) {
+	return gridfs.NewBucket(mongoConnection.Database(mongoDbDatabaseName), options.GridFSBucket().SetName(mongoExportBucketName))
+}
+
+// CreateExportFile 以导出任务 id 为文件 id 创建导出文件，同 id 的旧文件会先被删除。写入失败时需调用 Abort
+func CreateExportFile(jobId int64, name string) (*gridfs.UploadStream, error) {
+	if err := DeleteExportFile(jobId); err != nil {
+		return nil, err
+	}
+
+	bucket, err := getExportBucket()
+	if err != nil {
+		return nil, err
+	}
+	return bucket.OpenUploadStreamWithID(jobId, name)
+}
+
+// OpenExportFile 返回导出文件的读取流，文件大小可通过 GetFile().Length 获取
+func OpenExportFile(jobId int64) (*gridfs.DownloadStream, error) {
+	bucket, err := getExportBucket()
+	if err != nil {
+		return nil, err
+	}
+	return bucket.OpenDownloadStream(jobId)
+}
+
+// DeleteExportFile 删除导出文件，文件不存在时不返回错误
+func DeleteExportFile(jobId int64) error {
+	bucket, err := getExportBucket()
+	if err != nil {
+		return err
+	}
+	if err = bucket.Delete(jobId); err != nil && err != gridfs.ErrFileNotFound {
+		return err
+	}
 	return nil
 }
 
@@ -236,20 +787,26 @@ func GetNotificationInSeq(ctx context.Context, receiverId int64, seq uint64) (*e
 	return noti, nil
 }
 
+// HandleNotification 处理通知，已过期的申请不能再被处理
 func HandleNotification(ctx context.Context, receiverId, handleUserId int64, seq uint64, isAgree bool) (*entities.Notification, error) {
-	result := notificationCollection.FindOneAndUpdate(
+	noti, err := GetNotificationInSeq(ctx, receiverId, seq)
+	if err == mongo.ErrNoDocuments {
+		return nil, MongoErrorNoNotification
+	} else if err != nil {
+		return nil, err
+	} else if noti.IsExpiredAt(time.Now().Unix(), int64(notificationExpireAfter/time.Second)) {
+		return nil, MongoErrorNotificationExpired
+	}
+
+	result, err := notificationCollection.UpdateOne(
 		ctx,
-		bson.D{{mongoDbAnd, bson.A{bson.D{{NotificationId, receiverId}}, bson.D{{NotificationSequence, seq}}}}},
-		bson.D{{NotificationIsHandled, true}, {NotificationIsAgree, isAgree}, {NotificationHandleUserId, handleUserId}},
-		nil,
+		bson.D{{NotificationId, receiverId}, {NotificationSequence, seq}, {NotificationIsExpired, bson.D{{mongoDbNotEqual, true}}}},
+		bson.D{{mongoDbSet, bson.D{{NotificationIsHandled, true}, {NotificationIsAgree, isAgree}, {NotificationHandleUserId, handleUserId}}}},
 	)
-
-	noti := &entities.Notification{}
-	if err := result.Decode(noti); err != nil {
-		if err == mongo.ErrNoDocuments {
-			return nil, MongoErrorNoNotification
-		}
+	if err != nil {
 		return nil, err
+	} else if result.MatchedCount == 0 {
+		return nil, MongoErrorNotificationExpired
 	}
 
 	noti.HandleUserId = handleUserId
@@ -258,6 +815,65 @@ func HandleNotification(ctx context.Context, receiverId, handleUserId int64, seq
 	return noti, nil
 }
 
+// AddOrRefreshPendingNotification 若同一发送者对同一接收者已有未处理且未过期的同类申请，
+// 则刷新其时间与验证消息并返回该通知，否则新增通知
+func AddOrRefreshPendingNotification(ctx context.Context, n *entities.Notification) (*entities.Notification, error) {
+	now := time.Now().Unix()
+	filter := bson.D{
+		{NotificationId, n.ReceiverId},
+		{NotificationSenderId, n.SenderId},
+		{NotificationOpType, n.OpType},
+		{NotificationReceiveType, n.ReceiveType},
+		{NotificationIsHandled, false},
+		{NotificationIsExpired, bson.D{{mongoDbNotEqual, true}}},
+	}
+	if notificationExpireAfter > 0 {
+		filter = append(filter, bson.E{Key: NotificationTimestamp, Value: bson.D{{mongoDbGreater, now - int64(notificationExpireAfter/time.Second)}}})
+	}
+
+	noti := &entities.Notification{}
+	err := notificationCollection.FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.D{{mongoDbSet, bson.D{{NotificationTimestamp, now}, {NotificationMessage, n.Message}}}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(noti)
+	if err == mongo.ErrNoDocuments {
+		return AddAndReturnNotification(ctx, n)
+	} else if err != nil {
+		return nil, err
+	}
+	return noti, nil
+}
+
+// ExpirePendingNotifications 将超过有效期仍未处理的申请关闭为已过期，返回关闭的数量
+func ExpirePendingNotifications(ctx context.Context) (int64, error) {
+	if notificationExpireAfter <= 0 {
+		return 0, nil
+	}
+
+	// []byte 会被编码为二进制，需逐个转换
+	receiveTypes := make(bson.A, 0, len(entities.PendingRequestTypes))
+	for _, receiveType := range entities.PendingRequestTypes {
+		receiveTypes = append(receiveTypes, receiveType)
+	}
+
+	result, err := notificationCollection.UpdateMany(
+		ctx,
+		bson.D{
+			{NotificationOpType, entities.Add},
+			{NotificationReceiveType, bson.D{{mongoDbIn, receiveTypes}}},
+			{NotificationIsHandled, false},
+			{NotificationTimestamp, bson.D{{mongoDbLessEqual, time.Now().Add(-notificationExpireAfter).Unix()}}},
+		},
+		bson.D{{mongoDbSet, bson.D{{NotificationIsHandled, true}, {NotificationIsAgree, false}, {NotificationIsExpired, true}}}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
 func getAndAddNotificationSequence(ctx context.Context, receiverId int64) (uint64, error) {
 	noti := entities.Notification{}
 	result := notiSeqCollection.FindOneAndUpdate(
@@ -292,6 +908,64 @@ func AddAndReturnNotification(ctx context.Context, n *entities.Notification) (*e
 	return n, nil
 }
 
+func AddPinnedMessage(ctx context.Context, p *entities.PinnedMessage) error {
+	if err := insertDocumentOne(ctx, p, pinnedMessageCollection); mongo.IsDuplicateKeyError(err) {
+		return MongoErrorMessagePinned
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+func DeletePinnedMessage(ctx context.Context, chatId int64, seq uint64) error {
+	result, err := pinnedMessageCollection.DeleteOne(ctx, bson.D{{PinnedMessageChatId, chatId}, {PinnedMessageSequence, seq}})
+	if err != nil {
+		return err
+	} else if result.DeletedCount == 0 {
+		return MongoErrorNoPinned
+	}
+	return nil
+}
+
+// DeleteDirectPinnedMessage 取消置顶私聊消息，peerId 为消息的发送者，避免误删接收者与其他人私聊中的置顶
+func DeleteDirectPinnedMessage(ctx context.Context, receiver int64, seq uint64, peerId int64) error {
+	result, err := pinnedMessageCollection.DeleteOne(ctx,
+		bson.D{{PinnedMessageChatId, receiver}, {PinnedMessageSequence, seq}, {PinnedMessagePeerId, peerId}})
+	if err != nil {
+		return err
+	} else if result.DeletedCount == 0 {
+		return MongoErrorNoPinned
+	}
+	return nil
+}
+
+// GetPinnedMessages 按置顶时间倒序返回会话中的置顶消息
+func GetPinnedMessages(ctx context.Context, chatId int64) ([]entities.PinnedMessage, error) {
+	return findPinnedMessages(ctx, getBson(PinnedMessageChatId, chatId))
+}
+
+// GetDirectPinnedMessages 按置顶时间倒序返回两个用户私聊中的置顶消息，包括双方各自收到的消息
+func GetDirectPinnedMessages(ctx context.Context, userId, friendId int64) ([]entities.PinnedMessage, error) {
+	return findPinnedMessages(ctx, bson.D{{mongoDbOr, bson.A{
+		bson.D{{PinnedMessageChatId, userId}, {PinnedMessagePeerId, friendId}},
+		bson.D{{PinnedMessageChatId, friendId}, {PinnedMessagePeerId, userId}},
+	}}})
+}
+
+func findPinnedMessages(ctx context.Context, filter bson.D) ([]entities.PinnedMessage, error) {
+	cursor, err := pinnedMessageCollection.Find(ctx, filter,
+		options.Find().SetSort(bson.D{{PinnedMessageTimestamp, -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	pinned := make([]entities.PinnedMessage, 0)
+	if err = decodeDataInCursor(cursor, &pinned); err != nil {
+		return nil, err
+	}
+	return pinned, nil
+}
+
 func SubscribeChatSeq(chatId int64) (*mongo.ChangeStream, error) {
 	return queueCollection.Watch(context.Background(),
 		mongo.Pipeline{bson.D{{mongoDbMatch, bson.D{{mongoDbAnd, bson.A{bson.D{{"operationType", bson.D{{mongoDbIn, bson.A{"insert", "update"}}}}},
@@ -369,7 +1043,12 @@ func initConnection() {
 	db := mongoConnection.Database(mongoDbDatabaseName)
 	messageCollection = db.Collection(mongoMessageCollectionName)
 	queueCollection = db.Collection(mongoQueueCollectionName)
-
+	notificationCollection = db.Collection(mongoNotificationCollectionName)
+	notiSeqCollection = db.Collection(mongoNotiSeqCollectionName)
+	mentionCollection = db.Collection(mongoMentionCollectionName)
+	webhookDeliveryCollection = db.Collection(mongoWebhookCollectionName)
+	pinnedMessageCollection = db.Collection(mongoPinnedCollectionName)
+	messageArchiveCollection = db.Collection(mongoArchiveCollectionName)
 }
 
 func createCollectionsAndIndexes() error {
@@ -379,23 +1058,43 @@ func createCollectionsAndIndexes() error {
 		return err
 	}
 
-	flag1, flag2 := false, false
+	existed := make(map[string]bool, len(lists))
 	for _, name := range lists {
-		if name == mongoMessageCollectionName {
-			flag1 = true
-		} else if name == mongoQueueCollectionName {
-			flag2 = true
-		}
+		existed[name] = true
 	}
 
-	if !flag1 {
-		if err = createCollectionAndIndexOne(db, mongoMessageCollectionName, MessageReceiver, MessageId); err != nil {
+	// 投递记录以 _id 区分，同一事件的多条投递状态与创建时间相同，因此该索引不能唯一
+	for _, entry := range []struct {
+		name     string
+		keys     []string
+		isUnique bool
+	}{
+		{mongoMessageCollectionName, []string{MessageReceiver, MessageId}, true},
+		{mongoQueueCollectionName, []string{ChatId}, true},
+		{mongoNotificationCollectionName, []string{NotificationId, NotificationSequence}, true},
+		{mongoNotiSeqCollectionName, []string{NotificationId}, true},
+		{mongoMentionCollectionName, []string{MentionUserId, MentionChatId, MentionSequence}, true},
+		{mongoWebhookCollectionName, []string{WebhookDeliveryStatus, WebhookDeliveryCreatedAt}, false},
+		{mongoPinnedCollectionName, []string{PinnedMessageChatId, PinnedMessageSequence}, true},
+		{mongoArchiveCollectionName, []string{MessageReceiver, MessageId}, true},
+	} {
+		if existed[entry.name] {
+			continue
+		}
+
+		if err = createCollectionAndIndexOne(db, entry.name, entry.isUnique, entry.keys...); err != nil {
 			return err
 		}
 	}
 
-	if !flag2 {
-		if err = createCollectionAndIndexOne(db, mongoQueueCollectionName, ChatId); err != nil {
+	// 查询用的非唯一索引，已存在的相同索引不会重复创建，因此已有的集合也会补建
+	for _, entry := range []struct {
+		name string
+		keys []string
+	}{
+		{mongoMentionCollectionName, []string{MentionUserId, MentionTimestamp, MentionChatId, MentionSequence}},
+	} {
+		if _, err = db.Collection(entry.name).Indexes().CreateOne(context.Background(), generateKeysIndex(false, entry.keys...)); err != nil {
 			return err
 		}
 	}
@@ -403,11 +1102,11 @@ func createCollectionsAndIndexes() error {
 	return nil
 }
 
-func createCollectionAndIndexOne(db *mongo.Database, collection string, key ...string) error {
+func createCollectionAndIndexOne(db *mongo.Database, collection string, isUnique bool, key ...string) error {
 	if err := db.CreateCollection(context.Background(), collection); err != nil {
 		return err
 	}
-	if _, err := db.Collection(collection).Indexes().CreateOne(context.Background(), generateKeysIndex(key...)); err != nil {
+	if _, err := db.Collection(collection).Indexes().CreateOne(context.Background(), generateKeysIndex(isUnique, key...)); err != nil {
 		return err
 	}
 
@@ -422,15 +1121,19 @@ func dropDatabase(cfg *config.MongoDBConfig) error {
 	return nil
 }
 
-func generateKeysIndex(keys ...string) mongo.IndexModel {
+func generateKeysIndex(isUnique bool, keys ...string) mongo.IndexModel {
 	keyDoc := bson.D{}
 	for _, key := range keys {
 		keyDoc = append(keyDoc, bson.E{Key: key, Value: 1})
 	}
 
+	indexOptions := options.Index()
+	if isUnique {
+		indexOptions.SetUnique(true)
+	}
 	return mongo.IndexModel{
 		Keys:    keyDoc,
-		Options: (&options.IndexOptions{}).SetUnique(true),
+		Options: indexOptions,
 	}
 }
 
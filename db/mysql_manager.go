@@ -10,6 +10,7 @@ import (
 	"liveChat/config"
 	"liveChat/entities"
 	"liveChat/tools"
+	"strings"
 	"time"
 )
 
@@ -21,7 +22,18 @@ var (
 	MysqlErrorUserNotExist  = errors.New("用户不存在")
 	MysqlErrorGroupNotExist = errors.New("群组不存在")
 	MysqlErrorNoLine        = errors.New("无行被插入或修改")
+	MysqlErrorBotNotExist   = errors.New("机器人不存在")
+	MysqlErrorInviteInvalid = errors.New("邀请链接不存在或已失效")
 	MysqlValidateFailed     = errors.New("用户信息校验失败")
+
+	MysqlErrorContactGroupNotExist = errors.New("分组不存在")
+	MysqlErrorContactGroupExist    = errors.New("分组已存在")
+	MysqlErrorExportJobNotExist    = errors.New("导出任务不存在")
+	MysqlErrorDeviceKeyNotExist    = errors.New("设备密钥不存在")
+	MysqlErrorDeviceKeyTooMany     = errors.New("登记密钥的设备数量超出上限")
+	MysqlErrorReportNotExist       = errors.New("举报不存在")
+	MysqlErrorReportDuplicated     = errors.New("已举报过该对象，请等待处理")
+	MysqlErrorReportReviewed       = errors.New("举报已被处理")
 )
 
 var (
@@ -52,7 +64,14 @@ func InitMysqlConnection(url string) {
 		panic(err)
 	}
 
-	if err = mysqlDb.AutoMigrate(&loginTableEntry{}, &entities.UserInfo{}, &entities.GroupInfo{}, &entities.Friendship{}, &entities.GroupMember{}); err != nil {
+	if err = registerMysqlMetrics(mysqlDb); err != nil {
+		panic(err)
+	}
+
+	if err = mysqlDb.AutoMigrate(&loginTableEntry{}, &entities.UserInfo{}, &entities.GroupInfo{}, &entities.Friendship{}, &entities.ContactGroup{}, &entities.Block{}, &entities.GroupMember{},
+		&entities.GroupRole{}, &entities.GroupInvite{}, &entities.GroupAnnouncement{}, &entities.GroupAnnouncementAck{},
+		&entities.PushDevice{}, &entities.PushSetting{}, &entities.WebhookSubscription{}, &entities.BotInfo{},
+		&entities.ExportJob{}, &entities.DeviceKey{}, &entities.OneTimePreKey{}, &entities.Report{}, &entities.AuditLog{}); err != nil {
 		panic(err)
 	}
 
@@ -100,6 +119,109 @@ func Register(executor *gorm.DB, account, email, password string) (id int64, err
 	return
 }
 
+// CheckPassword 校验用户的登录密码，机器人与已注销的用户没有登录信息
+func CheckPassword(executor *gorm.DB, userId int64, password string) (bool, error) {
+	executor = returnMysqlDbObj(executor)
+	result := executor.Model(&loginTableEntry{}).Where("id = ? AND password = ?", userId, password).Find(&loginTableEntry{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteAccount 删除用户的登录信息并解除所有好友关系，群组成员关系需要调用方按继任规则处理
+func DeleteAccount(executor *gorm.DB, userId int64) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", userId).Delete(&loginTableEntry{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(entities.NewEmptyUserInfo()).Where("id = ?", userId).Update("is_deleted", true)
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected != 1 {
+			return MysqlErrorUserNotExist
+		}
+
+		return tx.
+			Model(&entities.Friendship{}).
+			Where("(self_id = ? OR friend_id = ?) AND is_deleted = 0", userId, userId).
+			Update("is_deleted", true).
+			Error
+	})
+}
+
+// RegisterBot 创建机器人账号，机器人没有登录信息，只能通过 API Key 调用接口
+func RegisterBot(executor *gorm.DB, ownerId int64, username, apiKeyHash string) (id int64, err error) {
+	executor = returnMysqlDbObj(executor)
+	err = executor.Transaction(func(tx *gorm.DB) (err error) {
+		id = tools.GenerateSnowflakeId(false)
+
+		info := entities.NewUserInfoWithDefaultValue(id)
+		info.Username = username
+		info.IsBot = true
+		if err := tx.Create(info).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(entities.NewBotInfo(id, ownerId, apiKeyHash)).Error; err != nil {
+			return err
+		}
+
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+
+	if err != nil {
+		return -1, err
+	}
+	return
+}
+
+func GetBotInfo(executor *gorm.DB, botId int64) (*entities.BotInfo, error) {
+	return getBotInfoByCond(executor, "id = ?", botId)
+}
+
+func GetBotInfoByApiKeyHash(executor *gorm.DB, apiKeyHash string) (*entities.BotInfo, error) {
+	return getBotInfoByCond(executor, "api_key_hash = ?", apiKeyHash)
+}
+
+func UpdateBotApiKey(executor *gorm.DB, botId int64, apiKeyHash string) error {
+	return updateBotInfo(executor, botId, map[string]interface{}{"api_key_hash": apiKeyHash})
+}
+
+func UpdateBotWebhook(executor *gorm.DB, botId int64, url, secret string) error {
+	return updateBotInfo(executor, botId, map[string]interface{}{"webhook_url": url, "webhook_secret": secret})
+}
+
+// SetBotDisabled 停用或重新启用机器人，创建者已注销的机器人不会被重新启用
+func SetBotDisabled(executor *gorm.DB, botId int64, isDisabled bool) error {
+	executor = returnMysqlDbObj(executor).Model(&entities.BotInfo{}).Where("id = ?", botId)
+	if !isDisabled {
+		executor = executor.Where("owner_id IN (SELECT id FROM user_infos WHERE is_deleted = 0)")
+	}
+	return executor.Update("is_disabled", isDisabled).Error
+}
+
+// DisableBotsOfOwner 停用 ownerId 创建的全部机器人并清除其 webhook，返回被停用的机器人 id
+func DisableBotsOfOwner(executor *gorm.DB, ownerId int64) ([]int64, error) {
+	executor = returnMysqlDbObj(executor)
+	ids := make([]int64, 0)
+	if err := executor.Model(&entities.BotInfo{}).Where("owner_id = ? AND is_disabled = 0", ownerId).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	} else if len(ids) == 0 {
+		return ids, nil
+	}
+
+	err := executor.Model(&entities.BotInfo{}).Where("id IN ?", ids).
+		Updates(map[string]interface{}{"is_disabled": true, "webhook_url": "", "webhook_secret": ""}).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 func SearchUserInfo(executor *gorm.DB, id int64, isSelf bool) (*entities.UserInfo, error) {
 	executor = returnMysqlDbObj(executor)
 	var (
@@ -110,6 +232,7 @@ func SearchUserInfo(executor *gorm.DB, id int64, isSelf bool) (*entities.UserInf
 	if isSelf {
 		result = executor.Preload("Groups", "member_id = ? AND is_deleted = 0", id).
 			Preload("Friendships", "self_id = ? AND is_deleted = 0", id).
+			Preload("ContactGroups").
 			Where("id = ?", id).
 			Find(info)
 	} else {
@@ -127,6 +250,94 @@ func SearchUserInfo(executor *gorm.DB, id int64, isSelf bool) (*entities.UserInf
 	return info, nil
 }
 
+func UpdateUserDiscoverableBy(executor *gorm.DB, userId int64, discoverableBy byte) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Transaction(func(tx *gorm.DB) error {
+		if err := isUserInfoExist(tx, userId); err != nil {
+			return err
+		}
+
+		return tx.Model(&entities.UserInfo{}).Where("id = ?", userId).Update("discoverable_by", discoverableBy).Error
+	})
+}
+
+func UpdateUserAddFriendPolicy(executor *gorm.DB, userId int64, policy byte) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Transaction(func(tx *gorm.DB) error {
+		if err := isUserInfoExist(tx, userId); err != nil {
+			return err
+		}
+
+		return tx.Model(&entities.UserInfo{}).Where("id = ?", userId).Update("add_friend_policy", policy).Error
+	})
+}
+
+func UpdateUserRetentionPolicy(executor *gorm.DB, userId int64, policy *entities.RetentionPolicy) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Transaction(func(tx *gorm.DB) error {
+		if err := isUserInfoExist(tx, userId); err != nil {
+			return err
+		}
+
+		return tx.Model(&entities.UserInfo{}).Where("id = ?", userId).
+			Select("retention_policy").
+			Updates(&entities.UserInfo{RetentionPolicy: policy}).Error
+	})
+}
+
+// GetUserRetentionPolicy 返回用户为发给自己的私聊消息设置的保留策略，未设置时返回 nil
+func GetUserRetentionPolicy(executor *gorm.DB, userId int64) (*entities.RetentionPolicy, error) {
+	executor = returnMysqlDbObj(executor)
+	info := entities.NewEmptyUserInfo()
+	result := executor.Select("id", "retention_policy").Where("id = ?", userId).Find(info)
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected != 1 {
+		return nil, MysqlErrorUserNotExist
+	}
+	return info.RetentionPolicy, nil
+}
+
+// SearchUsers 按完整的账号、完整的邮箱或用户名前缀检索用户，结果按用户 id 升序排列，只返回 id 大于 cursor 的用户
+// 用户名前缀只匹配设置为所有人可搜索的用户
+func SearchUsers(executor *gorm.DB, keyword string, cursor, limit int64) ([]entities.UserInfo, error) {
+	executor = returnMysqlDbObj(executor)
+	users := make([]entities.UserInfo, 0)
+	result := executor.
+		Model(&entities.UserInfo{}).
+		Select("user_infos.*").
+		Joins("LEFT JOIN login_table_entries ON login_table_entries.id = user_infos.id").
+		Where("user_infos.is_deleted = 0 AND user_infos.id > ?", cursor).
+		Where(executor.
+			Where("user_infos.discoverable_by <> ? AND (login_table_entries.account = ? OR login_table_entries.email = ?)",
+				entities.DiscoverableByNobody, keyword, keyword).
+			Or("user_infos.discoverable_by = ? AND user_infos.username LIKE ?",
+				entities.DiscoverableByEveryone, escapeLikePattern(keyword)+"%")).
+		Order("user_infos.id").
+		Limit(int(limit)).
+		Find(&users)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return users, nil
+}
+
+// SelectUserIds 按 id 升序返回 id 大于 cursor 的未注销用户，不包括机器人
+func SelectUserIds(executor *gorm.DB, cursor, limit int64) ([]int64, error) {
+	executor = returnMysqlDbObj(executor)
+	ids := make([]int64, 0)
+	result := executor.Model(&entities.UserInfo{}).
+		Where("is_deleted = 0 AND is_bot = 0 AND id > ?", cursor).
+		Order("id").
+		Limit(int(limit)).
+		Pluck("id", &ids)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return ids, nil
+}
+
 func UpdateUserName(executor *gorm.DB, userId int64, userName string) error {
 	executor = returnMysqlDbObj(executor)
 	return updateUserInfo(executor, userId, "username", userName)
@@ -218,6 +429,124 @@ func TellIsFriendBetween(executor *gorm.DB, userId1, userId2 int64) (bool, int64
 	return false, time.Now().UnixMilli(), nil
 }
 
+// UpdateFriendSetting 更新用户对好友的备注、标签与分组，contactGroupId 为 0 时移出分组
+func UpdateFriendSetting(executor *gorm.DB, selfId, friendId int64, remark string, tags []string, contactGroupId int64) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Transaction(func(tx *gorm.DB) error {
+		if contactGroupId != 0 {
+			if err := isContactGroupExist(tx, selfId, contactGroupId); err != nil {
+				return err
+			}
+		}
+
+		result := tx.Model(&entities.Friendship{}).
+			Where("self_id = ? AND friend_id = ? AND is_deleted = 0", selfId, friendId).
+			Select("remark", "tags", "contact_group_id").
+			Updates(&entities.Friendship{Remark: remark, Tags: tags, ContactGroupId: contactGroupId})
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected != 1 {
+			return MysqlErrorUserNotExist
+		}
+
+		return nil
+	})
+}
+
+func AddContactGroup(executor *gorm.DB, group *entities.ContactGroup) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Transaction(func(tx *gorm.DB) error {
+		if err := isContactGroupNameUnused(tx, group.UserId, group.Name); err != nil {
+			return err
+		}
+
+		return tx.Create(group).Error
+	})
+}
+
+func RenameContactGroup(executor *gorm.DB, userId, contactGroupId int64, name string) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Transaction(func(tx *gorm.DB) error {
+		if err := isContactGroupExist(tx, userId, contactGroupId); err != nil {
+			return err
+		} else if err = isContactGroupNameUnused(tx, userId, name); err != nil {
+			return err
+		}
+
+		return tx.Model(&entities.ContactGroup{}).Where("id = ?", contactGroupId).Update("name", name).Error
+	})
+}
+
+// DeleteContactGroup 删除分组，分组内的好友变为未分组
+func DeleteContactGroup(executor *gorm.DB, userId, contactGroupId int64) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", contactGroupId, userId).Delete(&entities.ContactGroup{})
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected != 1 {
+			return MysqlErrorContactGroupNotExist
+		}
+
+		return tx.Model(&entities.Friendship{}).
+			Where("self_id = ? AND contact_group_id = ?", userId, contactGroupId).
+			Update("contact_group_id", 0).Error
+	})
+}
+
+func BlockUser(executor *gorm.DB, userId, blockedId int64) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Transaction(func(tx *gorm.DB) error {
+		if err := isUserInfoExist(tx, blockedId); err != nil {
+			return err
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "blocked_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"is_deleted": false, "updated_at": time.Now()}),
+		}).Create(entities.NewBlock(userId, blockedId)).Error
+	})
+}
+
+func UnblockUser(executor *gorm.DB, userId, blockedId int64) error {
+	executor = returnMysqlDbObj(executor)
+	result := executor.Model(&entities.Block{}).
+		Where("user_id = ? AND blocked_id = ? AND is_deleted = 0", userId, blockedId).
+		Update("is_deleted", true)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected != 1 {
+		return MysqlErrorNoLine
+	}
+
+	return nil
+}
+
+func SelectBlockedUsers(executor *gorm.DB, userId int64) ([]entities.Block, error) {
+	executor = returnMysqlDbObj(executor)
+	blocks := make([]entities.Block, 0)
+	if result := executor.Where("user_id = ? AND is_deleted = 0", userId).Find(&blocks); result.Error != nil {
+		return nil, result.Error
+	}
+	return blocks, nil
+}
+
+// TellIsBlocked 返回 userId 是否将 blockedId 加入了黑名单以及记录的更新时间
+func TellIsBlocked(executor *gorm.DB, userId, blockedId int64) (bool, int64, error) {
+	executor = returnMysqlDbObj(executor)
+	block := &entities.Block{}
+	ret := executor.Where("user_id = ? AND blocked_id = ?", userId, blockedId).Find(block)
+	if ret.Error != nil {
+		return false, 0, ret.Error
+	}
+
+	if ret.RowsAffected == 1 {
+		return !block.IsDeleted, block.GormModel.UpdatedAt.UnixMilli(), nil
+	}
+
+	return false, time.Now().UnixMilli(), nil
+}
+
 func AddGroupInfo(executor *gorm.DB, owner int64, name, introduction, avatar string) (id int64, err error) {
 	executor = returnMysqlDbObj(executor)
 	err = executor.Transaction(func(tx *gorm.DB) error {
@@ -226,7 +555,9 @@ func AddGroupInfo(executor *gorm.DB, owner int64, name, introduction, avatar str
 			return err
 		}
 
-		if err := tx.Create(entities.NewGroupMember(id, owner, true)).Error; err != nil {
+		member := entities.NewGroupMember(id, owner, true)
+		member.Role = entities.RoleOwner
+		if err := tx.Create(member).Error; err != nil {
 			return err
 		}
 
@@ -247,7 +578,11 @@ func SearchGroupInfo(executor *gorm.DB, id int64, isInGroup bool) (*entities.Gro
 	)
 
 	if isInGroup {
-		result = executor.Preload("Members", executor.Where(&entities.GroupMember{GroupId: id, IsDeleted: false})).Where("id = ?", id).Find(groupInfo)
+		result = executor.
+			Preload("Members", executor.Where(&entities.GroupMember{GroupId: id, IsDeleted: false})).
+			Preload("Roles").
+			Where("id = ?", id).
+			Find(groupInfo)
 	} else {
 		result = executor.Where("id = ?", id).Find(groupInfo)
 	}
@@ -294,11 +629,22 @@ func DeleteFromGroup(executor *gorm.DB, userId, groupId int64) error {
 }
 
 func AddAdministrator(executor *gorm.DB, userId, groupId int64) error {
+	executor = returnMysqlDbObj(executor)
+	return SetGroupMemberRole(executor, userId, groupId, entities.RoleAdministrator)
+}
+
+func DeleteAdministrator(executor *gorm.DB, userId, groupId int64) error {
+	executor = returnMysqlDbObj(executor)
+	return SetGroupMemberRole(executor, userId, groupId, entities.RoleMember)
+}
+
+// SetGroupMemberRole 设置成员的角色，IsAdministrator 与角色保持同步以兼容旧的客户端
+func SetGroupMemberRole(executor *gorm.DB, userId, groupId int64, role string) error {
 	executor = returnMysqlDbObj(executor)
 	result := executor.
 		Model(&entities.GroupMember{}).
 		Where("group_id = ? AND member_id = ? AND is_deleted = 0", groupId, userId).
-		Update("is_administrator", true)
+		Updates(map[string]interface{}{"role": role, "is_administrator": role == entities.RoleAdministrator || role == entities.RoleOwner})
 
 	if result.Error != nil {
 		return result.Error
@@ -309,22 +655,202 @@ func AddAdministrator(executor *gorm.DB, userId, groupId int64) error {
 	return nil
 }
 
-func DeleteAdministrator(executor *gorm.DB, userId, groupId int64) error {
+func SaveGroupRole(executor *gorm.DB, role *entities.GroupRole) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "group_id"}, {Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"permissions"}),
+	}).Create(role).Error
+}
+
+// DeleteGroupRole 删除群组定义的角色，持有自定义角色的成员回退为普通成员
+func DeleteGroupRole(executor *gorm.DB, groupId int64, name string) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ? AND name = ?", groupId, name).Delete(&entities.GroupRole{}).Error; err != nil {
+			return err
+		}
+
+		if entities.IsBuiltinRole(name) {
+			return nil
+		}
+
+		return tx.
+			Model(&entities.GroupMember{}).
+			Where("group_id = ? AND role = ?", groupId, name).
+			Update("role", entities.RoleMember).
+			Error
+	})
+}
+
+// TransferGroupOwnership 将群主转让给群组内的成员，原群主降为管理员
+func TransferGroupOwnership(executor *gorm.DB, groupId, oldOwner, newOwner int64) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(entities.NewEmptyGroupInfo()).
+			Where("id = ? AND owner = ? AND is_deleted = 0", groupId, oldOwner).
+			Update("owner", newOwner)
+
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected != 1 {
+			return MysqlErrorGroupNotExist
+		}
+
+		if err := SetGroupMemberRole(tx, newOwner, groupId, entities.RoleOwner); err != nil {
+			return err
+		}
+
+		// 原群主注销账号时随后会被移出群组，此时不要求其仍在群组内
+		return tx.
+			Model(&entities.GroupMember{}).
+			Where("group_id = ? AND member_id = ? AND is_deleted = 0", groupId, oldOwner).
+			Updates(map[string]interface{}{"role": entities.RoleAdministrator, "is_administrator": true}).
+			Error
+	})
+}
+
+func UpdateGroupSuccessionPolicy(executor *gorm.DB, groupId int64, policy byte) error {
+	executor = returnMysqlDbObj(executor)
+	result := executor.Model(entities.NewEmptyGroupInfo()).Where("id = ? AND is_deleted = 0", groupId).Update("succession_policy", policy)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected != 1 {
+		return MysqlErrorGroupNotExist
+	}
+
+	return nil
+}
+
+func UpdateGroupJoinPolicy(executor *gorm.DB, groupId int64, policy byte) error {
+	executor = returnMysqlDbObj(executor)
+	result := executor.Model(entities.NewEmptyGroupInfo()).Where("id = ? AND is_deleted = 0", groupId).Update("join_policy", policy)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected != 1 {
+		return MysqlErrorGroupNotExist
+	}
+
+	return nil
+}
+
+func UpdateGroupRetentionPolicy(executor *gorm.DB, groupId int64, policy *entities.RetentionPolicy) error {
+	executor = returnMysqlDbObj(executor)
+	result := executor.Model(entities.NewEmptyGroupInfo()).Where("id = ? AND is_deleted = 0", groupId).
+		Select("retention_policy", "updated_at").
+		Updates(&entities.GroupInfo{RetentionPolicy: policy})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected != 1 {
+		return MysqlErrorGroupNotExist
+	}
+
+	return nil
+}
+
+func AddGroupInvite(executor *gorm.DB, invite *entities.GroupInvite) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Create(invite).Error
+}
+
+func GetGroupInvite(executor *gorm.DB, code string) (*entities.GroupInvite, error) {
+	executor = returnMysqlDbObj(executor)
+	invite := &entities.GroupInvite{}
+	result := executor.Where("code = ?", code).Find(invite)
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected != 1 {
+		return nil, MysqlErrorInviteInvalid
+	}
+
+	return invite, nil
+}
+
+// SelectGroupInvites 返回群组内未撤销且未过期的邀请链接
+func SelectGroupInvites(executor *gorm.DB, groupId, now int64) ([]entities.GroupInvite, error) {
+	executor = returnMysqlDbObj(executor)
+	invites := make([]entities.GroupInvite, 0)
+	result := executor.Where("group_id = ? AND is_revoked = 0 AND expires_at > ?", groupId, now).Order("created_at DESC").Find(&invites)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return invites, nil
+}
+
+func RevokeGroupInvite(executor *gorm.DB, groupId int64, code string) error {
+	executor = returnMysqlDbObj(executor)
+	result := executor.Model(&entities.GroupInvite{}).Where("code = ? AND group_id = ? AND is_revoked = 0", code, groupId).Update("is_revoked", true)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected != 1 {
+		return MysqlErrorInviteInvalid
+	}
+
+	return nil
+}
+
+// UseGroupInvite 原子地占用邀请链接的一次使用次数，链接不可用时返回 MysqlErrorInviteInvalid
+func UseGroupInvite(executor *gorm.DB, code string, now int64) error {
 	executor = returnMysqlDbObj(executor)
 	result := executor.
-		Model(&entities.GroupMember{}).
-		Where("group_id = ? AND member_id = ? AND is_deleted = 0", groupId, userId).
-		Update("is_administrator", false)
+		Model(&entities.GroupInvite{}).
+		Where("code = ? AND is_revoked = 0 AND expires_at > ? AND (max_uses = 0 OR used_count < max_uses)", code, now).
+		Update("used_count", gorm.Expr("used_count + 1"))
 
 	if result.Error != nil {
 		return result.Error
 	} else if result.RowsAffected != 1 {
-		return MysqlErrorUserNotExist
+		return MysqlErrorInviteInvalid
 	}
 
 	return nil
 }
 
+func AddGroupAnnouncement(executor *gorm.DB, announcement *entities.GroupAnnouncement) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Create(announcement).Error
+}
+
+// GetLatestGroupAnnouncement 返回群组当前的公告，群组没有公告时返回 MysqlErrorNoLine
+func GetLatestGroupAnnouncement(executor *gorm.DB, groupId int64) (*entities.GroupAnnouncement, error) {
+	executor = returnMysqlDbObj(executor)
+	announcement := &entities.GroupAnnouncement{}
+	result := executor.Where("group_id = ?", groupId).Order("id DESC").Limit(1).Find(announcement)
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, MysqlErrorNoLine
+	}
+
+	return announcement, nil
+}
+
+// AcknowledgeGroupAnnouncement 记录成员已确认公告，重复确认时不会增加确认人数
+func AcknowledgeGroupAnnouncement(executor *gorm.DB, announcementId, userId int64) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&entities.GroupAnnouncementAck{AnnouncementId: announcementId, UserId: userId})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		return tx.Model(&entities.GroupAnnouncement{}).
+			Where("id = ?", announcementId).
+			Update("ack_count", gorm.Expr("ack_count + 1")).Error
+	})
+}
+
+func IsGroupAnnouncementAcknowledged(executor *gorm.DB, announcementId, userId int64) (bool, error) {
+	executor = returnMysqlDbObj(executor)
+	var count int64
+	err := executor.Model(&entities.GroupAnnouncementAck{}).
+		Where("announcement_id = ? AND user_id = ?", announcementId, userId).
+		Count(&count).Error
+	return count != 0, err
+}
+
 func UpdateGroupName(executor *gorm.DB, groupId int64, name string) error {
 	executor = returnMysqlDbObj(executor)
 	return updateGroupInfo(executor, groupId, "name", name)
@@ -340,6 +866,35 @@ func UpdateGroupAvatar(executor *gorm.DB, groupId int64, avatar string) error {
 	return updateGroupInfo(executor, groupId, "avatar", avatar)
 }
 
+func UpdateGroupMuteAll(executor *gorm.DB, groupId int64, isMuteAll bool) error {
+	executor = returnMysqlDbObj(executor)
+	result := executor.Model(entities.NewEmptyGroupInfo()).Where("id = ? AND is_deleted = 0", groupId).Update("is_mute_all", isMuteAll)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected != 1 {
+		return MysqlErrorGroupNotExist
+	}
+
+	return nil
+}
+
+// UpdateGroupMemberMute 设置成员禁言的截止时间（毫秒时间戳），为 0 时解除禁言
+func UpdateGroupMemberMute(executor *gorm.DB, userId, groupId, mutedUntil int64) error {
+	executor = returnMysqlDbObj(executor)
+	result := executor.
+		Model(&entities.GroupMember{}).
+		Where("group_id = ? AND member_id = ? AND is_deleted = 0", groupId, userId).
+		Update("muted_until", mutedUntil)
+
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected != 1 {
+		return MysqlErrorUserNotExist
+	}
+
+	return nil
+}
+
 func SelectGroupMemberList(executor *gorm.DB, groupId int64) ([]entities.GroupMember, error) {
 	executor = returnMysqlDbObj(executor)
 	info, err := SearchGroupInfo(executor, groupId, true)
@@ -360,6 +915,100 @@ func SelectGroupInfoForUser(executor *gorm.DB, userId int64) ([]entities.GroupMe
 	return ret, nil
 }
 
+func RegisterPushDevice(executor *gorm.DB, device *entities.PushDevice) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "platform", "provider", "updated_at", "deleted_at"}),
+	}).Create(device).Error
+}
+
+func UnregisterPushDevice(executor *gorm.DB, userId int64, token string) error {
+	executor = returnMysqlDbObj(executor)
+	result := executor.Where("user_id = ? AND token = ?", userId, token).Delete(&entities.PushDevice{})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return MysqlErrorNoLine
+	}
+	return nil
+}
+
+func SelectPushDevices(executor *gorm.DB, userId int64) ([]entities.PushDevice, error) {
+	executor = returnMysqlDbObj(executor)
+	devices := make([]entities.PushDevice, 0)
+	if result := executor.Where("user_id = ?", userId).Find(&devices); result.Error != nil {
+		return nil, result.Error
+	}
+	return devices, nil
+}
+
+func GetPushSetting(executor *gorm.DB, userId int64) (*entities.PushSetting, error) {
+	executor = returnMysqlDbObj(executor)
+	setting := entities.NewDefaultPushSetting(userId)
+	if result := executor.Where("user_id = ?", userId).Find(setting); result.Error != nil {
+		return nil, result.Error
+	}
+	return setting, nil
+}
+
+func SavePushSetting(executor *gorm.DB, setting *entities.PushSetting) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Save(setting).Error
+}
+
+func AddWebhookSubscription(executor *gorm.DB, subscription *entities.WebhookSubscription) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Create(subscription).Error
+}
+
+func DeleteWebhookSubscription(executor *gorm.DB, id int64) error {
+	executor = returnMysqlDbObj(executor)
+	result := executor.Delete(&entities.WebhookSubscription{}, id)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return MysqlErrorNoLine
+	}
+	return nil
+}
+
+func SelectWebhookSubscriptions(executor *gorm.DB) ([]entities.WebhookSubscription, error) {
+	executor = returnMysqlDbObj(executor)
+	subscriptions := make([]entities.WebhookSubscription, 0)
+	if result := executor.Find(&subscriptions); result.Error != nil {
+		return nil, result.Error
+	}
+	return subscriptions, nil
+}
+
+func getBotInfoByCond(executor *gorm.DB, cond string, value interface{}) (*entities.BotInfo, error) {
+	executor = returnMysqlDbObj(executor)
+	info := &entities.BotInfo{}
+	result := executor.Where(cond, value).Limit(1).Find(info)
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, MysqlErrorBotNotExist
+	}
+	return info, nil
+}
+
+func updateBotInfo(executor *gorm.DB, botId int64, values map[string]interface{}) error {
+	executor = returnMysqlDbObj(executor)
+	result := executor.Model(&entities.BotInfo{}).Where("id = ?", botId).Updates(values)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return MysqlErrorBotNotExist
+	}
+	return nil
+}
+
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 func updateUserInfo(executor *gorm.DB, userId int64, columnName, columnValue string) error {
 	if result := executor.Model(&entities.UserInfo{}).Where("id = ?", userId).Update(columnName, columnValue); result.Error != nil {
 		return result.Error
@@ -369,6 +1018,297 @@ func updateUserInfo(executor *gorm.DB, userId int64, columnName, columnValue str
 	return nil
 }
 
+func AddExportJob(executor *gorm.DB, job *entities.ExportJob) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Create(job).Error
+}
+
+func GetExportJob(executor *gorm.DB, jobId int64) (*entities.ExportJob, error) {
+	executor = returnMysqlDbObj(executor)
+	job := &entities.ExportJob{}
+	result := executor.Where("id = ?", jobId).Find(job)
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected != 1 {
+		return nil, MysqlErrorExportJobNotExist
+	}
+	return job, nil
+}
+
+// SelectExportJobs 按创建时间倒序返回用户自己发起的导出任务
+func SelectExportJobs(executor *gorm.DB, userId int64) ([]entities.ExportJob, error) {
+	executor = returnMysqlDbObj(executor)
+	jobs := make([]entities.ExportJob, 0)
+	if result := executor.Where("user_id = ? AND is_by_operator = 0", userId).Order("id DESC").Find(&jobs); result.Error != nil {
+		return nil, result.Error
+	}
+	return jobs, nil
+}
+
+// ClaimPendingExportJob 认领最早创建的待执行任务并标记为执行中，多个节点同时认领时只有一个成功。没有待执行任务时返回 nil
+func ClaimPendingExportJob(executor *gorm.DB, now int64) (*entities.ExportJob, error) {
+	executor = returnMysqlDbObj(executor)
+	for {
+		job := &entities.ExportJob{}
+		result := executor.Where("status = ?", entities.ExportPending).Order("created_at").Limit(1).Find(job)
+		if result.Error != nil {
+			return nil, result.Error
+		} else if result.RowsAffected == 0 {
+			return nil, nil
+		}
+
+		result = executor.Model(&entities.ExportJob{}).Where("id = ? AND status = ?", job.Id, entities.ExportPending).
+			Updates(map[string]interface{}{"status": entities.ExportRunning, "heartbeat_at": now})
+		if result.Error != nil {
+			return nil, result.Error
+		} else if result.RowsAffected == 1 {
+			job.Status, job.HeartbeatAt = entities.ExportRunning, now
+			return job, nil
+		}
+	}
+}
+
+// UpdateExportJobProgress 同时刷新任务的更新时间，作为执行节点仍然存活的心跳
+func UpdateExportJobProgress(executor *gorm.DB, jobId, processed, total, now int64) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Model(&entities.ExportJob{}).Where("id = ?", jobId).
+		Updates(map[string]interface{}{"processed": processed, "total": total, "heartbeat_at": now}).Error
+}
+
+// RequeueStaleExportJobs 将心跳早于 before 的执行中任务重置为待执行，返回重置的数量
+func RequeueStaleExportJobs(executor *gorm.DB, before int64) (int64, error) {
+	executor = returnMysqlDbObj(executor)
+	result := executor.Model(&entities.ExportJob{}).Where("status = ? AND heartbeat_at < ?", entities.ExportRunning, before).
+		Updates(map[string]interface{}{"status": entities.ExportPending, "processed": 0})
+	return result.RowsAffected, result.Error
+}
+
+// SelectExpiredExportJobs 返回完成时间早于 before 的已完成任务
+func SelectExpiredExportJobs(executor *gorm.DB, before int64, limit int) ([]entities.ExportJob, error) {
+	executor = returnMysqlDbObj(executor)
+	jobs := make([]entities.ExportJob, 0)
+	if result := executor.Where("status = ? AND finished_at < ?", entities.ExportDone, before).Limit(limit).Find(&jobs); result.Error != nil {
+		return nil, result.Error
+	}
+	return jobs, nil
+}
+
+func ExpireExportJob(executor *gorm.DB, jobId int64) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Model(&entities.ExportJob{}).Where("id = ? AND status = ?", jobId, entities.ExportDone).
+		Update("status", entities.ExportExpired).Error
+}
+
+func FinishExportJob(executor *gorm.DB, jobId int64, status byte, errInfo string, finishedAt int64) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Model(&entities.ExportJob{}).Where("id = ?", jobId).
+		Updates(map[string]interface{}{"status": status, "error": errInfo, "finished_at": finishedAt}).Error
+}
+
+// SaveDeviceKey 保存设备的身份公钥与签名预密钥并追加一次性预密钥，身份公钥变化时旧的一次性预密钥会被清除。
+// 新设备登记或身份公钥变化时 isIdentityChanged 为真
+func SaveDeviceKey(executor *gorm.DB, key *entities.DeviceKey, preKeys []entities.OneTimePreKey) (isIdentityChanged bool, err error) {
+	executor = returnMysqlDbObj(executor)
+	err = executor.Transaction(func(tx *gorm.DB) error {
+		old := &entities.DeviceKey{}
+		result := tx.Where("user_id = ? AND device_id = ?", key.UserId, key.DeviceId).Find(old)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			var count int64
+			if err := tx.Model(&entities.DeviceKey{}).Where("user_id = ?", key.UserId).Count(&count).Error; err != nil {
+				return err
+			} else if count >= entities.MaxEncryptionDevices {
+				return MysqlErrorDeviceKeyTooMany
+			}
+			isIdentityChanged = true
+		} else if old.IdentityKey != key.IdentityKey {
+			if err := tx.Where("user_id = ? AND device_id = ?", key.UserId, key.DeviceId).Delete(&entities.OneTimePreKey{}).Error; err != nil {
+				return err
+			}
+			isIdentityChanged = true
+		}
+
+		if err := tx.Save(key).Error; err != nil {
+			return err
+		}
+
+		if len(preKeys) == 0 {
+			return nil
+		}
+		for i := range preKeys {
+			preKeys[i].UserId, preKeys[i].DeviceId = key.UserId, key.DeviceId
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "device_id"}, {Name: "key_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"public_key"}),
+		}).Create(&preKeys).Error
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+	return
+}
+
+func DeleteDeviceKey(executor *gorm.DB, userId, deviceId int64) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND device_id = ?", userId, deviceId).Delete(&entities.DeviceKey{})
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected == 0 {
+			return MysqlErrorDeviceKeyNotExist
+		}
+
+		return tx.Where("user_id = ? AND device_id = ?", userId, deviceId).Delete(&entities.OneTimePreKey{}).Error
+	})
+}
+
+func CountOneTimePreKeys(executor *gorm.DB, userId, deviceId int64) (int64, error) {
+	executor = returnMysqlDbObj(executor)
+	var count int64
+	err := executor.Model(&entities.OneTimePreKey{}).Where("user_id = ? AND device_id = ?", userId, deviceId).Count(&count).Error
+	return count, err
+}
+
+// ClaimPreKeyBundles 返回用户每台设备的密钥，并为每台设备领取（删除）一个一次性预密钥，
+// 领取时加锁以保证同一个一次性预密钥不会分发给两个请求方
+func ClaimPreKeyBundles(executor *gorm.DB, userId int64) ([]entities.PreKeyBundle, error) {
+	executor = returnMysqlDbObj(executor)
+	bundles := make([]entities.PreKeyBundle, 0)
+	err := executor.Transaction(func(tx *gorm.DB) error {
+		keys := make([]entities.DeviceKey, 0)
+		if err := tx.Where("user_id = ?", userId).Order("device_id").Find(&keys).Error; err != nil {
+			return err
+		}
+
+		for i := range keys {
+			preKey := &entities.OneTimePreKey{}
+			result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("user_id = ? AND device_id = ?", userId, keys[i].DeviceId).
+				Order("key_id").Limit(1).Find(preKey)
+			if result.Error != nil {
+				return result.Error
+			} else if result.RowsAffected == 0 {
+				bundles = append(bundles, *entities.NewPreKeyBundle(&keys[i], nil))
+				continue
+			}
+
+			if err := tx.Where("user_id = ? AND device_id = ? AND key_id = ?", userId, keys[i].DeviceId, preKey.KeyId).
+				Delete(&entities.OneTimePreKey{}).Error; err != nil {
+				return err
+			}
+			bundles = append(bundles, *entities.NewPreKeyBundle(&keys[i], preKey))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bundles, nil
+}
+
+// AddReport 同一用户对同一对象只能有一条待处理的举报
+func AddReport(executor *gorm.DB, report *entities.Report) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("reporter_id = ? AND target_type = ? AND target_id = ? AND seq = ? AND status = ?",
+			report.ReporterId, report.TargetType, report.TargetId, report.Seq, entities.ReportPending).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Find(&entities.Report{})
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected != 0 {
+			return MysqlErrorReportDuplicated
+		}
+
+		return tx.Create(report).Error
+	})
+}
+
+func GetReport(executor *gorm.DB, reportId int64) (*entities.Report, error) {
+	executor = returnMysqlDbObj(executor)
+	report := &entities.Report{}
+	result := executor.Where("id = ?", reportId).Find(report)
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected != 1 {
+		return nil, MysqlErrorReportNotExist
+	}
+	return report, nil
+}
+
+// SelectReports 按提交顺序返回指定状态下 id 大于 cursor 的举报
+func SelectReports(executor *gorm.DB, status byte, cursor, limit int64) ([]entities.Report, error) {
+	executor = returnMysqlDbObj(executor)
+	reports := make([]entities.Report, 0)
+	result := executor.Where("status = ? AND id > ?", status, cursor).Order("id").Limit(int(limit)).Find(&reports)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return reports, nil
+}
+
+// ReviewReport 将待处理的举报标记为已处理，并发复核同一举报时只有一个会成功
+func ReviewReport(executor *gorm.DB, reportId int64, status byte, action string, reviewedAt int64) error {
+	executor = returnMysqlDbObj(executor)
+	result := executor.Model(&entities.Report{}).
+		Where("id = ? AND status = ?", reportId, entities.ReportPending).
+		Updates(map[string]interface{}{"status": status, "review_action": action, "reviewed_at": reviewedAt})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected != 1 {
+		return MysqlErrorReportReviewed
+	}
+	return nil
+}
+
+func AddAuditLog(executor *gorm.DB, auditLog *entities.AuditLog) error {
+	executor = returnMysqlDbObj(executor)
+	return executor.Create(auditLog).Error
+}
+
+// SelectAuditLogs 按时间倒序返回 id 小于 cursor 的审计记录，cursor 为 0 时从最新的记录开始。reportId 不为 0 时只返回该举报的记录
+func SelectAuditLogs(executor *gorm.DB, reportId, cursor, limit int64) ([]entities.AuditLog, error) {
+	executor = returnMysqlDbObj(executor)
+	logs := make([]entities.AuditLog, 0)
+	query := executor.Model(&entities.AuditLog{})
+	if reportId != 0 {
+		query = query.Where("report_id = ?", reportId)
+	}
+	if cursor > 0 {
+		query = query.Where("id < ?", cursor)
+	}
+
+	if result := query.Order("id DESC").Limit(int(limit)).Find(&logs); result.Error != nil {
+		return nil, result.Error
+	}
+	return logs, nil
+}
+
+// BanUser 封禁用户至 bannedUntil，为 0 时解除封禁
+func BanUser(executor *gorm.DB, userId, bannedUntil int64) error {
+	executor = returnMysqlDbObj(executor)
+	result := executor.Model(&entities.UserInfo{}).Where("id = ? AND is_deleted = 0", userId).Update("banned_until", bannedUntil)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected != 1 {
+		return MysqlErrorUserNotExist
+	}
+	return nil
+}
+
+func GetUserBannedUntil(executor *gorm.DB, userId int64) (int64, error) {
+	executor = returnMysqlDbObj(executor)
+	var bannedUntil int64
+	result := executor.Model(&entities.UserInfo{}).Select("banned_until").Where("id = ?", userId).Find(&bannedUntil)
+	if result.Error != nil {
+		return 0, result.Error
+	} else if result.RowsAffected != 1 {
+		return 0, MysqlErrorUserNotExist
+	}
+	return bannedUntil, nil
+}
+
 func isUserInfoExist(tx *gorm.DB, userId int64) error {
 	result := tx.Model(&entities.UserInfo{}).Where("id = ?", userId).Find(entities.NewEmptyUserInfo())
 	if result.Error != nil {
@@ -380,6 +1320,28 @@ func isUserInfoExist(tx *gorm.DB, userId int64) error {
 	return nil
 }
 
+func isContactGroupExist(tx *gorm.DB, userId, contactGroupId int64) error {
+	result := tx.Where("id = ? AND user_id = ?", contactGroupId, userId).Find(&entities.ContactGroup{})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected != 1 {
+		return MysqlErrorContactGroupNotExist
+	}
+
+	return nil
+}
+
+func isContactGroupNameUnused(tx *gorm.DB, userId int64, name string) error {
+	result := tx.Where("user_id = ? AND name = ?", userId, name).Find(&entities.ContactGroup{})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected != 0 {
+		return MysqlErrorContactGroupExist
+	}
+
+	return nil
+}
+
 func setDeleteFlagForFriendship(tx *gorm.DB, userId1, userId2 int64) error {
 	result := tx.Model(&entities.Friendship{}).Where("self_id = ? AND friend_id = ?", userId1, userId2).Update("is_deleted", true)
 	if result.Error != nil {
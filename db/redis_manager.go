@@ -24,6 +24,7 @@ var (
 	redisLockTimeOut       = time.Second * 10
 	messageCacheTimeOut    = time.Hour
 	friendshipCacheTimeOut = time.Hour * 24
+	botInboxTimeOut        = time.Hour * 24 * 7
 
 	isRedisInitiated = false
 )
@@ -40,6 +41,7 @@ func InitRedisConnection(url string) {
 	}
 
 	redisConnection = redis.NewClient(options)
+	redisConnection.AddHook(redisMetricsHook{})
 	err = redisConnection.Ping(context.Background()).Err()
 	if err != nil {
 		panic(err)
@@ -61,8 +63,12 @@ func FetchMessageCache(chatId int64, seq uint64) (*entities.Message, error) {
 	key := getCacheMessageKey(chatId, seq)
 	ret := redisConnection.GetEx(context.Background(), key, messageCacheTimeOut)
 	if ret.Err() != nil {
+		if ret.Err() == redis.Nil {
+			RecordCacheAccess(CacheMessage, false)
+		}
 		return nil, returnNilForRedisNil(ret.Err())
 	}
+	RecordCacheAccess(CacheMessage, true)
 
 	result, err := ret.Result()
 	if err != nil {
@@ -76,6 +82,23 @@ func FetchMessageCache(chatId int64, seq uint64) (*entities.Message, error) {
 	return message, nil
 }
 
+const messageCacheDeleteBatch = 512
+
+// DeleteMessageCacheInRange 删除会话中序号在 [bottom, top] 范围内的消息缓存
+func DeleteMessageCacheInRange(chatId int64, bottom, top uint64) error {
+	keys := make([]string, 0, messageCacheDeleteBatch)
+	for seq := bottom; seq <= top; seq++ {
+		keys = append(keys, getCacheMessageKey(chatId, seq))
+		if len(keys) == messageCacheDeleteBatch || seq == top {
+			if err := redisConnection.Del(context.Background(), keys...).Err(); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	return nil
+}
+
 var (
 	luaScriptAtomicCheckAndSetToken = redis.NewScript(luaScriptAtomicCheckAndSetExpiringTxt)
 	luaScriptAtomicCheckToken       = redis.NewScript(luaScriptAtomicCheckAndResetTxt)
@@ -94,6 +117,19 @@ func RedisCheckAndResetToken(token string) (int64, error) {
 	return result, nil
 }
 
+// RevokeToken 使用户当前的 token 失效
+func RevokeToken(userId int64) error {
+	userKey := strconv.FormatInt(userId, 10)
+	token, err := redisConnection.Get(context.Background(), userKey).Result()
+	if err == redis.Nil {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return redisConnection.Del(context.Background(), token, userKey).Err()
+}
+
 var (
 	luaScriptAtomicSetGroupCache = redis.NewScript(luaScriptAtomicSetGroupCacheTxt)
 )
@@ -143,6 +179,7 @@ func PullGroupInfoCache(groupId int64) (*entities.GroupInfo, error) {
 
 var (
 	luaScriptAtomicSetFriendshipCache = redis.NewScript(luaScriptAtomicSetFriendshipCacheTxt)
+	luaScriptAtomicSetBlockCache      = redis.NewScript(luaScriptAtomicSetBlockCacheTxt)
 )
 
 func SetFriendshipCache(userKey string, updateTime int64, isFriend bool) error {
@@ -161,6 +198,22 @@ func PullFriendshipCache(userKey string) (bool, error) {
 	return ret.Bool()
 }
 
+func SetBlockCache(blockKey string, updateTime int64, isBlocked bool) error {
+	return luaScriptAtomicSetBlockCache.Run(context.Background(),
+		redisConnection,
+		[]string{blockKey, strconv.FormatInt(updateTime, 10)},
+		isBlocked).Err()
+}
+
+func PullBlockCache(blockKey string) (bool, error) {
+	ret := redisConnection.HGet(context.Background(), "block", blockKey)
+	if ret.Err() != nil {
+		return false, ret.Err()
+	}
+
+	return ret.Bool()
+}
+
 const (
 	LockSuccess = iota
 	LockFailed
@@ -257,6 +310,114 @@ func checkLockReturnValue(result *redis.Cmd) (bool, error) {
 	return true, nil
 }
 
+const (
+	botInboxKeyPrefix = "botInbox_"
+	botInboxLimit     = 1000
+)
+
+// PushBotInbox 将事件放入机器人收件箱，收件箱只保留最新的 botInboxLimit 条
+func PushBotInbox(botId int64, data []byte) error {
+	key := botInboxKeyPrefix + strconv.FormatInt(botId, 10)
+	_, err := redisConnection.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.LPush(context.Background(), key, data)
+		pipe.LTrim(context.Background(), key, 0, botInboxLimit-1)
+		pipe.Expire(context.Background(), key, botInboxTimeOut)
+		return nil
+	})
+	return err
+}
+
+// PopBotInbox 按到达顺序取出至多 max 条事件，收件箱为空时最多阻塞 timeout。
+// BRPOP 的超时为 0 时会一直阻塞，因此 timeout 不大于 0 时不等待
+func PopBotInbox(ctx context.Context, botId int64, timeout time.Duration, max int) ([][]byte, error) {
+	key := botInboxKeyPrefix + strconv.FormatInt(botId, 10)
+	ret := make([][]byte, 0, max)
+
+	if timeout > 0 {
+		first, err := redisConnection.BRPop(ctx, timeout, key).Result()
+		if err == redis.Nil {
+			return ret, nil
+		} else if err != nil {
+			return nil, err
+		}
+		ret = append(ret, []byte(first[1]))
+	}
+
+	for len(ret) < max {
+		data, err := redisConnection.RPop(ctx, key).Bytes()
+		if err == redis.Nil {
+			break
+		} else if err != nil {
+			return ret, err
+		}
+		ret = append(ret, data)
+	}
+	return ret, nil
+}
+
+const (
+	rateLimitKeyPrefix   = "rateLimit_"
+	tokenBucketKeyPrefix = "tokenBucket_"
+	rateLimitBanPrefix   = "rateLimitBan_"
+)
+
+var (
+	luaScriptAtomicIncrInWindow = redis.NewScript(luaScriptAtomicIncrInWindowTxt)
+	luaScriptAtomicTakeToken    = redis.NewScript(luaScriptAtomicTakeTokenTxt)
+)
+
+// CountInFixedWindow 将 name 在当前窗口内的计数加一并返回
+func CountInFixedWindow(name string, window time.Duration) (int64, error) {
+	return luaScriptAtomicIncrInWindow.Run(context.Background(),
+		redisConnection,
+		[]string{rateLimitKeyPrefix + name},
+		window.Milliseconds()).Int64()
+}
+
+// TakeBucketToken 从 name 对应的令牌桶中取出一个令牌，令牌不足时返回需要等待的时间。
+// 时间取自 Redis 服务器，各节点的时钟偏差不影响计数
+func TakeBucketToken(name string, ratePerSecond float64, burst int64) (time.Duration, error) {
+	wait, err := luaScriptAtomicTakeToken.Run(context.Background(),
+		redisConnection,
+		[]string{tokenBucketKeyPrefix + name},
+		ratePerSecond/1000, burst).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(wait) * time.Millisecond, nil
+}
+
+func SetRateLimitBan(name string, duration time.Duration) error {
+	return redisConnection.Set(context.Background(), rateLimitBanPrefix+name, 1, duration).Err()
+}
+
+// GetRateLimitBan 返回 name 剩余的封禁时间，未被封禁时返回 0
+func GetRateLimitBan(name string) (time.Duration, error) {
+	ttl, err := redisConnection.PTTL(context.Background(), rateLimitBanPrefix+name).Result()
+	if err != nil {
+		return 0, err
+	} else if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+const userMuteKeyPrefix = "userMute_"
+
+// SetUserMute 在全部会话中禁言用户 duration
+func SetUserMute(userId int64, duration time.Duration) error {
+	return redisConnection.Set(context.Background(), userMuteKeyPrefix+strconv.FormatInt(userId, 10), 1, duration).Err()
+}
+
+// IsUserMuted 判断用户是否被全局禁言
+func IsUserMuted(userId int64) (bool, error) {
+	count, err := redisConnection.Exists(context.Background(), userMuteKeyPrefix+strconv.FormatInt(userId, 10)).Result()
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
+
 func getCacheMessageKey(chatId int64, seq uint64) string {
 	return strconv.FormatInt(chatId, 10) + "_" + strconv.FormatUint(seq, 10)
 }
@@ -378,4 +539,59 @@ if (not ret) or (ret < updateTime) then
     redis.call("HSET", updateTimeTableName, userKey, updateTime)
     redis.call("HSET", friendshipTableName, userKey, isFriend)
 end`
+
+	luaScriptAtomicSetBlockCacheTxt = `
+local blockKey = KEYS[1]
+local updateTime = KEYS[2]
+local isBlocked = ARGV[1]
+
+local updateTimeTableName = "blockUpdateTime"
+local blockTableName = "block"
+
+local ret = redis.call("HGET", updateTimeTableName, blockKey)
+if (not ret) or (ret < updateTime) then
+    redis.call("HSET", updateTimeTableName, blockKey, updateTime)
+    redis.call("HSET", blockTableName, blockKey, isBlocked)
+end`
+
+	luaScriptAtomicIncrInWindowTxt = `
+local key = KEYS[1]
+local window = ARGV[1]
+
+local count = redis.call("INCR", key)
+if count == 1 then
+    redis.call("PEXPIRE", key, window)
+end
+return count`
+
+	luaScriptAtomicTakeTokenTxt = `
+local key = KEYS[1]
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call("HMGET", key, "tokens", "ts")
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if (not tokens) or (not ts) then
+    tokens = burst
+    ts = now
+end
+if now > ts then
+    tokens = math.min(burst, tokens + (now - ts) * rate)
+    ts = now
+end
+
+local wait = 0
+if tokens >= 1 then
+    tokens = tokens - 1
+else
+    wait = math.ceil((1 - tokens) / rate)
+end
+
+redis.call("HSET", key, "tokens", tostring(tokens), "ts", tostring(ts))
+redis.call("PEXPIRE", key, math.ceil(burst / rate) + 1000)
+return wait`
 )
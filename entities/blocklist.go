@@ -0,0 +1,19 @@
+package entities
+
+import "gorm.io/gorm"
+
+// Block 表示 UserId 将 BlockedId 加入了黑名单，解除后只设置删除标记
+type Block struct {
+	GormModel gorm.Model `gorm:"embedded" json:"-"`
+	UserId    int64      `gorm:"uniqueIndex:block_index;index:user_block_index" json:"userId"`
+	BlockedId int64      `gorm:"uniqueIndex:block_index" json:"blockedId"`
+	IsDeleted bool       `gorm:"index:user_block_index" json:"-"`
+}
+
+func NewBlock(userId, blockedId int64) *Block {
+	return &Block{
+		UserId:    userId,
+		BlockedId: blockedId,
+		IsDeleted: false,
+	}
+}
@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrorBotDisabled = errors.New("机器人已停用")
+	ErrorBotBanned   = errors.New("机器人已被封禁")
+)
+
+// BotInfo 为机器人账号的附加信息，Id 与其 UserInfo 的 Id 相同，ApiKeyHash 为 API Key 的 SHA-256 摘要。
+// 创建者注销账号后机器人被停用，IsDisabled 为 true
+type BotInfo struct {
+	Id            int64  `gorm:"primaryKey" json:"id"`
+	OwnerId       int64  `gorm:"index:bot_owner_index" json:"ownerId"`
+	ApiKeyHash    string `gorm:"type:char(64);uniqueIndex:bot_api_key_index" json:"-"`
+	WebhookUrl    string `gorm:"type:varchar(1024)" json:"webhookUrl"`
+	WebhookSecret string `json:"-"`
+	IsDisabled    bool   `json:"-"`
+
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+func NewBotInfo(id, ownerId int64, apiKeyHash string) *BotInfo {
+	return &BotInfo{
+		Id:         id,
+		OwnerId:    ownerId,
+		ApiKeyHash: apiKeyHash,
+	}
+}
+
+// CheckUsable 判断机器人能否调用接口，bannedUntil 为机器人账号的封禁截止时间，now 与其同为毫秒时间戳
+func (b *BotInfo) CheckUsable(bannedUntil, now int64) error {
+	if b.IsDisabled {
+		return ErrorBotDisabled
+	} else if bannedUntil > now {
+		return ErrorBotBanned
+	}
+	return nil
+}
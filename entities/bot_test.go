@@ -0,0 +1,27 @@
+package entities
+
+import "testing"
+
+func TestBotCheckUsable(t *testing.T) {
+	const now = int64(1700000000000)
+
+	cases := []struct {
+		name        string
+		disabled    bool
+		bannedUntil int64
+		want        error
+	}{
+		{"normal", false, 0, nil},
+		{"ban expired", false, now - 1, nil},
+		{"banned", false, now + 1000, ErrorBotBanned},
+		{"banned forever", false, 1<<63 - 1, ErrorBotBanned},
+		{"disabled", true, 0, ErrorBotDisabled},
+	}
+
+	for _, c := range cases {
+		info := &BotInfo{Id: 1, IsDisabled: c.disabled}
+		if err := info.CheckUsable(c.bannedUntil, now); err != c.want {
+			t.Errorf("%s: got %v, want %v", c.name, err, c.want)
+		}
+	}
+}
@@ -5,6 +5,10 @@ import "go.mongodb.org/mongo-driver/bson"
 type Chat struct {
 	Id       int64  `bson:"id"` // Id 必须是唯一索引
 	Sequence uint64 `bson:"sequence"`
+	// PurgedSeq 之前（含）的消息已按保留策略清理，读取时按空洞返回
+	PurgedSeq uint64 `bson:"purged_sequence,omitempty"`
+	// PurgeCompletedSeq 之前（含）的消息、提及与置顶记录已确实删除，小于 PurgedSeq 说明上次清理中途失败
+	PurgeCompletedSeq uint64 `bson:"purge_completed_sequence,omitempty"`
 }
 
 func NewChat(chatId int64, sequence uint64) *Chat {
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	MaxFriendTagCount  = 20
+	MaxFriendTagLength = 16
+)
+
+// ContactGroup 为用户对好友的分组，好友通过 Friendship.ContactGroupId 归入分组，0 表示未分组
+type ContactGroup struct {
+	Id     int64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserId int64  `gorm:"uniqueIndex:contact_group_index" json:"-"`
+	Name   string `gorm:"type:varchar(32);uniqueIndex:contact_group_index" json:"name"`
+}
+
+func NewContactGroup(userId int64, name string) *ContactGroup {
+	return &ContactGroup{
+		UserId: userId,
+		Name:   name,
+	}
+}
+
+// NormalizeFriendTags 去除标签两端的空白、空标签与重复标签，标签数量或长度超出限制时返回 false
+func NormalizeFriendTags(tags []string) ([]string, bool) {
+	ret := make([]string, 0, len(tags))
+	visited := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		} else if utf8.RuneCountInString(tag) > MaxFriendTagLength {
+			return nil, false
+		}
+
+		if _, ok := visited[tag]; ok {
+			continue
+		}
+		visited[tag] = struct{}{}
+		ret = append(ret, tag)
+	}
+
+	if len(ret) > MaxFriendTagCount {
+		return nil, false
+	}
+	return ret, true
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeFriendTags(t *testing.T) {
+	tooMany := make([]string, 0, MaxFriendTagCount+1)
+	for i := 0; i <= MaxFriendTagCount; i++ {
+		tooMany = append(tooMany, strconv.Itoa(i))
+	}
+
+	cases := []struct {
+		tags []string
+		want []string
+		ok   bool
+	}{
+		{nil, []string{}, true},
+		{[]string{" 同事 ", "同事", "", "  ", "大学"}, []string{"同事", "大学"}, true},
+		{[]string{strings.Repeat("长", MaxFriendTagLength)}, []string{strings.Repeat("长", MaxFriendTagLength)}, true},
+		{[]string{strings.Repeat("长", MaxFriendTagLength+1)}, nil, false},
+		{tooMany, nil, false},
+	}
+
+	for i, c := range cases {
+		if got, ok := NormalizeFriendTags(c.tags); ok != c.ok || !reflect.DeepEqual(got, c.want) {
+			t.Errorf("case %d: got (%v, %v), want (%v, %v)", i, got, ok, c.want, c.ok)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"encoding/base64"
+	"errors"
+)
+
+const (
+	// MaxOneTimePreKeysPerUpload 为单次上传的一次性预密钥数量上限
+	MaxOneTimePreKeysPerUpload = 100
+	// MaxEncryptionDevices 为每个用户可登记密钥的设备数量上限
+	MaxEncryptionDevices = 8
+)
+
+var (
+	ErrorPublicKeyIllegal = errors.New("公钥格式错误")
+	ErrorSignatureIllegal = errors.New("签名格式错误")
+	ErrorPreKeyTooMany    = errors.New("一次性预密钥数量超出上限")
+	ErrorPreKeyDuplicated = errors.New("一次性预密钥 id 重复")
+)
+
+// DeviceKey 为用户某台设备的身份公钥与签名预密钥，服务端只负责存储与分发，不验证签名，
+// 签名由发起会话的客户端按 X3DH 流程使用身份公钥验证。公钥与签名均以标准 base64 编码
+type DeviceKey struct {
+	UserId                int64  `gorm:"primaryKey;autoIncrement:false" json:"userId"`
+	DeviceId              int64  `gorm:"primaryKey;autoIncrement:false" json:"deviceId"`
+	IdentityKey           string `gorm:"type:varchar(64)" json:"identityKey"`
+	SignedPreKeyId        int64  `json:"signedPreKeyId"`
+	SignedPreKey          string `gorm:"type:varchar(64)" json:"signedPreKey"`
+	SignedPreKeySignature string `gorm:"type:varchar(128)" json:"signedPreKeySignature"`
+	UpdatedAt             int64  `gorm:"autoUpdateTime:milli" json:"updatedAt"`
+}
+
+// OneTimePreKey 为一次性预密钥，被其他用户领取后即删除
+type OneTimePreKey struct {
+	UserId    int64  `gorm:"primaryKey;autoIncrement:false" json:"-"`
+	DeviceId  int64  `gorm:"primaryKey;autoIncrement:false" json:"-"`
+	KeyId     int64  `gorm:"primaryKey;autoIncrement:false" json:"keyId"`
+	PublicKey string `gorm:"type:varchar(64)" json:"publicKey"`
+}
+
+// PreKeyBundle 为发起加密会话所需的一组密钥，设备的一次性预密钥耗尽时 OneTimePreKey 为空
+type PreKeyBundle struct {
+	DeviceId              int64  `json:"deviceId"`
+	IdentityKey           string `json:"identityKey"`
+	SignedPreKeyId        int64  `json:"signedPreKeyId"`
+	SignedPreKey          string `json:"signedPreKey"`
+	SignedPreKeySignature string `json:"signedPreKeySignature"`
+	OneTimePreKeyId       int64  `json:"oneTimePreKeyId,omitempty"`
+	OneTimePreKey         string `json:"oneTimePreKey,omitempty"`
+}
+
+func NewPreKeyBundle(key *DeviceKey, preKey *OneTimePreKey) *PreKeyBundle {
+	bundle := &PreKeyBundle{
+		DeviceId:              key.DeviceId,
+		IdentityKey:           key.IdentityKey,
+		SignedPreKeyId:        key.SignedPreKeyId,
+		SignedPreKey:          key.SignedPreKey,
+		SignedPreKeySignature: key.SignedPreKeySignature,
+	}
+	if preKey != nil {
+		bundle.OneTimePreKeyId = preKey.KeyId
+		bundle.OneTimePreKey = preKey.PublicKey
+	}
+	return bundle
+}
+
+// IsValidPublicKey Curve25519 公钥为 32 字节，带类型前缀时为 33 字节
+func IsValidPublicKey(key string) bool {
+	data, err := base64.StdEncoding.DecodeString(key)
+	return err == nil && (len(data) == 32 || len(data) == 33)
+}
+
+func IsValidSignature(signature string) bool {
+	data, err := base64.StdEncoding.DecodeString(signature)
+	return err == nil && len(data) == 64
+}
+
+// Validate 检查设备密钥与随附的一次性预密钥的格式
+func (k *DeviceKey) Validate(preKeys []OneTimePreKey) error {
+	if !IsValidPublicKey(k.IdentityKey) || !IsValidPublicKey(k.SignedPreKey) {
+		return ErrorPublicKeyIllegal
+	} else if !IsValidSignature(k.SignedPreKeySignature) {
+		return ErrorSignatureIllegal
+	} else if len(preKeys) > MaxOneTimePreKeysPerUpload {
+		return ErrorPreKeyTooMany
+	}
+
+	ids := make(map[int64]struct{}, len(preKeys))
+	for _, preKey := range preKeys {
+		if !IsValidPublicKey(preKey.PublicKey) {
+			return ErrorPublicKeyIllegal
+		} else if _, ok := ids[preKey.KeyId]; ok {
+			return ErrorPreKeyDuplicated
+		}
+		ids[preKey.KeyId] = struct{}{}
+	}
+	return nil
+}
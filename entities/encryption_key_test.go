@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestDeviceKeyValidate(t *testing.T) {
+	var (
+		publicKey = base64.StdEncoding.EncodeToString(make([]byte, 33))
+		signature = base64.StdEncoding.EncodeToString(make([]byte, 64))
+		valid     = DeviceKey{IdentityKey: publicKey, SignedPreKey: publicKey, SignedPreKeySignature: signature}
+	)
+
+	tooMany := make([]OneTimePreKey, MaxOneTimePreKeysPerUpload+1)
+	for i := range tooMany {
+		tooMany[i] = OneTimePreKey{KeyId: int64(i), PublicKey: publicKey}
+	}
+
+	withIdentity := func(identity string) *DeviceKey {
+		key := valid
+		key.IdentityKey = identity
+		return &key
+	}
+	withSignature := func(signature string) *DeviceKey {
+		key := valid
+		key.SignedPreKeySignature = signature
+		return &key
+	}
+
+	cases := []struct {
+		key     *DeviceKey
+		preKeys []OneTimePreKey
+		want    error
+	}{
+		{&valid, nil, nil},
+		{&valid, []OneTimePreKey{{KeyId: 1, PublicKey: publicKey}, {KeyId: 2, PublicKey: publicKey}}, nil},
+		{withIdentity(""), nil, ErrorPublicKeyIllegal},
+		{withIdentity("not base64!"), nil, ErrorPublicKeyIllegal},
+		{withIdentity(base64.StdEncoding.EncodeToString(make([]byte, 16))), nil, ErrorPublicKeyIllegal},
+		{withSignature(strings.Repeat("A", 8)), nil, ErrorSignatureIllegal},
+		{&valid, []OneTimePreKey{{KeyId: 1, PublicKey: "bad"}}, ErrorPublicKeyIllegal},
+		{&valid, []OneTimePreKey{{KeyId: 1, PublicKey: publicKey}, {KeyId: 1, PublicKey: publicKey}}, ErrorPreKeyDuplicated},
+		{&valid, tooMany, ErrorPreKeyTooMany},
+	}
+
+	for i, c := range cases {
+		if got := c.key.Validate(c.preKeys); got != c.want {
+			t.Errorf("case %d: got %v, want %v", i, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package entities
+
+const (
+	// ExportChat 导出一个会话的全部消息
+	ExportChat byte = iota
+	// ExportAccount 导出用户的资料、好友、群组与通知
+	ExportAccount
+)
+
+const (
+	ExportFormatJsonLines = "jsonl"
+	ExportFormatHtml      = "html"
+)
+
+const (
+	ExportPending byte = iota
+	ExportRunning
+	ExportDone
+	ExportFailed
+	// ExportExpired 导出文件已超过保存期限被删除
+	ExportExpired
+)
+
+// ExportJob 为异步导出任务。UserId 为导出数据所属的用户，IsByOperator 为真时任务由运维接口发起，
+// 只能通过运维接口查看与下载。私聊导出时 ChatId 为对方的用户 id
+type ExportJob struct {
+	Id           int64  `gorm:"primaryKey;autoIncrement:false" json:"id"`
+	UserId       int64  `gorm:"index" json:"userId"`
+	IsByOperator bool   `json:"isByOperator"`
+	Type         byte   `json:"type"`
+	ChatId       int64  `json:"chatId"`
+	Format       string `gorm:"type:varchar(8)" json:"format"`
+	Status       byte   `json:"status"`
+	Processed    int64  `json:"processed"`
+	Total        int64  `json:"total"`
+	Error        string `gorm:"type:varchar(255)" json:"error"`
+	CreatedAt    int64  `gorm:"autoCreateTime:milli" json:"createdAt"`
+	FinishedAt   int64  `json:"finishedAt"`
+	// HeartbeatAt 为执行节点最后一次汇报进度的时间，长时间未更新的执行中任务会被其他节点重新执行
+	HeartbeatAt int64 `json:"-"`
+}
+
+func NewExportJob(id, userId int64, jobType byte, chatId int64, format string, isByOperator bool) *ExportJob {
+	return &ExportJob{
+		Id:           id,
+		UserId:       userId,
+		IsByOperator: isByOperator,
+		Type:         jobType,
+		ChatId:       chatId,
+		Format:       format,
+		Status:       ExportPending,
+	}
+}
+
+func IsValidExportFormat(format string) bool {
+	return format == ExportFormatJsonLines || format == ExportFormatHtml
+}
+
+// Progress 返回任务的完成百分比
+func (j *ExportJob) Progress() int64 {
+	if j.Status == ExportDone {
+		return 100
+	} else if j.Total <= 0 {
+		return 0
+	}
+
+	progress := j.Processed * 100 / j.Total
+	if progress > 99 {
+		progress = 99
+	}
+	return progress
+}
@@ -0,0 +1,32 @@
+package entities
+
+import "testing"
+
+func TestExportJobProgress(t *testing.T) {
+	cases := []struct {
+		status           byte
+		processed, total int64
+		want             int64
+	}{
+		{ExportPending, 0, 0, 0},
+		{ExportRunning, 50, 200, 25},
+		{ExportRunning, 200, 200, 99},
+		{ExportDone, 0, 0, 100},
+		{ExportFailed, 10, 20, 50},
+	}
+
+	for _, c := range cases {
+		job := &ExportJob{Status: c.status, Processed: c.processed, Total: c.total}
+		if got := job.Progress(); got != c.want {
+			t.Errorf("status %d %d/%d: got %d, want %d", c.status, c.processed, c.total, got, c.want)
+		}
+	}
+}
+
+func TestIsValidExportFormat(t *testing.T) {
+	for format, want := range map[string]bool{"jsonl": true, "html": true, "csv": false, "": false} {
+		if got := IsValidExportFormat(format); got != want {
+			t.Errorf("format %q: got %v, want %v", format, got, want)
+		}
+	}
+}
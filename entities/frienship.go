@@ -10,6 +10,10 @@ type Friendship struct {
 	FriendId  int64      `gorm:"uniqueIndex:friend_index" json:"friendId"`
 	IsDeleted bool       `gorm:"index:self_reverse_index" json:"-"`
 	ChatId    int64      `gorm:"index:chat_id_index" json:"chatId"`
+
+	Remark         string   `gorm:"type:varchar(32)" json:"remark"`
+	Tags           []string `gorm:"serializer:json" json:"tags"`
+	ContactGroupId int64    `json:"contactGroupId"`
 }
 
 func NewFriendship(selfId, friendId, chatId int64) *Friendship {
@@ -0,0 +1,29 @@
+package entities
+
+import "time"
+
+// GroupAnnouncement 为群组公告，群组以最新发布的一条作为当前公告
+type GroupAnnouncement struct {
+	Id        int64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	GroupId   int64  `gorm:"index" json:"groupId"`
+	Title     string `gorm:"type:varchar(64)" json:"title"`
+	Body      string `gorm:"type:text" json:"body"`
+	AuthorId  int64  `json:"authorId"`
+	CreatedAt int64  `gorm:"autoCreateTime:milli" json:"createdAt"`
+	AckCount  int64  `json:"ackCount"`
+}
+
+type GroupAnnouncementAck struct {
+	AnnouncementId int64 `gorm:"primaryKey;autoIncrement:false"`
+	UserId         int64 `gorm:"primaryKey;autoIncrement:false"`
+	CreatedAt      time.Time
+}
+
+func NewGroupAnnouncement(groupId, authorId int64, title, body string) *GroupAnnouncement {
+	return &GroupAnnouncement{
+		GroupId:  groupId,
+		Title:    title,
+		Body:     body,
+		AuthorId: authorId,
+	}
+}
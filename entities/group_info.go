@@ -10,11 +10,18 @@ type GroupInfo struct {
 	Introduction string `json:"introduction"`
 	Avatar       string `json:"avatar"`
 	IsDeleted    bool   `json:"-"`
+	IsMuteAll    bool   `json:"isMuteAll"`
+
+	JoinPolicy       byte `json:"joinPolicy"`
+	SuccessionPolicy byte `json:"successionPolicy"`
+	// RetentionPolicy 为 nil 时使用全局默认的消息保留策略
+	RetentionPolicy *RetentionPolicy `gorm:"serializer:json" json:"retentionPolicy"`
 
 	CreatedAt time.Time `json:"-"`
 	UpdatedAt time.Time `json:"-"`
 
 	Members []GroupMember `gorm:"foreignKey:GroupId" json:"members"`
+	Roles   []GroupRole   `gorm:"foreignKey:GroupId" json:"roles"`
 }
 
 func NewGroupInfo(id, owner int64, name, introduction, avatar string) *GroupInfo {
@@ -0,0 +1,74 @@
+package entities
+
+import "time"
+
+const (
+	// JoinPolicyApproval 申请加入需要审批，为群组的默认规则
+	JoinPolicyApproval byte = iota
+	JoinPolicyOpen
+	// JoinPolicyInviteOnly 只能通过邀请或邀请链接加入
+	JoinPolicyInviteOnly
+	JoinPolicyClosed
+)
+
+const (
+	JoinRejected byte = iota
+	JoinNeedApproval
+	JoinDirectly
+)
+
+type GroupInvite struct {
+	Code      string    `gorm:"primaryKey;type:varchar(32)" json:"code"`
+	GroupId   int64     `gorm:"index" json:"groupId"`
+	CreatorId int64     `json:"creatorId"`
+	MaxUses   int64     `json:"maxUses"`
+	UsedCount int64     `json:"usedCount"`
+	ExpiresAt int64     `json:"expiresAt"`
+	IsRevoked bool      `json:"isRevoked"`
+	CreatedAt time.Time `json:"-"`
+}
+
+func NewGroupInvite(code string, groupId, creatorId, maxUses, expiresAt int64) *GroupInvite {
+	return &GroupInvite{
+		Code:      code,
+		GroupId:   groupId,
+		CreatorId: creatorId,
+		MaxUses:   maxUses,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func IsValidJoinPolicy(policy byte) bool {
+	return policy <= JoinPolicyClosed
+}
+
+// IsUsableAt 判断邀请链接在 now（毫秒时间戳）时能否使用，MaxUses 为 0 时不限制使用次数
+func (invite *GroupInvite) IsUsableAt(now int64) bool {
+	return !invite.IsRevoked && invite.ExpiresAt > now && (invite.MaxUses == 0 || invite.UsedCount < invite.MaxUses)
+}
+
+// JoinActionFor 按群组的加入规则判断用户如何加入群组，inviterId 为 0 时表示用户主动申请加入
+// 邀请者有权处理入群申请时被邀请者可以直接加入，否则仍需审批
+func (info *GroupInfo) JoinActionFor(inviterId int64) byte {
+	switch info.JoinPolicy {
+	case JoinPolicyOpen:
+		return JoinDirectly
+	case JoinPolicyClosed:
+		return JoinRejected
+	}
+
+	if inviterId == 0 {
+		if info.JoinPolicy == JoinPolicyInviteOnly {
+			return JoinRejected
+		}
+		return JoinNeedApproval
+	}
+
+	permissions, isIn := info.PermissionsOf(inviterId)
+	if !isIn {
+		return JoinRejected
+	} else if permissions.Has(PermissionApproveJoin) {
+		return JoinDirectly
+	}
+	return JoinNeedApproval
+}
@@ -0,0 +1,58 @@
+package entities
+
+import "testing"
+
+func TestJoinActionFor(t *testing.T) {
+	info := &GroupInfo{
+		Id:    -1,
+		Owner: 1,
+		Members: []GroupMember{
+			{MemberId: 1, Role: RoleOwner},
+			{MemberId: 2, Role: RoleAdministrator},
+			{MemberId: 3, Role: RoleMember},
+			{MemberId: 4, Role: RoleAdministrator, IsDeleted: true},
+		},
+	}
+
+	cases := []struct {
+		policy    byte
+		inviterId int64
+		action    byte
+	}{
+		{JoinPolicyApproval, 0, JoinNeedApproval},
+		{JoinPolicyApproval, 2, JoinDirectly},
+		{JoinPolicyApproval, 3, JoinNeedApproval},
+		{JoinPolicyApproval, 4, JoinRejected},
+		{JoinPolicyOpen, 0, JoinDirectly},
+		{JoinPolicyInviteOnly, 0, JoinRejected},
+		{JoinPolicyInviteOnly, 1, JoinDirectly},
+		{JoinPolicyInviteOnly, 3, JoinNeedApproval},
+		{JoinPolicyClosed, 1, JoinRejected},
+	}
+
+	for _, c := range cases {
+		info.JoinPolicy = c.policy
+		if action := info.JoinActionFor(c.inviterId); action != c.action {
+			t.Errorf("policy %d with inviter %d: got %d, want %d", c.policy, c.inviterId, action, c.action)
+		}
+	}
+}
+
+func TestGroupInviteIsUsableAt(t *testing.T) {
+	cases := []struct {
+		invite GroupInvite
+		usable bool
+	}{
+		{GroupInvite{ExpiresAt: 2000}, true},
+		{GroupInvite{ExpiresAt: 1000}, false},
+		{GroupInvite{ExpiresAt: 2000, IsRevoked: true}, false},
+		{GroupInvite{ExpiresAt: 2000, MaxUses: 2, UsedCount: 1}, true},
+		{GroupInvite{ExpiresAt: 2000, MaxUses: 2, UsedCount: 2}, false},
+	}
+
+	for i, c := range cases {
+		if usable := c.invite.IsUsableAt(1000); usable != c.usable {
+			t.Errorf("case %d: got %v, want %v", i, usable, c.usable)
+		}
+	}
+}
@@ -9,14 +9,22 @@ type GroupMember struct {
 	GroupId         int64      `gorm:"uniqueIndex:group_info_index" json:"groupId"`
 	MemberId        int64      `gorm:"uniqueIndex:group_info_index;index:reverse_select_index" json:"memberId"`
 	IsAdministrator bool       `json:"isAdministrator"`
+	Role            string     `gorm:"type:varchar(32)" json:"role"`
+	MutedUntil      int64      `json:"mutedUntil"`
 	IsDeleted       bool       `gorm:"uniqueIndex:group_info_index;index:reverse_select_index" json:"-"`
 }
 
 func NewGroupMember(groupId, userId int64, isAdministrator bool) *GroupMember {
+	role := RoleMember
+	if isAdministrator {
+		role = RoleAdministrator
+	}
+
 	return &GroupMember{
 		GroupId:         groupId,
 		MemberId:        userId,
 		IsAdministrator: isAdministrator,
+		Role:            role,
 		IsDeleted:       false,
 	}
 }
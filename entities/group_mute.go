@@ -0,0 +1,20 @@
+package entities
+
+// IsMutedAt 判断成员在 now（毫秒时间戳）时是否被禁言，拥有禁言权限的成员不受全员禁言影响
+func (info *GroupInfo) IsMutedAt(userId, now int64) bool {
+	permissions, isIn := info.PermissionsOf(userId)
+	if !isIn || userId == info.Owner {
+		return false
+	}
+
+	if info.IsMuteAll && !permissions.Has(PermissionMuteMember) {
+		return true
+	}
+
+	for _, member := range info.Members {
+		if member.MemberId == userId && !member.IsDeleted {
+			return member.MutedUntil > now
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package entities
+
+import "testing"
+
+func TestIsMutedAt(t *testing.T) {
+	info := &GroupInfo{
+		Id:        -1,
+		Owner:     1,
+		IsMuteAll: true,
+		Members: []GroupMember{
+			{GroupId: -1, MemberId: 1, Role: RoleOwner},
+			{GroupId: -1, MemberId: 2, Role: RoleModerator},
+			{GroupId: -1, MemberId: 3, Role: RoleMember},
+			{GroupId: -1, MemberId: 4, Role: RoleModerator, MutedUntil: 2000},
+		},
+	}
+
+	cases := []struct {
+		userId  int64
+		now     int64
+		isMuted bool
+	}{
+		{1, 1000, false},
+		{2, 1000, false},
+		{3, 1000, true},
+		{4, 1000, true},
+		{4, 2000, false},
+		{5, 1000, false},
+	}
+
+	for _, c := range cases {
+		if isMuted := info.IsMutedAt(c.userId, c.now); isMuted != c.isMuted {
+			t.Errorf("user %d at %d: got %v, want %v", c.userId, c.now, isMuted, c.isMuted)
+		}
+	}
+
+	info.IsMuteAll = false
+	if info.IsMutedAt(3, 1000) {
+		t.Errorf("user 3 should not be muted after mute all is lifted")
+	}
+}
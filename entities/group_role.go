@@ -0,0 +1,129 @@
+package entities
+
+type GroupPermission uint32
+
+const (
+	// PermissionEditGroupInfo 修改群名、群介绍与群头像
+	PermissionEditGroupInfo GroupPermission = 1 << iota
+	PermissionApproveJoin
+	PermissionRemoveMember
+	PermissionPinMessage
+	PermissionMuteMember
+	PermissionMentionAll
+	// PermissionManageRoles 定义角色并为成员分配角色
+	PermissionManageRoles
+
+	PermissionAll = PermissionEditGroupInfo | PermissionApproveJoin | PermissionRemoveMember | PermissionPinMessage |
+		PermissionMuteMember | PermissionMentionAll | PermissionManageRoles
+)
+
+const (
+	RoleOwner         = "owner"
+	RoleAdministrator = "admin"
+	RoleModerator     = "moderator"
+	RoleMember        = "member"
+)
+
+// builtinRolePermissions 为内置角色的默认权限，群组可以覆盖除群主以外内置角色的权限
+var builtinRolePermissions = map[string]GroupPermission{
+	RoleOwner: PermissionAll,
+	RoleAdministrator: PermissionEditGroupInfo | PermissionApproveJoin | PermissionRemoveMember | PermissionPinMessage |
+		PermissionMuteMember | PermissionMentionAll,
+	RoleModerator: PermissionApproveJoin | PermissionPinMessage | PermissionMuteMember,
+	RoleMember:    0,
+}
+
+var builtinRoleOrder = []string{RoleOwner, RoleAdministrator, RoleModerator, RoleMember}
+
+type GroupRole struct {
+	Id          int64           `gorm:"primaryKey;autoIncrement" json:"-"`
+	GroupId     int64           `gorm:"uniqueIndex:group_role_index" json:"-"`
+	Name        string          `gorm:"type:varchar(32);uniqueIndex:group_role_index" json:"name"`
+	Permissions GroupPermission `json:"permissions"`
+	IsBuiltin   bool            `gorm:"-" json:"isBuiltin"`
+}
+
+func NewGroupRole(groupId int64, name string, permissions GroupPermission) *GroupRole {
+	return &GroupRole{
+		GroupId:     groupId,
+		Name:        name,
+		Permissions: permissions,
+		IsBuiltin:   IsBuiltinRole(name),
+	}
+}
+
+func (p GroupPermission) Has(permission GroupPermission) bool {
+	return p&permission == permission
+}
+
+func IsBuiltinRole(name string) bool {
+	_, ok := builtinRolePermissions[name]
+	return ok
+}
+
+// RoleName 返回成员的角色名，旧数据中未设置角色的成员按 IsAdministrator 推断
+func (m *GroupMember) RoleName() string {
+	if m.Role != "" {
+		return m.Role
+	} else if m.IsAdministrator {
+		return RoleAdministrator
+	}
+	return RoleMember
+}
+
+// RolePermissions 返回群组内某个角色的权限，群组定义的同名角色优先于内置角色
+func (info *GroupInfo) RolePermissions(name string) (GroupPermission, bool) {
+	if name == RoleOwner {
+		return PermissionAll, true
+	}
+
+	for _, role := range info.Roles {
+		if role.Name == name {
+			return role.Permissions, true
+		}
+	}
+
+	permissions, ok := builtinRolePermissions[name]
+	return permissions, ok
+}
+
+// PermissionsOf 返回用户在群组内的权限以及用户是否在群组内，所有群组操作的鉴权都应以此为准
+func (info *GroupInfo) PermissionsOf(userId int64) (GroupPermission, bool) {
+	if info.IsDeleted {
+		return 0, false
+	}
+
+	for i := range info.Members {
+		member := &info.Members[i]
+		if member.MemberId != userId || member.IsDeleted {
+			continue
+		}
+
+		if info.Owner == userId {
+			return PermissionAll, true
+		}
+		permissions, _ := info.RolePermissions(member.RoleName())
+		return permissions, true
+	}
+
+	if info.Owner == userId {
+		return PermissionAll, true
+	}
+	return 0, false
+}
+
+// EffectiveRoles 返回群组内可用的全部角色，内置角色在前
+func (info *GroupInfo) EffectiveRoles() []GroupRole {
+	ret := make([]GroupRole, 0, len(builtinRoleOrder)+len(info.Roles))
+	for _, name := range builtinRoleOrder {
+		permissions, _ := info.RolePermissions(name)
+		ret = append(ret, GroupRole{GroupId: info.Id, Name: name, Permissions: permissions, IsBuiltin: true})
+	}
+
+	for _, role := range info.Roles {
+		if !IsBuiltinRole(role.Name) {
+			ret = append(ret, role)
+		}
+	}
+	return ret
+}
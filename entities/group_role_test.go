@@ -0,0 +1,74 @@
+package entities
+
+import "testing"
+
+func TestPermissionsOf(t *testing.T) {
+	info := &GroupInfo{
+		Id:    -1,
+		Owner: 1,
+		Members: []GroupMember{
+			{GroupId: -1, MemberId: 1, IsAdministrator: true, Role: RoleOwner},
+			{GroupId: -1, MemberId: 2, Role: RoleAdministrator},
+			// 旧数据没有角色字段
+			{GroupId: -1, MemberId: 3, IsAdministrator: true},
+			{GroupId: -1, MemberId: 4, Role: RoleModerator},
+			{GroupId: -1, MemberId: 5, Role: "pinner"},
+			{GroupId: -1, MemberId: 6},
+			{GroupId: -1, MemberId: 7, Role: "unknown"},
+		},
+		Roles: []GroupRole{
+			{GroupId: -1, Name: "pinner", Permissions: PermissionPinMessage},
+			{GroupId: -1, Name: RoleModerator, Permissions: PermissionPinMessage},
+			{GroupId: -1, Name: RoleOwner, Permissions: 0},
+		},
+	}
+
+	cases := []struct {
+		userId      int64
+		permissions GroupPermission
+		isIn        bool
+	}{
+		{1, PermissionAll, true},
+		{2, builtinRolePermissions[RoleAdministrator], true},
+		{3, builtinRolePermissions[RoleAdministrator], true},
+		{4, PermissionPinMessage, true},
+		{5, PermissionPinMessage, true},
+		{6, 0, true},
+		{7, 0, true},
+		{8, 0, false},
+	}
+
+	for _, c := range cases {
+		permissions, isIn := info.PermissionsOf(c.userId)
+		if permissions != c.permissions || isIn != c.isIn {
+			t.Errorf("user %d: got (%b, %v), want (%b, %v)", c.userId, permissions, isIn, c.permissions, c.isIn)
+		}
+	}
+
+	if !builtinRolePermissions[RoleAdministrator].Has(PermissionMentionAll) || builtinRolePermissions[RoleAdministrator].Has(PermissionManageRoles) {
+		t.Errorf("unexpected administrator permissions %b", builtinRolePermissions[RoleAdministrator])
+	}
+}
+
+func TestEffectiveRoles(t *testing.T) {
+	info := &GroupInfo{
+		Id: -1,
+		Roles: []GroupRole{
+			{GroupId: -1, Name: RoleMember, Permissions: PermissionPinMessage},
+			{GroupId: -1, Name: "pinner", Permissions: PermissionPinMessage},
+		},
+	}
+
+	roles := info.EffectiveRoles()
+	if len(roles) != 5 {
+		t.Fatalf("got %d roles, want 5", len(roles))
+	}
+
+	if roles[3].Name != RoleMember || roles[3].Permissions != PermissionPinMessage || !roles[3].IsBuiltin {
+		t.Errorf("builtin role not overridden: %+v", roles[3])
+	}
+
+	if roles[4].Name != "pinner" || roles[4].IsBuiltin {
+		t.Errorf("unexpected custom role: %+v", roles[4])
+	}
+}
@@ -0,0 +1,50 @@
+package entities
+
+const (
+	// SuccessionNone 群主注销账号后解散群组
+	SuccessionNone byte = iota
+	// SuccessionAdministrator 由最早加入的管理员继任，没有管理员时解散群组
+	SuccessionAdministrator
+	// SuccessionAnyMember 由最早加入的管理员继任，没有管理员时由最早加入的成员继任
+	SuccessionAnyMember
+)
+
+func IsValidSuccessionPolicy(policy byte) bool {
+	return policy <= SuccessionAnyMember
+}
+
+// Successor 按群组的继任规则返回群主注销后的继任者，成员需要包含加入时间
+func (info *GroupInfo) Successor() (int64, bool) {
+	var administrator, member *GroupMember
+	for i := range info.Members {
+		candidate := &info.Members[i]
+		if candidate.IsDeleted || candidate.MemberId == info.Owner {
+			continue
+		}
+
+		if candidate.RoleName() == RoleAdministrator {
+			if administrator == nil || joinedEarlier(candidate, administrator) {
+				administrator = candidate
+			}
+		} else if member == nil || joinedEarlier(candidate, member) {
+			member = candidate
+		}
+	}
+
+	switch {
+	case info.SuccessionPolicy == SuccessionNone:
+		return 0, false
+	case administrator != nil:
+		return administrator.MemberId, true
+	case info.SuccessionPolicy == SuccessionAnyMember && member != nil:
+		return member.MemberId, true
+	}
+	return 0, false
+}
+
+func joinedEarlier(a, b *GroupMember) bool {
+	if a.GormModel.CreatedAt.Equal(b.GormModel.CreatedAt) {
+		return a.GormModel.ID < b.GormModel.ID
+	}
+	return a.GormModel.CreatedAt.Before(b.GormModel.CreatedAt)
+}
@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestSuccessor(t *testing.T) {
+	joinedAt := func(minute int) gorm.Model {
+		return gorm.Model{CreatedAt: time.Date(2022, 10, 1, 0, minute, 0, 0, time.UTC)}
+	}
+
+	info := &GroupInfo{
+		Id:    -1,
+		Owner: 1,
+		Members: []GroupMember{
+			{GormModel: joinedAt(0), MemberId: 1, Role: RoleOwner},
+			{GormModel: joinedAt(1), MemberId: 2, Role: RoleMember},
+			{GormModel: joinedAt(3), MemberId: 3, Role: RoleAdministrator},
+			{GormModel: joinedAt(2), MemberId: 4, IsAdministrator: true},
+			{GormModel: joinedAt(0), MemberId: 5, Role: RoleAdministrator, IsDeleted: true},
+		},
+	}
+
+	cases := []struct {
+		policy    byte
+		members   int
+		successor int64
+		ok        bool
+	}{
+		{SuccessionNone, 5, 0, false},
+		{SuccessionAdministrator, 5, 4, true},
+		{SuccessionAnyMember, 5, 4, true},
+		{SuccessionAdministrator, 2, 0, false},
+		{SuccessionAnyMember, 2, 2, true},
+		{SuccessionAnyMember, 1, 0, false},
+	}
+
+	members := info.Members
+	for _, c := range cases {
+		info.SuccessionPolicy = c.policy
+		info.Members = members[:c.members]
+		if successor, ok := info.Successor(); successor != c.successor || ok != c.ok {
+			t.Errorf("policy %d with %d members: got (%d, %v), want (%d, %v)", c.policy, c.members, successor, ok, c.successor, c.ok)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package entities
+
+type Mention struct {
+	UserId    int64  `bson:"user_id" json:"userId"`
+	ChatId    int64  `bson:"chat_id" json:"chatId"`
+	Seq       uint64 `bson:"sequence" json:"seq"`
+	SenderId  int64  `bson:"sender_id" json:"senderId"`
+	Timestamp uint64 `bson:"timestamp" json:"timestamp"`
+	IsAll     bool   `bson:"is_all" json:"isAll"`
+	IsRead    bool   `bson:"is_read" json:"isRead"`
+}
+
+// MentionCount 为某个会话内未读的提及数量，FirstUnreadSeq 供客户端跳转到第一条未读提及
+type MentionCount struct {
+	ChatId         int64  `bson:"_id" json:"chatId"`
+	UnreadCount    int64  `bson:"unread_count" json:"unreadCount"`
+	FirstUnreadSeq uint64 `bson:"first_unread_seq" json:"firstUnreadSeq"`
+}
+
+func NewMention(userId int64, m *Message) *Mention {
+	return &Mention{
+		UserId:    userId,
+		ChatId:    m.Receiver,
+		Seq:       m.Id,
+		SenderId:  m.Sender,
+		Timestamp: m.Timestamp,
+		IsAll:     m.MentionAll,
+		IsRead:    false,
+	}
+}
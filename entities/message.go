@@ -4,6 +4,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"liveChat/rpc"
 	"strings"
+	"unicode/utf8"
 )
 
 type ContentType uint8
@@ -12,6 +13,8 @@ const (
 	Text ContentType = iota
 	Image
 	Emoji
+	// Encrypted 为端到端加密的私聊消息，内容为客户端加密后的密文，服务端只负责转发与存储
+	Encrypted
 )
 
 const protobufStringLengthLimit = 232
@@ -24,6 +27,9 @@ type Message struct {
 	Type      ContentType `bson:"type"`
 
 	Content string `bson:"content"`
+
+	Mentions   []int64 `bson:"mentions,omitempty"`
+	MentionAll bool    `bson:"mention_all,omitempty"`
 }
 
 func NewMessage(id uint64, sender, receiver int64, timestamp uint64, contentType ContentType, content string) *Message {
@@ -38,7 +44,7 @@ func NewMessage(id uint64, sender, receiver int64, timestamp uint64, contentType
 }
 
 func NewMessageFromProtobufWithoutSeq(m *rpc.Message) *Message {
-	return NewMessage(
+	message := NewMessage(
 		0,
 		m.GetSender(),
 		m.GetReceiver(),
@@ -46,17 +52,15 @@ func NewMessageFromProtobufWithoutSeq(m *rpc.Message) *Message {
 		ContentType(m.GetType()),
 		buildStringFromProtobuf(m.Contents),
 	)
+	message.Mentions = m.GetMentions()
+	message.MentionAll = m.GetMentionAll()
+	return message
 }
 
 func NewMessageFromProtobufWithSeq(m *rpc.Message) *Message {
-	return NewMessage(
-		m.Id,
-		m.GetSender(),
-		m.GetReceiver(),
-		m.GetTimestamp(),
-		ContentType(m.GetType()),
-		buildStringFromProtobuf(m.Contents),
-	)
+	message := NewMessageFromProtobufWithoutSeq(m)
+	message.Id = m.Id
+	return message
 }
 
 func NewEmptyMessage() *Message {
@@ -76,21 +80,29 @@ func NewMessageFromChangeStreamBson(data bson.D) *Message {
 
 func TransferMessageToProtoBuf(m *Message) *rpc.Message {
 	message := rpc.Message{
-		Id:        m.Id,
-		Sender:    m.Sender,
-		Receiver:  m.Receiver,
-		Timestamp: m.Timestamp,
-		Type:      rpc.MessageContentType(m.Type),
-		Contents:  nil,
+		Id:         m.Id,
+		Sender:     m.Sender,
+		Receiver:   m.Receiver,
+		Timestamp:  m.Timestamp,
+		Type:       rpc.MessageContentType(m.Type),
+		Contents:   nil,
+		Mentions:   m.Mentions,
+		MentionAll: m.MentionAll,
 	}
 
-	for i := 0; i < len(m.Content); i += protobufStringLengthLimit {
+	// 按字节切分时需避开多字节字符的中间位置，否则 protobuf 会因非法 UTF-8 拒绝序列化
+	for i := 0; i < len(m.Content); {
 		ceil := i + protobufStringLengthLimit
 		if ceil >= len(m.Content) {
 			message.Contents = append(message.Contents, m.Content[i:])
-		} else {
-			message.Contents = append(message.Contents, m.Content[i:i+protobufStringLengthLimit])
+			break
+		}
+
+		for ceil > i+1 && !utf8.RuneStart(m.Content[ceil]) {
+			ceil--
 		}
+		message.Contents = append(message.Contents, m.Content[i:ceil])
+		i = ceil
 	}
 
 	return &message
@@ -48,6 +48,31 @@ func easyjson4086215fDecodeLiveChatEntities(in *jlexer.Lexer, out *Message) {
 			out.Type = ContentType(in.Uint8())
 		case "Content":
 			out.Content = string(in.String())
+		case "Mentions":
+			if in.IsNull() {
+				in.Skip()
+				out.Mentions = nil
+			} else {
+				in.Delim('[')
+				if out.Mentions == nil {
+					if !in.IsDelim(']') {
+						out.Mentions = make([]int64, 0, 8)
+					} else {
+						out.Mentions = []int64{}
+					}
+				} else {
+					out.Mentions = (out.Mentions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 int64
+					v1 = int64(in.Int64())
+					out.Mentions = append(out.Mentions, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "MentionAll":
+			out.MentionAll = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -92,6 +117,27 @@ func easyjson4086215fEncodeLiveChatEntities(out *jwriter.Writer, in Message) {
 		out.RawString(prefix)
 		out.String(string(in.Content))
 	}
+	{
+		const prefix string = ",\"Mentions\":"
+		out.RawString(prefix)
+		if in.Mentions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Mentions {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.Int64(int64(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"MentionAll\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.MentionAll))
+	}
 	out.RawByte('}')
 }
 
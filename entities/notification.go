@@ -7,12 +7,26 @@ const (
 	Delete
 	Approve
 	Refuse
+	Mute
+	Unmute
+	Transfer
+	Announce
+	Pin
+	Unpin
+	Update
 )
 
 const (
 	Friend byte = iota
 	Group
 	Administrator
+	MemberRole
+	Invitation
+	Contact
+	// IdentityKey 为好友的设备身份公钥变更通知
+	IdentityKey
+	// System 为运维发送的系统公告，发送者为 0
+	System
 )
 
 type Notification struct {
@@ -25,8 +39,15 @@ type Notification struct {
 	IsHandled    bool   `bson:"is_handled"`
 	IsAgree      bool   `bson:"is_agree"`
 	HandleUserId int64  `bson:"handle_user_id"`
+	// Message 为好友申请或入群申请附带的验证消息
+	Message string `bson:"message,omitempty"`
+	// IsExpired 为真时表示申请超过有效期未处理，已被自动关闭
+	IsExpired bool `bson:"is_expired"`
 }
 
+// PendingRequestTypes 为需要对方处理、会过期的申请类通知
+var PendingRequestTypes = []byte{Friend, Group, Invitation}
+
 func NewNotification(senderId int64, receiverId int64, opType, receiveType byte, isHandled, isAgree bool) *Notification {
 	return &Notification{
 		SenderId:    senderId,
@@ -40,6 +61,33 @@ func NewNotification(senderId int64, receiverId int64, opType, receiveType byte,
 	}
 }
 
+// IsGroupWideNotification 判断群组通知是否需要下发给全体成员，其余群组通知只下发给有权处理的成员
+func IsGroupWideNotification(opType byte) bool {
+	return opType == Mute || opType == Unmute || opType == Transfer ||
+		opType == Announce || opType == Pin || opType == Unpin
+}
+
+// IsPendingRequest 判断通知是否为尚未处理的好友申请、入群申请或入群邀请
+func (n *Notification) IsPendingRequest() bool {
+	if n.OpType != Add || n.IsHandled {
+		return false
+	}
+	for _, receiveType := range PendingRequestTypes {
+		if n.ReceiveType == receiveType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpiredAt 判断申请在 now 时刻（Unix 秒）是否已过期，expireAfter 不大于 0 时申请不会过期
+func (n *Notification) IsExpiredAt(now, expireAfter int64) bool {
+	if n.IsExpired {
+		return true
+	}
+	return expireAfter > 0 && n.IsPendingRequest() && now-n.Timestamp >= expireAfter
+}
+
 func NewNotificationFromRpc(request *rpc.NotificationRequest) *Notification {
 	return &Notification{
 		SenderId:    request.Sender,
@@ -50,5 +98,6 @@ func NewNotificationFromRpc(request *rpc.NotificationRequest) *Notification {
 		ReceiveType: byte(request.ReceiveType),
 		IsHandled:   request.IsHandledByAuth,
 		IsAgree:     request.IsAgree,
+		Message:     request.Message,
 	}
 }
@@ -52,6 +52,12 @@ func easyjson9806e1DecodeLiveChatEntities(in *jlexer.Lexer, out *Notification) {
 			out.IsHandled = bool(in.Bool())
 		case "IsAgree":
 			out.IsAgree = bool(in.Bool())
+		case "HandleUserId":
+			out.HandleUserId = int64(in.Int64())
+		case "Message":
+			out.Message = string(in.String())
+		case "IsExpired":
+			out.IsExpired = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -106,6 +112,21 @@ func easyjson9806e1EncodeLiveChatEntities(out *jwriter.Writer, in Notification)
 		out.RawString(prefix)
 		out.Bool(bool(in.IsAgree))
 	}
+	{
+		const prefix string = ",\"HandleUserId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.HandleUserId))
+	}
+	{
+		const prefix string = ",\"Message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"IsExpired\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsExpired))
+	}
 	out.RawByte('}')
 }
 
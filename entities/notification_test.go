@@ -0,0 +1,33 @@
+package entities
+
+import "testing"
+
+func TestNotificationIsExpiredAt(t *testing.T) {
+	const (
+		now         = int64(10000)
+		expireAfter = int64(3600)
+	)
+
+	cases := []struct {
+		name        string
+		noti        Notification
+		expireAfter int64
+		expired     bool
+	}{
+		{"fresh friend request", Notification{OpType: Add, ReceiveType: Friend, Timestamp: now - 10}, expireAfter, false},
+		{"stale friend request", Notification{OpType: Add, ReceiveType: Friend, Timestamp: now - expireAfter}, expireAfter, true},
+		{"stale join request", Notification{OpType: Add, ReceiveType: Group, Timestamp: 0}, expireAfter, true},
+		{"stale invitation", Notification{OpType: Add, ReceiveType: Invitation, Timestamp: 0}, expireAfter, true},
+		{"expiry disabled", Notification{OpType: Add, ReceiveType: Friend, Timestamp: 0}, 0, false},
+		{"already handled", Notification{OpType: Add, ReceiveType: Friend, Timestamp: 0, IsHandled: true}, expireAfter, false},
+		{"not a request", Notification{OpType: Approve, ReceiveType: Friend, Timestamp: 0}, expireAfter, false},
+		{"contact sync", Notification{OpType: Add, ReceiveType: Contact, Timestamp: 0}, expireAfter, false},
+		{"marked expired", Notification{OpType: Add, ReceiveType: Friend, Timestamp: now, IsHandled: true, IsExpired: true}, 0, true},
+	}
+
+	for _, c := range cases {
+		if expired := c.noti.IsExpiredAt(now, c.expireAfter); expired != c.expired {
+			t.Errorf("%s: got %v, want %v", c.name, expired, c.expired)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package entities
+
+// PinnedMessage 引用 message 集合中 (ChatId, Seq) 对应的消息。私聊消息按接收者存放，
+// 此时 ChatId 为消息的接收者，PeerId 为发送者，群聊中 PeerId 为 0
+type PinnedMessage struct {
+	ChatId    int64  `bson:"chat_id" json:"chatId"`
+	Seq       uint64 `bson:"sequence" json:"seq"`
+	PeerId    int64  `bson:"peer_id,omitempty" json:"peerId,omitempty"`
+	PinnedBy  int64  `bson:"pinned_by" json:"pinnedBy"`
+	Timestamp int64  `bson:"timestamp" json:"timestamp"`
+}
+
+func NewPinnedMessage(chatId int64, seq uint64, pinnedBy, timestamp int64) *PinnedMessage {
+	return &PinnedMessage{
+		ChatId:    chatId,
+		Seq:       seq,
+		PinnedBy:  pinnedBy,
+		Timestamp: timestamp,
+	}
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"gorm.io/gorm"
+)
+
+type PushDevice struct {
+	GormModel gorm.Model `gorm:"embedded" json:"-"`
+	UserId    int64      `gorm:"index:push_device_user_index" json:"userId"`
+	Platform  int        `json:"platform"`
+	Provider  string     `json:"provider"`
+	Token     string     `gorm:"type:varchar(512);uniqueIndex:push_device_token_index" json:"token"`
+}
+
+func NewPushDevice(userId int64, platform int, provider, token string) *PushDevice {
+	return &PushDevice{
+		UserId:   userId,
+		Platform: platform,
+		Provider: provider,
+		Token:    token,
+	}
+}
+
+// PushSetting 为用户的离线推送设置，免打扰时段以分钟表示，按 TimezoneOffset（分钟）换算为用户本地时间，
+// DndStartMinute 大于 DndEndMinute 时表示跨越零点的时段
+type PushSetting struct {
+	UserId         int64   `gorm:"primaryKey" json:"userId"`
+	IsDisabled     bool    `json:"isDisabled"`
+	IsDndEnabled   bool    `json:"isDndEnabled"`
+	DndStartMinute int     `json:"dndStartMinute"`
+	DndEndMinute   int     `json:"dndEndMinute"`
+	TimezoneOffset int     `json:"timezoneOffset"`
+	MutedChats     []int64 `gorm:"serializer:json" json:"mutedChats"`
+}
+
+func NewDefaultPushSetting(userId int64) *PushSetting {
+	return &PushSetting{
+		UserId:     userId,
+		MutedChats: make([]int64, 0),
+	}
+}
+
+func (s *PushSetting) IsInDnd(minuteOfDayInUTC int) bool {
+	if !s.IsDndEnabled || s.DndStartMinute == s.DndEndMinute {
+		return false
+	}
+
+	minute := ((minuteOfDayInUTC+s.TimezoneOffset)%1440 + 1440) % 1440
+	if s.DndStartMinute < s.DndEndMinute {
+		return minute >= s.DndStartMinute && minute < s.DndEndMinute
+	}
+	return minute >= s.DndStartMinute || minute < s.DndEndMinute
+}
+
+func (s *PushSetting) IsChatMuted(chatId int64) bool {
+	for _, id := range s.MutedChats {
+		if id == chatId {
+			return true
+		}
+	}
+	return false
+}
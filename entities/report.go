@@ -0,0 +1,132 @@
+package entities
+
+const (
+	ReportMessage byte = iota
+	ReportUser
+	ReportGroup
+)
+
+// AuditSystem 为不针对单个对象的运维操作，例如发送系统公告
+const AuditSystem = ReportGroup + 1
+
+const (
+	ReportPending byte = iota
+	ReportDismissed
+	ReportActioned
+)
+
+const (
+	ReviewDismiss       = "dismiss"
+	ReviewDeleteMessage = "deleteMessage"
+	ReviewMute          = "mute"
+	ReviewBan           = "ban"
+	ReviewDissolveGroup = "dissolveGroup"
+)
+
+// 运维接口直接执行、不对应举报的操作，封禁与解散群组沿用复核操作的名称
+const (
+	AdminForceLogout = "forceLogout"
+	AdminUnban       = "unban"
+	AdminAnnounce    = "announce"
+)
+
+// MaxReportReasonLength 为举报理由的最大长度，按 rune 计算
+const MaxReportReasonLength = 500
+
+// Report 为用户提交的举报。举报消息时 TargetId 为会话 id，Seq 为消息序号，并保存消息的发送者与内容快照，
+// 消息之后被删除或按保留策略清理时仍可复核；举报用户或群组时 TargetId 为对应的 id
+type Report struct {
+	Id              int64  `gorm:"primaryKey" json:"id"`
+	ReporterId      int64  `gorm:"index" json:"reporterId"`
+	TargetType      byte   `gorm:"index:report_target_index" json:"targetType"`
+	TargetId        int64  `gorm:"index:report_target_index" json:"targetId"`
+	Seq             uint64 `json:"seq"`
+	MessageSender   int64  `json:"messageSender"`
+	MessageSnapshot string `gorm:"type:text" json:"messageSnapshot"`
+	Reason          string `gorm:"type:varchar(2048)" json:"reason"`
+	Status          byte   `gorm:"index" json:"status"`
+	ReviewAction    string `gorm:"type:varchar(16)" json:"reviewAction"`
+	CreatedAt       int64  `gorm:"autoCreateTime:milli" json:"createdAt"`
+	ReviewedAt      int64  `json:"reviewedAt"`
+}
+
+func NewReport(reporterId int64, targetType byte, targetId int64, seq uint64, reason string) *Report {
+	return &Report{
+		ReporterId: reporterId,
+		TargetType: targetType,
+		TargetId:   targetId,
+		Seq:        seq,
+		Reason:     reason,
+		Status:     ReportPending,
+	}
+}
+
+func IsValidReportTarget(targetType byte) bool {
+	return targetType <= ReportGroup
+}
+
+// ReportedUserId 返回被禁言或封禁的用户，举报群组时返回 0
+func (r *Report) ReportedUserId() int64 {
+	switch r.TargetType {
+	case ReportMessage:
+		return r.MessageSender
+	case ReportUser:
+		return r.TargetId
+	}
+	return 0
+}
+
+// ReportedGroupId 返回被举报的群组，举报私聊消息或用户时返回 0
+func (r *Report) ReportedGroupId() int64 {
+	if r.TargetType == ReportGroup || (r.TargetType == ReportMessage && r.TargetId < 0) {
+		return r.TargetId
+	}
+	return 0
+}
+
+// AllowsAction 判断复核操作能否作用于该举报。禁言群组时禁言全体成员，禁言群消息的发送者时只在该群内禁言，
+// 其余情况为全局禁言
+func (r *Report) AllowsAction(action string) bool {
+	switch action {
+	case ReviewDismiss:
+		return true
+	case ReviewDeleteMessage:
+		return r.TargetType == ReportMessage
+	case ReviewMute:
+		return r.ReportedUserId() != 0 || r.ReportedGroupId() != 0
+	case ReviewBan:
+		return r.ReportedUserId() != 0
+	case ReviewDissolveGroup:
+		return r.ReportedGroupId() != 0
+	}
+	return false
+}
+
+// AuditLog 记录运维人员的每一次复核操作与运维操作，Operator 为所用运维密钥的指纹，不对应举报时 ReportId 为 0
+type AuditLog struct {
+	Id         int64  `gorm:"primaryKey" json:"id"`
+	Operator   string `gorm:"type:varchar(32)" json:"operator"`
+	Action     string `gorm:"type:varchar(16)" json:"action"`
+	ReportId   int64  `gorm:"index" json:"reportId"`
+	TargetType byte   `json:"targetType"`
+	TargetId   int64  `json:"targetId"`
+	Seq        uint64 `json:"seq"`
+	Detail     string `gorm:"type:varchar(2048)" json:"detail"`
+	CreatedAt  int64  `gorm:"autoCreateTime:milli" json:"createdAt"`
+}
+
+func NewAuditLog(operator, action string, report *Report, detail string) *AuditLog {
+	auditLog := NewOperatorAuditLog(operator, action, report.TargetType, report.TargetId, detail)
+	auditLog.ReportId, auditLog.Seq = report.Id, report.Seq
+	return auditLog
+}
+
+func NewOperatorAuditLog(operator, action string, targetType byte, targetId int64, detail string) *AuditLog {
+	return &AuditLog{
+		Operator:   operator,
+		Action:     action,
+		TargetType: targetType,
+		TargetId:   targetId,
+		Detail:     detail,
+	}
+}
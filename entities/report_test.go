@@ -0,0 +1,63 @@
+package entities
+
+import "testing"
+
+func TestReportAllowsAction(t *testing.T) {
+	var (
+		groupMessage   = &Report{TargetType: ReportMessage, TargetId: -10, Seq: 3, MessageSender: 7}
+		privateMessage = &Report{TargetType: ReportMessage, TargetId: 5, Seq: 3, MessageSender: 7}
+		user           = &Report{TargetType: ReportUser, TargetId: 7}
+		group          = &Report{TargetType: ReportGroup, TargetId: -10}
+	)
+
+	cases := []struct {
+		name   string
+		report *Report
+		want   map[string]bool
+	}{
+		{"group message", groupMessage, map[string]bool{
+			ReviewDismiss: true, ReviewDeleteMessage: true, ReviewMute: true, ReviewBan: true, ReviewDissolveGroup: true,
+		}},
+		{"private message", privateMessage, map[string]bool{
+			ReviewDismiss: true, ReviewDeleteMessage: true, ReviewMute: true, ReviewBan: true, ReviewDissolveGroup: false,
+		}},
+		{"user", user, map[string]bool{
+			ReviewDismiss: true, ReviewDeleteMessage: false, ReviewMute: true, ReviewBan: true, ReviewDissolveGroup: false,
+		}},
+		{"group", group, map[string]bool{
+			ReviewDismiss: true, ReviewDeleteMessage: false, ReviewMute: true, ReviewBan: false, ReviewDissolveGroup: true,
+		}},
+	}
+
+	for _, c := range cases {
+		for action, want := range c.want {
+			if got := c.report.AllowsAction(action); got != want {
+				t.Errorf("%s %s: got %v, want %v", c.name, action, got, want)
+			}
+		}
+		if c.report.AllowsAction("unknown") {
+			t.Errorf("%s: unknown action allowed", c.name)
+		}
+	}
+}
+
+func TestReportTargets(t *testing.T) {
+	cases := []struct {
+		report          *Report
+		userId, groupId int64
+	}{
+		{&Report{TargetType: ReportMessage, TargetId: -10, MessageSender: 7}, 7, -10},
+		{&Report{TargetType: ReportMessage, TargetId: 5, MessageSender: 7}, 7, 0},
+		{&Report{TargetType: ReportUser, TargetId: 7}, 7, 0},
+		{&Report{TargetType: ReportGroup, TargetId: -10}, 0, -10},
+	}
+
+	for _, c := range cases {
+		if got := c.report.ReportedUserId(); got != c.userId {
+			t.Errorf("type %d: reported user %d, want %d", c.report.TargetType, got, c.userId)
+		}
+		if got := c.report.ReportedGroupId(); got != c.groupId {
+			t.Errorf("type %d: reported group %d, want %d", c.report.TargetType, got, c.groupId)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package entities
+
+import "time"
+
+// RetentionPolicy 为会话消息的保留策略。KeepDays 与 KeepLast 为 0 时表示对应维度不限制，
+// 两者同时设置时超出任一限制的消息都会被清理
+type RetentionPolicy struct {
+	KeepDays int64 `json:"keepDays"`
+	KeepLast int64 `json:"keepLast"`
+}
+
+// MessageGap 表示会话中 [BottomId, TopId] 范围内的消息已被清理
+type MessageGap struct {
+	BottomId uint64 `json:"bottomId"`
+	TopId    uint64 `json:"topId"`
+}
+
+func (p RetentionPolicy) IsValid() bool {
+	return p.KeepDays >= 0 && p.KeepLast >= 0
+}
+
+func (p RetentionPolicy) IsUnlimited() bool {
+	return p.KeepDays == 0 && p.KeepLast == 0
+}
+
+// PurgeSeqByCount 返回按条数保留时需要清理到的序号（含），sequence 为会话当前的最大序号
+func (p RetentionPolicy) PurgeSeqByCount(sequence uint64) uint64 {
+	if p.KeepLast == 0 || sequence <= uint64(p.KeepLast) {
+		return 0
+	}
+	return sequence - uint64(p.KeepLast)
+}
+
+// ExpireBefore 返回按天数保留时的过期时间（毫秒时间戳），早于该时间的消息需要清理，未限制天数时返回 0
+func (p RetentionPolicy) ExpireBefore(now time.Time) uint64 {
+	if p.KeepDays == 0 {
+		return 0
+	}
+	return uint64(now.Add(-time.Duration(p.KeepDays) * 24 * time.Hour).UnixMilli())
+}
+
+// ResolveRetentionPolicy custom 为 nil 时使用全局默认策略
+func ResolveRetentionPolicy(defaults RetentionPolicy, custom *RetentionPolicy) RetentionPolicy {
+	if custom == nil {
+		return defaults
+	}
+	return *custom
+}
+
+// PurgedGapIn 返回 [bottom, top] 范围内已被清理的部分，purgedSeq 为会话已清理到的序号，没有交集时返回 nil
+func PurgedGapIn(bottom, top, purgedSeq uint64) *MessageGap {
+	if purgedSeq == 0 || bottom > top || bottom > purgedSeq {
+		return nil
+	}
+	if top > purgedSeq {
+		top = purgedSeq
+	}
+	return &MessageGap{BottomId: bottom, TopId: top}
+}
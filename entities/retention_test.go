@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRetentionPolicyPurgeBoundary(t *testing.T) {
+	if seq := (RetentionPolicy{KeepLast: 100}).PurgeSeqByCount(150); seq != 50 {
+		t.Errorf("keep last 100 of 150: got %d, want 50", seq)
+	}
+	if seq := (RetentionPolicy{KeepLast: 100}).PurgeSeqByCount(80); seq != 0 {
+		t.Errorf("keep last 100 of 80: got %d, want 0", seq)
+	}
+	if seq := (RetentionPolicy{KeepDays: 1}).PurgeSeqByCount(150); seq != 0 {
+		t.Errorf("unlimited count: got %d, want 0", seq)
+	}
+
+	now := time.UnixMilli(10 * 24 * 60 * 60 * 1000)
+	if before := (RetentionPolicy{KeepDays: 3}).ExpireBefore(now); before != 7*24*60*60*1000 {
+		t.Errorf("keep 3 days: got %d", before)
+	}
+	if before := (RetentionPolicy{KeepLast: 3}).ExpireBefore(now); before != 0 {
+		t.Errorf("unlimited days: got %d, want 0", before)
+	}
+}
+
+func TestPurgedGapIn(t *testing.T) {
+	cases := []struct {
+		bottom, top, purgedSeq uint64
+		want                   *MessageGap
+	}{
+		{1, 10, 0, nil},
+		{11, 20, 10, nil},
+		{1, 20, 10, &MessageGap{1, 10}},
+		{3, 8, 10, &MessageGap{3, 8}},
+		{10, 10, 10, &MessageGap{10, 10}},
+		{8, 3, 10, nil},
+	}
+
+	for _, c := range cases {
+		if got := PurgedGapIn(c.bottom, c.top, c.purgedSeq); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("range [%d, %d] purged to %d: got %+v, want %+v", c.bottom, c.top, c.purgedSeq, got, c.want)
+		}
+	}
+}
@@ -19,9 +19,18 @@ type UserInfo struct {
 	Username         string `json:"username"`
 	UserAvatar       string `json:"avatar"`
 	UserIntroduction string `json:"introduction"`
-
-	Friendships []Friendship  `gorm:"foreignKey:SelfId" json:"friendships"`
-	Groups      []GroupMember `gorm:"foreignKey:MemberId" json:"groupList"`
+	IsBot            bool   `json:"isBot"`
+	IsDeleted        bool   `json:"-"`
+	DiscoverableBy   byte   `json:"discoverableBy"`
+	AddFriendPolicy  byte   `json:"addFriendPolicy"`
+	// BannedUntil 为封禁的截止时间，单位毫秒，为 0 时未被封禁
+	BannedUntil int64 `json:"-"`
+	// RetentionPolicy 为发给该用户的私聊消息的保留策略，为 nil 时使用全局默认策略
+	RetentionPolicy *RetentionPolicy `gorm:"serializer:json" json:"retentionPolicy"`
+
+	Friendships   []Friendship   `gorm:"foreignKey:SelfId" json:"friendships"`
+	Groups        []GroupMember  `gorm:"foreignKey:MemberId" json:"groupList"`
+	ContactGroups []ContactGroup `gorm:"foreignKey:UserId" json:"contactGroups"`
 }
 
 func NewUserInfo(id int64, userName string) *UserInfo {
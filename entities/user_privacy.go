@@ -0,0 +1,24 @@
+package entities
+
+const (
+	// DiscoverableByEveryone 可以通过账号、邮箱与用户名前缀搜索到用户，为默认设置
+	DiscoverableByEveryone byte = iota
+	// DiscoverableByExactMatch 只能通过完整的账号或邮箱搜索到用户
+	DiscoverableByExactMatch
+	DiscoverableByNobody
+)
+
+func IsValidDiscoverableBy(discoverableBy byte) bool {
+	return discoverableBy <= DiscoverableByNobody
+}
+
+const (
+	// AddFriendNeedApproval 好友申请需要用户同意，为默认设置
+	AddFriendNeedApproval byte = iota
+	AddFriendDirectly
+	AddFriendNobody
+)
+
+func IsValidAddFriendPolicy(policy byte) bool {
+	return policy <= AddFriendNobody
+}
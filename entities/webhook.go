@@ -0,0 +1,93 @@
+package entities
+
+import "time"
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryDead      = "dead"
+)
+
+// WebhookSubscription 为一个外部回调订阅，Events 与 ChatIds 为空时分别表示订阅全部事件与全部会话
+type WebhookSubscription struct {
+	Id         int64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	Url        string   `gorm:"type:varchar(1024)" json:"url"`
+	Secret     string   `json:"-"`
+	Events     []string `gorm:"serializer:json" json:"events"`
+	ChatIds    []int64  `gorm:"serializer:json" json:"chatIds"`
+	IsDisabled bool     `json:"isDisabled"`
+
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+func NewWebhookSubscription(url, secret string, events []string, chatIds []int64) *WebhookSubscription {
+	if events == nil {
+		events = make([]string, 0)
+	}
+	if chatIds == nil {
+		chatIds = make([]int64, 0)
+	}
+
+	return &WebhookSubscription{
+		Url:     url,
+		Secret:  secret,
+		Events:  events,
+		ChatIds: chatIds,
+	}
+}
+
+func (s *WebhookSubscription) Accepts(eventType string, chatId int64) bool {
+	if s.IsDisabled {
+		return false
+	}
+
+	if len(s.Events) != 0 {
+		matched := false
+		for _, event := range s.Events {
+			if event == eventType {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(s.ChatIds) == 0 {
+		return true
+	}
+	for _, id := range s.ChatIds {
+		if id == chatId {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery 中 BotId 不为 0 时为发给机器人的投递，此时 SubscriptionId 为 0
+type WebhookDelivery struct {
+	Id             string `bson:"_id" json:"id"`
+	SubscriptionId int64  `bson:"subscription_id" json:"subscriptionId"`
+	BotId          int64  `bson:"bot_id,omitempty" json:"botId,omitempty"`
+	EventType      string `bson:"event_type" json:"eventType"`
+	Payload        string `bson:"payload" json:"payload"`
+	Status         string `bson:"status" json:"status"`
+	Attempts       int    `bson:"attempts" json:"attempts"`
+	LastError      string `bson:"last_error" json:"lastError"`
+	CreatedAt      int64  `bson:"created_at" json:"createdAt"`
+	UpdatedAt      int64  `bson:"updated_at" json:"updatedAt"`
+}
+
+func NewWebhookDelivery(id string, subscriptionId int64, eventType, payload string, timestamp int64) *WebhookDelivery {
+	return &WebhookDelivery{
+		Id:             id,
+		SubscriptionId: subscriptionId,
+		EventType:      eventType,
+		Payload:        payload,
+		Status:         WebhookDeliveryPending,
+		CreatedAt:      timestamp,
+		UpdatedAt:      timestamp,
+	}
+}
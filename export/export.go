@@ -0,0 +1,352 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"liveChat/config"
+	"liveChat/entities"
+	"liveChat/log"
+	"sort"
+	"time"
+)
+
+const (
+	defaultWorkerNumber = 2
+	defaultExpireAfter  = time.Hour * 24
+
+	messageBatchSize      = 500
+	notificationBatchSize = 500
+
+	// 空闲的协程每隔 claimInterval 检查一次其他节点提交的任务
+	claimInterval = time.Second * 10
+	// 执行中的任务超过 staleJobTimeout 未汇报进度时视为执行节点已退出
+	staleJobTimeout  = time.Minute * 10
+	maintainInterval = time.Minute
+	expireBatchSize  = 100
+)
+
+// Exporter 在后台执行导出任务。任务保存在数据库中，由各节点的协程认领执行，结果写入 files
+type Exporter struct {
+	store       Store
+	files       FileStorage
+	wake        chan struct{}
+	now         func() time.Time
+	expireAfter time.Duration
+}
+
+// NewExporter expireAfter 为导出文件的保存期限，不大于 0 时使用 defaultExpireAfter
+func NewExporter(store Store, files FileStorage, workers int, expireAfter time.Duration) *Exporter {
+	if workers <= 0 {
+		workers = defaultWorkerNumber
+	}
+	if expireAfter <= 0 {
+		expireAfter = defaultExpireAfter
+	}
+
+	e := &Exporter{
+		store:       store,
+		files:       files,
+		wake:        make(chan struct{}, workers),
+		now:         time.Now,
+		expireAfter: expireAfter,
+	}
+	for i := 0; i < workers; i++ {
+		go e.work()
+	}
+	return e
+}
+
+// Submit 唤醒空闲的协程认领新保存的任务
+func (e *Exporter) Submit() {
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Open 返回已完成任务的导出文件与文件大小
+func (e *Exporter) Open(job *entities.ExportJob) (io.ReadCloser, int64, error) {
+	return e.files.Open(job)
+}
+
+// Maintain 定期将执行节点已退出的任务放回队列，并删除超过保存期限的导出文件
+func (e *Exporter) Maintain() {
+	go func() {
+		for {
+			e.maintain()
+			time.Sleep(maintainInterval)
+		}
+	}()
+}
+
+func (e *Exporter) maintain() {
+	now := e.now()
+	if count, err := e.store.RequeueStale(now.Add(-staleJobTimeout).UnixMilli()); err != nil {
+		log.Error(fmt.Sprintf("重置超时的导出任务失败: %s", err.Error()))
+	} else if count != 0 {
+		e.Submit()
+	}
+
+	jobs, err := e.store.GetExpired(now.Add(-e.expireAfter).UnixMilli(), expireBatchSize)
+	if err != nil {
+		log.Error(fmt.Sprintf("读取过期的导出任务失败: %s", err.Error()))
+		return
+	}
+	for i := range jobs {
+		if err = e.files.Delete(&jobs[i]); err != nil {
+			log.Error(fmt.Sprintf("删除导出任务 %d 的文件失败: %s", jobs[i].Id, err.Error()))
+			continue
+		}
+		if err = e.store.Expire(jobs[i].Id); err != nil {
+			log.Error(fmt.Sprintf("记录导出任务 %d 过期失败: %s", jobs[i].Id, err.Error()))
+		}
+	}
+}
+
+func (e *Exporter) work() {
+	for {
+		job, err := e.store.ClaimPending(e.now().UnixMilli())
+		if err != nil {
+			log.Error(fmt.Sprintf("认领导出任务失败: %s", err.Error()))
+		}
+		if job == nil {
+			select {
+			case <-e.wake:
+			case <-time.After(claimInterval):
+			}
+			continue
+		}
+		e.run(job)
+	}
+}
+
+func (e *Exporter) run(job *entities.ExportJob) {
+	status, errInfo := entities.ExportDone, ""
+	if err := e.export(job); err != nil {
+		log.Error(fmt.Sprintf("导出任务 %d 失败: %s", job.Id, err.Error()))
+		status, errInfo = entities.ExportFailed, err.Error()
+	}
+
+	if err := e.store.Finish(job.Id, status, errInfo, e.now().UnixMilli()); err != nil {
+		log.Error(fmt.Sprintf("记录导出任务 %d 的结果失败: %s", job.Id, err.Error()))
+	}
+}
+
+func (e *Exporter) export(job *entities.ExportJob) error {
+	file, err := e.files.Create(job)
+	if err != nil {
+		return err
+	}
+
+	if err = e.write(job, file); err != nil {
+		if abortErr := file.Abort(); abortErr != nil {
+			log.Error(fmt.Sprintf("丢弃导出任务 %d 的文件失败: %s", job.Id, abortErr.Error()))
+		}
+		return err
+	}
+	return file.Close()
+}
+
+func (e *Exporter) write(job *entities.ExportJob, file io.Writer) error {
+	var title string
+	if job.Type == entities.ExportAccount {
+		title = fmt.Sprintf("用户 %d 的账号数据", job.UserId)
+	} else {
+		title = fmt.Sprintf("用户 %d 与会话 %d 的聊天记录", job.UserId, job.ChatId)
+	}
+
+	writer, err := newRecordWriter(job.Format, title, file)
+	if err != nil {
+		return err
+	}
+
+	if job.Type == entities.ExportAccount {
+		err = e.exportAccount(job, writer)
+	} else {
+		err = e.exportChat(job, writer)
+	}
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+// exportChat 导出群聊或私聊的全部消息。私聊消息按接收者存放，需要同时读取双方的收件会话，
+// 只保留双方之间的消息并按时间合并
+func (e *Exporter) exportChat(job *entities.ExportJob, writer recordWriter) error {
+	var cursors []*chatCursor
+	if job.ChatId < 0 {
+		cursors = []*chatCursor{{chatId: job.ChatId}}
+	} else {
+		cursors = []*chatCursor{{chatId: job.UserId, peer: job.ChatId}, {chatId: job.ChatId, peer: job.UserId}}
+	}
+
+	var total int64
+	for _, c := range cursors {
+		top, err := e.store.GetChatSequence(c.chatId)
+		if err != nil {
+			return err
+		}
+		c.next, c.top = 1, top
+		total += int64(top)
+	}
+
+	for {
+		var (
+			next      *chatCursor
+			processed int64
+			fetched   bool
+		)
+		for _, c := range cursors {
+			isFetched, err := c.fill(e.store, writer)
+			if err != nil {
+				return err
+			}
+			fetched = fetched || isFetched
+			processed += c.scanned()
+
+			if len(c.buffer) != 0 && (next == nil || c.buffer[0].Timestamp < next.buffer[0].Timestamp) {
+				next = c
+			}
+		}
+
+		if fetched {
+			if err := e.store.UpdateProgress(job.Id, processed, total); err != nil {
+				return err
+			}
+		}
+
+		if next == nil {
+			return nil
+		}
+		if err := writer.WriteMessage(&next.buffer[0]); err != nil {
+			return err
+		}
+		next.buffer = next.buffer[1:]
+	}
+}
+
+// exportAccount 导出用户的资料（包含好友关系、分组与群组成员身份）、所在群组的信息以及全部通知
+func (e *Exporter) exportAccount(job *entities.ExportJob, writer recordWriter) error {
+	info, err := e.store.GetAccount(job.UserId)
+	if err != nil {
+		return err
+	}
+
+	notiSeq, err := e.store.GetNotificationSequence(job.UserId)
+	if err != nil {
+		return err
+	}
+
+	var (
+		processed int64
+		total     = int64(1+len(info.Groups)) + int64(notiSeq)
+	)
+
+	if err = writer.WriteRecord(recordProfile, info); err != nil {
+		return err
+	}
+	processed++
+
+	for _, member := range info.Groups {
+		group, err := e.store.GetGroup(member.GroupId)
+		if err != nil {
+			return err
+		}
+		if err = writer.WriteRecord(recordGroup, group); err != nil {
+			return err
+		}
+		processed++
+	}
+	if err = e.store.UpdateProgress(job.Id, processed, total); err != nil {
+		return err
+	}
+
+	for bottom := uint64(1); bottom <= notiSeq; bottom += notificationBatchSize {
+		top := bottom + notificationBatchSize - 1
+		if top > notiSeq {
+			top = notiSeq
+		}
+
+		notifications, err := e.store.GetNotifications(job.UserId, bottom, top)
+		if err != nil {
+			return err
+		}
+		for i := range notifications {
+			if err = writer.WriteRecord(recordNotification, &notifications[i]); err != nil {
+				return err
+			}
+		}
+
+		processed += int64(top - bottom + 1)
+		if err = e.store.UpdateProgress(job.Id, processed, total); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chatCursor 按序号分批读取一个会话中的消息，peer 不为 0 时只保留 peer 发送的消息
+type chatCursor struct {
+	chatId int64
+	peer   int64
+	next   uint64
+	top    uint64
+	buffer []entities.Message
+}
+
+// fill 在缓冲为空时读取下一批消息，读取范围内被清理的部分会直接写入 writer，返回本次是否读取了数据
+func (c *chatCursor) fill(store Store, writer recordWriter) (bool, error) {
+	fetched := false
+	for len(c.buffer) == 0 && c.next <= c.top {
+		bottom, top := c.next, c.next+messageBatchSize-1
+		if top > c.top {
+			top = c.top
+		}
+
+		messages, gap, err := store.GetMessages(c.chatId, bottom, top)
+		if err != nil {
+			return fetched, err
+		}
+		if gap != nil {
+			if err = writer.WriteGap(c.chatId, gap); err != nil {
+				return fetched, err
+			}
+		}
+
+		for _, m := range messages {
+			if c.peer == 0 || m.Sender == c.peer {
+				c.buffer = append(c.buffer, m)
+			}
+		}
+		sort.Slice(c.buffer, func(i, j int) bool { return c.buffer[i].Id < c.buffer[j].Id })
+		c.next = top + 1
+		fetched = true
+	}
+	return fetched, nil
+}
+
+func (c *chatCursor) scanned() int64 {
+	return int64(c.next - 1)
+}
+
+var exporter *Exporter
+
+// InitExport directory 为空时导出文件保存在 MongoDB 中，否则保存在该目录
+func InitExport(cfg config.ExportConfig) {
+	var files FileStorage = mongoStorage{}
+	if cfg.Directory != "" {
+		files = directoryStorage{directory: cfg.Directory}
+	}
+	exporter = NewExporter(databaseStore{}, files, cfg.Workers, time.Duration(cfg.ExpireHours)*time.Hour)
+	exporter.Maintain()
+}
+
+func Submit() {
+	exporter.Submit()
+}
+
+func Open(job *entities.ExportJob) (io.ReadCloser, int64, error) {
+	return exporter.Open(job)
+}
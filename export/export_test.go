@@ -0,0 +1,250 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"liveChat/entities"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memoryStore struct {
+	messages      map[int64][]entities.Message
+	purgedSeq     map[int64]uint64
+	account       *entities.UserInfo
+	groups        map[int64]*entities.GroupInfo
+	notifications []entities.Notification
+
+	processed, total int64
+	status           byte
+
+	staleBefore int64
+	expired     []entities.ExportJob
+	expiredIds  []int64
+}
+
+func (s *memoryStore) GetChatSequence(chatId int64) (uint64, error) {
+	return uint64(len(s.messages[chatId])), nil
+}
+
+func (s *memoryStore) GetMessages(chatId int64, bottom, top uint64) ([]entities.Message, *entities.MessageGap, error) {
+	gap := entities.PurgedGapIn(bottom, top, s.purgedSeq[chatId])
+	if gap != nil {
+		bottom = gap.TopId + 1
+	}
+
+	result := make([]entities.Message, 0)
+	for _, m := range s.messages[chatId] {
+		if m.Id >= bottom && m.Id <= top {
+			result = append(result, m)
+		}
+	}
+	return result, gap, nil
+}
+
+func (s *memoryStore) GetAccount(userId int64) (*entities.UserInfo, error) {
+	return s.account, nil
+}
+
+func (s *memoryStore) GetGroup(groupId int64) (*entities.GroupInfo, error) {
+	return s.groups[groupId], nil
+}
+
+func (s *memoryStore) GetNotificationSequence(receiverId int64) (uint64, error) {
+	return uint64(len(s.notifications)), nil
+}
+
+func (s *memoryStore) GetNotifications(receiverId int64, bottom, top uint64) ([]entities.Notification, error) {
+	return s.notifications[bottom-1 : top], nil
+}
+
+func (s *memoryStore) UpdateProgress(jobId, processed, total int64) error {
+	s.processed, s.total = processed, total
+	return nil
+}
+
+func (s *memoryStore) Finish(jobId int64, status byte, errInfo string, finishedAt int64) error {
+	s.status = status
+	return nil
+}
+
+func (s *memoryStore) ClaimPending(now int64) (*entities.ExportJob, error) {
+	return nil, nil
+}
+
+func (s *memoryStore) RequeueStale(heartbeatBefore int64) (int64, error) {
+	s.staleBefore = heartbeatBefore
+	return 0, nil
+}
+
+func (s *memoryStore) GetExpired(finishedBefore int64, limit int) ([]entities.ExportJob, error) {
+	ret := make([]entities.ExportJob, 0)
+	for _, job := range s.expired {
+		if job.FinishedAt < finishedBefore {
+			ret = append(ret, job)
+		}
+	}
+	return ret, nil
+}
+
+func (s *memoryStore) Expire(jobId int64) error {
+	s.expiredIds = append(s.expiredIds, jobId)
+	return nil
+}
+
+func newTestExporter(t *testing.T, store Store) (*Exporter, directoryStorage) {
+	files := directoryStorage{directory: t.TempDir()}
+	return NewExporter(store, files, 1, time.Hour), files
+}
+
+func readLines(t *testing.T, path string) []exportLine {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	lines := make([]exportLine, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := exportLine{}
+		if err = json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestExportPrivateChatMergesBothInboxes(t *testing.T) {
+	const userId, friendId, otherId = 1, 2, 3
+
+	store := &memoryStore{
+		messages: map[int64][]entities.Message{
+			userId: {
+				*entities.NewMessage(1, otherId, userId, 100, entities.Text, "from other"),
+				*entities.NewMessage(2, friendId, userId, 200, entities.Text, "friend 1"),
+				*entities.NewMessage(3, friendId, userId, 400, entities.Text, "friend 2"),
+			},
+			friendId: {
+				*entities.NewMessage(1, userId, friendId, 300, entities.Text, "user 1"),
+				*entities.NewMessage(2, otherId, friendId, 350, entities.Text, "to friend from other"),
+			},
+		},
+	}
+
+	e, files := newTestExporter(t, store)
+	job := entities.NewExportJob(10, userId, entities.ExportChat, friendId, entities.ExportFormatJsonLines, false)
+	e.run(job)
+
+	if store.status != entities.ExportDone {
+		t.Fatalf("status: got %d, want %d", store.status, entities.ExportDone)
+	}
+	if store.processed != 5 || store.total != 5 {
+		t.Errorf("progress: got %d/%d, want 5/5", store.processed, store.total)
+	}
+
+	var contents []string
+	for _, line := range readLines(t, files.path(job)) {
+		if line.Type != recordMessage {
+			t.Fatalf("unexpected record %s", line.Type)
+		}
+		contents = append(contents, line.Data.(map[string]interface{})["content"].(string))
+	}
+
+	if got, want := strings.Join(contents, ","), "friend 1,user 1,friend 2"; got != want {
+		t.Errorf("messages: got %s, want %s", got, want)
+	}
+}
+
+func TestExportGroupChatWritesGap(t *testing.T) {
+	const groupId = -5
+
+	messages := make([]entities.Message, 0)
+	for i := uint64(1); i <= messageBatchSize+10; i++ {
+		messages = append(messages, *entities.NewMessage(i, 1, groupId, i, entities.Text, "hi"))
+	}
+	store := &memoryStore{
+		messages:  map[int64][]entities.Message{groupId: messages},
+		purgedSeq: map[int64]uint64{groupId: 20},
+	}
+
+	e, files := newTestExporter(t, store)
+	job := entities.NewExportJob(11, 1, entities.ExportChat, groupId, entities.ExportFormatJsonLines, false)
+	e.run(job)
+
+	lines := readLines(t, files.path(job))
+	if len(lines) != messageBatchSize+10-20+1 {
+		t.Fatalf("lines: got %d", len(lines))
+	}
+	if lines[0].Type != recordGap || lines[0].ChatId != groupId {
+		t.Errorf("first line: got %+v, want gap", lines[0])
+	}
+	if last := lines[len(lines)-1].Data.(map[string]interface{})["id"].(float64); last != messageBatchSize+10 {
+		t.Errorf("last message: got %v", last)
+	}
+}
+
+func TestExportAccountHtml(t *testing.T) {
+	store := &memoryStore{
+		account: &entities.UserInfo{Id: 1, Username: "<alice>", Groups: []entities.GroupMember{{GroupId: -7}}},
+		groups:  map[int64]*entities.GroupInfo{-7: {Id: -7, Name: "team"}},
+		notifications: []entities.Notification{
+			{ReceiverId: 1, Seq: 1}, {ReceiverId: 1, Seq: 2},
+		},
+	}
+
+	e, files := newTestExporter(t, store)
+	job := entities.NewExportJob(12, 1, entities.ExportAccount, 0, entities.ExportFormatHtml, false)
+	e.run(job)
+
+	if store.status != entities.ExportDone || store.processed != 4 || store.total != 4 {
+		t.Fatalf("status %d progress %d/%d", store.status, store.processed, store.total)
+	}
+
+	data, err := os.ReadFile(files.path(job))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if strings.Contains(content, "<alice>") || !strings.Contains(content, "&lt;alice&gt;") {
+		t.Error("profile is not escaped")
+	}
+	if !strings.HasSuffix(content, "</html>\n") {
+		t.Error("html is not closed")
+	}
+}
+
+func TestMaintainExpiresFiles(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	oldJob := entities.ExportJob{Id: 20, Format: entities.ExportFormatJsonLines, Status: entities.ExportDone, FinishedAt: now.Add(-time.Hour * 2).UnixMilli()}
+	newJob := entities.ExportJob{Id: 21, Format: entities.ExportFormatJsonLines, Status: entities.ExportDone, FinishedAt: now.UnixMilli()}
+	store := &memoryStore{expired: []entities.ExportJob{oldJob, newJob}}
+
+	e, files := newTestExporter(t, store)
+	e.now = func() time.Time { return now }
+	for _, job := range []*entities.ExportJob{&oldJob, &newJob} {
+		file, err := files.Create(job)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.Close()
+	}
+
+	e.maintain()
+
+	if store.staleBefore != now.Add(-staleJobTimeout).UnixMilli() {
+		t.Errorf("stale jobs are not requeued")
+	}
+	if len(store.expiredIds) != 1 || store.expiredIds[0] != oldJob.Id {
+		t.Errorf("expired jobs: got %v", store.expiredIds)
+	}
+	if _, err := os.Stat(files.path(&oldJob)); !os.IsNotExist(err) {
+		t.Error("expired file is not removed")
+	}
+	if _, err := os.Stat(files.path(&newJob)); err != nil {
+		t.Error("unexpired file is removed")
+	}
+}
@@ -0,0 +1,108 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"liveChat/db"
+	"liveChat/entities"
+	"os"
+	"path/filepath"
+)
+
+// FileStorage 保存导出文件。下载请求可能落在任意节点，多节点部署时必须是各节点共享的存储
+type FileStorage interface {
+	Create(job *entities.ExportJob) (FileWriter, error)
+	// Open 返回文件内容与大小
+	Open(job *entities.ExportJob) (io.ReadCloser, int64, error)
+	// Delete 文件不存在时不返回错误
+	Delete(job *entities.ExportJob) error
+}
+
+// FileWriter 写入失败时调用 Abort 丢弃已写入的内容
+type FileWriter interface {
+	io.Writer
+	Close() error
+	Abort() error
+}
+
+func fileName(job *entities.ExportJob) string {
+	return fmt.Sprintf("%d.%s", job.Id, job.Format)
+}
+
+// mongoStorage 将导出文件保存在 MongoDB 的 GridFS 中，各节点共享
+type mongoStorage struct{}
+
+func (mongoStorage) Create(job *entities.ExportJob) (FileWriter, error) {
+	return db.CreateExportFile(job.Id, fileName(job))
+}
+
+func (mongoStorage) Open(job *entities.ExportJob) (io.ReadCloser, int64, error) {
+	stream, err := db.OpenExportFile(job.Id)
+	if err != nil {
+		return nil, 0, err
+	}
+	return stream, stream.GetFile().Length, nil
+}
+
+func (mongoStorage) Delete(job *entities.ExportJob) error {
+	return db.DeleteExportFile(job.Id)
+}
+
+// directoryStorage 将导出文件保存在目录中，多节点部署时该目录需挂载为共享存储
+type directoryStorage struct {
+	directory string
+}
+
+func (s directoryStorage) path(job *entities.ExportJob) string {
+	return filepath.Join(s.directory, fileName(job))
+}
+
+func (s directoryStorage) Create(job *entities.ExportJob) (FileWriter, error) {
+	if err := os.MkdirAll(s.directory, 0700); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(s.path(job), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &directoryFile{File: file}, nil
+}
+
+func (s directoryStorage) Open(job *entities.ExportJob) (io.ReadCloser, int64, error) {
+	file, err := os.Open(s.path(job))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	return file, info.Size(), nil
+}
+
+func (s directoryStorage) Delete(job *entities.ExportJob) error {
+	if err := os.Remove(s.path(job)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+type directoryFile struct {
+	*os.File
+}
+
+func (f *directoryFile) Close() error {
+	if err := f.File.Sync(); err != nil {
+		f.File.Close()
+		return err
+	}
+	return f.File.Close()
+}
+
+func (f *directoryFile) Abort() error {
+	f.File.Close()
+	return os.Remove(f.File.Name())
+}
@@ -0,0 +1,88 @@
+package export
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/mongo"
+	"liveChat/db"
+	"liveChat/entities"
+	"time"
+)
+
+// Store 为导出任务读取数据与记录进度的来源
+type Store interface {
+	GetChatSequence(chatId int64) (uint64, error)
+	GetMessages(chatId int64, bottom, top uint64) ([]entities.Message, *entities.MessageGap, error)
+	GetAccount(userId int64) (*entities.UserInfo, error)
+	GetGroup(groupId int64) (*entities.GroupInfo, error)
+	GetNotificationSequence(receiverId int64) (uint64, error)
+	GetNotifications(receiverId int64, bottom, top uint64) ([]entities.Notification, error)
+	UpdateProgress(jobId, processed, total int64) error
+	Finish(jobId int64, status byte, errInfo string, finishedAt int64) error
+
+	// ClaimPending 认领一个待执行任务，没有时返回 nil
+	ClaimPending(now int64) (*entities.ExportJob, error)
+	RequeueStale(heartbeatBefore int64) (int64, error)
+	GetExpired(finishedBefore int64, limit int) ([]entities.ExportJob, error)
+	Expire(jobId int64) error
+}
+
+type databaseStore struct{}
+
+func (databaseStore) GetChatSequence(chatId int64) (uint64, error) {
+	seq, err := db.GetChatSequence(context.Background(), chatId)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	return seq, err
+}
+
+func (databaseStore) GetMessages(chatId int64, bottom, top uint64) ([]entities.Message, *entities.MessageGap, error) {
+	return db.GetMessageInSeqRange(context.Background(), chatId, bottom, top)
+}
+
+func (databaseStore) GetAccount(userId int64) (*entities.UserInfo, error) {
+	return db.SearchUserInfo(nil, userId, true)
+}
+
+func (databaseStore) GetGroup(groupId int64) (*entities.GroupInfo, error) {
+	return db.SearchGroupInfo(nil, groupId, false)
+}
+
+func (databaseStore) GetNotificationSequence(receiverId int64) (uint64, error) {
+	seq, err := db.GetNotificationSequence(context.Background(), receiverId)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	return seq, err
+}
+
+func (databaseStore) GetNotifications(receiverId int64, bottom, top uint64) ([]entities.Notification, error) {
+	return db.GetNotificationInSeqRange(context.Background(), receiverId, bottom, top)
+}
+
+func (databaseStore) UpdateProgress(jobId, processed, total int64) error {
+	return db.UpdateExportJobProgress(nil, jobId, processed, total, time.Now().UnixMilli())
+}
+
+func (databaseStore) Finish(jobId int64, status byte, errInfo string, finishedAt int64) error {
+	if len(errInfo) > 255 {
+		errInfo = errInfo[:255]
+	}
+	return db.FinishExportJob(nil, jobId, status, errInfo, finishedAt)
+}
+
+func (databaseStore) ClaimPending(now int64) (*entities.ExportJob, error) {
+	return db.ClaimPendingExportJob(nil, now)
+}
+
+func (databaseStore) RequeueStale(heartbeatBefore int64) (int64, error) {
+	return db.RequeueStaleExportJobs(nil, heartbeatBefore)
+}
+
+func (databaseStore) GetExpired(finishedBefore int64, limit int) ([]entities.ExportJob, error) {
+	return db.SelectExpiredExportJobs(nil, finishedBefore, limit)
+}
+
+func (databaseStore) Expire(jobId int64) error {
+	return db.ExpireExportJob(nil, jobId)
+}
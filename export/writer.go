@@ -0,0 +1,167 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"liveChat/entities"
+	"time"
+)
+
+const (
+	recordMessage      = "message"
+	recordGap          = "gap"
+	recordProfile      = "profile"
+	recordGroup        = "group"
+	recordNotification = "notification"
+)
+
+const htmlTimeLayout = "2006-01-02 15:04:05"
+
+// recordWriter 将导出的数据按格式写入文件，Close 会写入结尾并刷新缓冲但不会关闭底层文件
+type recordWriter interface {
+	WriteMessage(m *entities.Message) error
+	WriteGap(chatId int64, gap *entities.MessageGap) error
+	WriteRecord(kind string, v interface{}) error
+	Close() error
+}
+
+func newRecordWriter(format, title string, w io.Writer) (recordWriter, error) {
+	switch format {
+	case entities.ExportFormatHtml:
+		return newHtmlWriter(title, w)
+	default:
+		return newJsonLinesWriter(w), nil
+	}
+}
+
+type exportLine struct {
+	Type   string      `json:"type"`
+	ChatId int64       `json:"chatId,omitempty"`
+	Data   interface{} `json:"data"`
+}
+
+type exportMessage struct {
+	Id         uint64  `json:"id"`
+	Sender     int64   `json:"sender"`
+	Receiver   int64   `json:"receiver"`
+	Timestamp  uint64  `json:"timestamp"`
+	Type       uint8   `json:"type"`
+	Content    string  `json:"content"`
+	Mentions   []int64 `json:"mentions,omitempty"`
+	MentionAll bool    `json:"mentionAll,omitempty"`
+}
+
+func newExportMessage(m *entities.Message) *exportMessage {
+	return &exportMessage{
+		Id:         m.Id,
+		Sender:     m.Sender,
+		Receiver:   m.Receiver,
+		Timestamp:  m.Timestamp,
+		Type:       uint8(m.Type),
+		Content:    m.Content,
+		Mentions:   m.Mentions,
+		MentionAll: m.MentionAll,
+	}
+}
+
+// jsonLinesWriter 每行写入一个 json 对象，type 字段标明记录的种类
+type jsonLinesWriter struct {
+	buffer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newJsonLinesWriter(w io.Writer) *jsonLinesWriter {
+	buffer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	return &jsonLinesWriter{buffer: buffer, encoder: encoder}
+}
+
+func (w *jsonLinesWriter) WriteMessage(m *entities.Message) error {
+	return w.encoder.Encode(&exportLine{Type: recordMessage, Data: newExportMessage(m)})
+}
+
+func (w *jsonLinesWriter) WriteGap(chatId int64, gap *entities.MessageGap) error {
+	return w.encoder.Encode(&exportLine{Type: recordGap, ChatId: chatId, Data: gap})
+}
+
+func (w *jsonLinesWriter) WriteRecord(kind string, v interface{}) error {
+	return w.encoder.Encode(&exportLine{Type: kind, Data: v})
+}
+
+func (w *jsonLinesWriter) Close() error {
+	return w.buffer.Flush()
+}
+
+// htmlWriter 生成不依赖外部资源的单个 html 文件，所有内容均经过转义
+type htmlWriter struct {
+	buffer *bufio.Writer
+}
+
+const htmlHeader = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+body { font-family: sans-serif; max-width: 860px; margin: 24px auto; color: #222; }
+.message { padding: 6px 0; border-bottom: 1px solid #eee; }
+.meta { color: #888; font-size: 12px; }
+.content { margin: 4px 0 0; white-space: pre-wrap; word-break: break-word; }
+.gap { margin: 8px 0; padding: 6px; background: #f6f6f6; color: #888; text-align: center; }
+pre { background: #f6f6f6; padding: 8px; overflow-x: auto; }
+</style>
+</head>
+<body>
+<h1>%s</h1>
+`
+
+const htmlFooter = `</body>
+</html>
+`
+
+func newHtmlWriter(title string, w io.Writer) (*htmlWriter, error) {
+	buffer := bufio.NewWriter(w)
+	title = html.EscapeString(title)
+	if _, err := fmt.Fprintf(buffer, htmlHeader, title, title); err != nil {
+		return nil, err
+	}
+	return &htmlWriter{buffer: buffer}, nil
+}
+
+func (w *htmlWriter) WriteMessage(m *entities.Message) error {
+	_, err := fmt.Fprintf(w.buffer,
+		"<div class=\"message\"><div class=\"meta\">#%d 用户 %d · %s</div><p class=\"content\">%s</p></div>\n",
+		m.Id, m.Sender, time.UnixMilli(int64(m.Timestamp)).UTC().Format(htmlTimeLayout), html.EscapeString(m.Content))
+	return err
+}
+
+func (w *htmlWriter) WriteGap(chatId int64, gap *entities.MessageGap) error {
+	_, err := fmt.Fprintf(w.buffer, "<div class=\"gap\">会话 %d 中序号 %d 至 %d 的消息已按保留策略清理</div>\n",
+		chatId, gap.BottomId, gap.TopId)
+	return err
+}
+
+func (w *htmlWriter) WriteRecord(kind string, v interface{}) error {
+	// 内容已整体转义，这里不再需要 json 的 html 转义，保持原文可读
+	data := &bytes.Buffer{}
+	encoder := json.NewEncoder(data)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w.buffer, "<h2>%s</h2>\n<pre>%s</pre>\n", html.EscapeString(kind), html.EscapeString(data.String()))
+	return err
+}
+
+func (w *htmlWriter) Close() error {
+	if _, err := w.buffer.WriteString(htmlFooter); err != nil {
+		return err
+	}
+	return w.buffer.Flush()
+}
@@ -0,0 +1,154 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"liveChat/log"
+	"liveChat/webhook"
+)
+
+// errorAbortTransaction 在事务中已生成错误回包时用于回滚事务，回包本身不受影响
+var errorAbortTransaction = errors.New("请求处理失败，回滚事务")
+
+// deleteAccount 注销账号：解除好友关系，退出所有群组，自己创建的群组按继任规则转让或解散，
+// 停用自己创建的机器人，并断开所有在线连接
+func deleteAccount(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		password = ctx.Param[passwordKey].(string)
+	)
+
+	if ok, err := db.CheckPassword(nil, userId, password); err != nil {
+		return errorHandlerHook(InternalError, err.Error())
+	} else if !ok {
+		return errorHandlerHook(UserNotFound, "请检查您的密码是否正确")
+	}
+
+	memberships, err := db.SelectGroupInfoForUser(nil, userId)
+	if err != nil {
+		return errorHandlerHook(InternalError, err.Error())
+	}
+
+	friendships, err := db.SelectFriendShip(nil, userId)
+	if err != nil {
+		return errorHandlerHook(InternalError, err.Error())
+	}
+
+	var (
+		notifications   = make([]*entities.Notification, 0, len(memberships)+len(friendships))
+		leftGroups      = make([]int64, 0, len(memberships))
+		dissolvedGroups = make([]int64, 0)
+		disabledBots    []int64
+	)
+
+	if db.StartDbTransaction(func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) error {
+		for _, membership := range memberships {
+			var (
+				groupId = membership.GroupId
+				info    *entities.GroupInfo
+				noti    *entities.Notification
+			)
+
+			if info, err = db.SearchGroupInfo(mysqlTx, groupId, true); err != nil {
+				retBuf, err = errorHandlerHook(InternalError, err.Error())
+				return errorAbortTransaction
+			} else if info.IsDeleted {
+				continue
+			}
+
+			if info.Owner == userId {
+				successor, ok := info.Successor()
+				if !ok {
+					var dissolveNotifications []*entities.Notification
+					if dissolveNotifications, err = dissolveGroupInTx(mysqlTx, mongoTx, groupId, userId); err != nil {
+						retBuf, err = errorHandlerHook(InternalError, err.Error())
+						return errorAbortTransaction
+					}
+					notifications = append(notifications, dissolveNotifications...)
+					dissolvedGroups = append(dissolvedGroups, groupId)
+					continue
+				}
+
+				if noti, retBuf, err = handOverGroup(mysqlTx, mongoTx, groupId, userId, successor); len(retBuf) != 0 || err != nil {
+					return errorAbortTransaction
+				}
+				notifications = append(notifications, noti)
+			}
+
+			if err = db.DeleteFromGroup(mysqlTx, userId, groupId); err != nil {
+				retBuf, err = errorHandlerHook(InternalError, err.Error())
+				return errorAbortTransaction
+			}
+
+			noti = entities.NewNotification(userId, groupId, entities.Delete, entities.Group, true, true)
+			noti.HandleUserId = userId
+			if noti, err = db.AddAndReturnNotification(mongoTx, noti); err != nil {
+				retBuf, err = errorHandlerHook(InternalError, err.Error())
+				return errorAbortTransaction
+			}
+			notifications = append(notifications, noti)
+			leftGroups = append(leftGroups, groupId)
+		}
+
+		for _, friendship := range friendships {
+			noti := entities.NewNotification(userId, friendship.FriendId, entities.Delete, entities.Friend, true, true)
+			noti.HandleUserId = userId
+			if noti, err = db.AddAndReturnNotification(mongoTx, noti); err != nil {
+				retBuf, err = errorHandlerHook(InternalError, err.Error())
+				return errorAbortTransaction
+			}
+			notifications = append(notifications, noti)
+		}
+
+		if disabledBots, err = db.DisableBotsOfOwner(mysqlTx, userId); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return errorAbortTransaction
+		}
+
+		if err = db.DeleteAccount(mysqlTx, userId); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return errorAbortTransaction
+		}
+		return nil
+	}) != nil {
+		return
+	}
+
+	// 账号已删除，以下步骤失败时只记录日志，缓存会在过期后自动更新
+	if err := controllers.RevokeToken(userId); err != nil {
+		log.Error(fmt.Sprintf("注销用户 %d 的 token 失败: %s", userId, err.Error()))
+	}
+	controllers.KickUserOff(userId)
+
+	for _, botId := range disabledBots {
+		if _, err := controllers.GetBotInfo(botId, true); err != nil {
+			log.Error(fmt.Sprintf("刷新机器人 %d 缓存失败: %s", botId, err.Error()))
+		}
+	}
+
+	for _, groupId := range append(leftGroups, dissolvedGroups...) {
+		if err := controllers.RefreshGroupInfoCache(groupId); err != nil {
+			log.Error(fmt.Sprintf("刷新群组 %d 缓存失败: %s", groupId, err.Error()))
+		}
+	}
+
+	for _, friendship := range friendships {
+		if _, err := controllers.CheckAreUsersFriend(userId, friendship.FriendId, true); err != nil {
+			log.Error(fmt.Sprintf("刷新好友关系缓存失败: %s", err.Error()))
+		}
+	}
+
+	for _, noti := range notifications {
+		SendNotification(noti)
+	}
+
+	for _, groupId := range dissolvedGroups {
+		webhook.Publish(webhook.EventGroupDeleted, groupId, &webhook.GroupData{GroupId: groupId, OperatorId: userId})
+	}
+	return
+}
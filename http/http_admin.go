@@ -0,0 +1,388 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"liveChat/log"
+	"liveChat/webhook"
+	"math"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	banDurationParam = "duration"
+
+	banDurationKey      = "banDuration"
+	announcementBodyKey = "systemAnnouncement"
+)
+
+const (
+	maxAnnouncementTargets   = 1000
+	announcementDeliverBatch = 500
+	// maxAuditMessageLength 为审计日志中保留的公告内容长度
+	maxAuditMessageLength = 256
+)
+
+type AdminUserBody struct {
+	ResponseHeader
+	User        *entities.UserInfo `json:"user"`
+	IsDeleted   bool               `json:"isDeleted"`
+	IsMuted     bool               `json:"isMuted"`
+	BannedUntil int64              `json:"bannedUntil"`
+}
+
+type AdminGroupBody struct {
+	ResponseHeader
+	Group     *entities.GroupInfo `json:"group"`
+	IsDeleted bool                `json:"isDeleted"`
+}
+
+type UserConnection struct {
+	Platform      string `json:"platform"`
+	RemoteAddress string `json:"remoteAddress"`
+	ConnectedAt   int64  `json:"connectedAt"`
+}
+
+// NodeConnections 中 Error 不为空时表示查询该节点失败，结果可能不完整
+type NodeConnections struct {
+	NodeId      int64            `json:"nodeId"`
+	Connections []UserConnection `json:"connections"`
+	Error       string           `json:"error,omitempty"`
+}
+
+type UserConnectionListBody struct {
+	ResponseHeader
+	Nodes []NodeConnections `json:"nodes"`
+}
+
+// systemAnnouncementForm 中 UserIds 与 GroupIds 均为空时发送给全体用户，否则发送给指定用户与指定群组的成员
+type systemAnnouncementForm struct {
+	Message  string  `json:"message"`
+	UserIds  []int64 `json:"userIds"`
+	GroupIds []int64 `json:"groupIds"`
+}
+
+func getBanDurationFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	duration, retBuf, err := getOptionalInt64ParamFromURL(ctx, banDurationParam, 0)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if duration < 0 {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "封禁时长超出范围")
+		return
+	}
+
+	ctx.Param[banDurationKey] = duration
+	return
+}
+
+func getSystemAnnouncementPostInBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	ginCtx := ctx.Ctx.(*gin.Context)
+
+	form := &systemAnnouncementForm{}
+	err = ginCtx.BindJSON(form)
+	if err != nil {
+		retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "Json 表单解析错误")
+		return
+	}
+
+	if form.Message == "" {
+		retBuf, err = errorHandlerHook(LackOfParameter, "缺少公告内容")
+		return
+	} else if utf8.RuneCountInString(form.Message) > maxAnnouncementBodyLength {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "公告内容过长")
+		return
+	} else if len(form.UserIds)+len(form.GroupIds) > maxAnnouncementTargets {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "公告接收对象过多")
+		return
+	}
+
+	for _, groupId := range form.GroupIds {
+		if groupId >= 0 {
+			retBuf, err = errorHandlerHook(UserParamTypeIllegal, "群组 id 无效")
+			return
+		}
+	}
+
+	ctx.Param[announcementBodyKey] = form
+	return
+}
+
+func returnAdminUserBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	userId := ctx.Param[userIdKey].(int64)
+
+	info, err := db.SearchUserInfo(nil, userId, true)
+	if err == db.MysqlErrorUserNotExist {
+		retBuf, err = errorHandlerHook(UserNotFound, "用户不存在")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	isMuted, err := db.IsUserMuted(userId)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&AdminUserBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		User:           info,
+		IsDeleted:      info.IsDeleted,
+		IsMuted:        isMuted,
+		BannedUntil:    info.BannedUntil,
+	}).MarshalJSON()
+}
+
+func returnAdminGroupBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	info, err := db.SearchGroupInfo(nil, ctx.Param[groupIdKey].(int64), true)
+	if err == db.MysqlErrorGroupNotExist {
+		retBuf, err = errorHandlerHook(GroupNotFound, "群组不存在")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&AdminGroupBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Group:          info,
+		IsDeleted:      info.IsDeleted,
+	}).MarshalJSON()
+}
+
+// returnUserConnectionListBody 通过 ServerNode rpc 查询用户在各个节点上的连接，只返回有连接或查询失败的节点
+func returnUserConnectionListBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	nodes := make([]NodeConnections, 0)
+	for _, node := range controllers.ListUserConnections(ctx.Param[userIdKey].(int64)) {
+		nodeConnections := NodeConnections{NodeId: node.NodeId, Connections: make([]UserConnection, 0), Error: node.Error}
+		for _, conn := range node.Connections {
+			nodeConnections.Connections = append(nodeConnections.Connections, UserConnection{
+				Platform:      conn.Platform.String(),
+				RemoteAddress: conn.RemoteAddress,
+				ConnectedAt:   conn.ConnectedAt,
+			})
+		}
+		nodes = append(nodes, nodeConnections)
+	}
+
+	return (&UserConnectionListBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Nodes:          nodes,
+	}).MarshalJSON()
+}
+
+func adminForceLogout(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	userId := ctx.Param[userIdKey].(int64)
+	if retBuf, err = forceLogout(userId); len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	return addAdminAuditLog(ctx, entities.AdminForceLogout, entities.ReportUser, userId, "")
+}
+
+func adminBanUser(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	userId := ctx.Param[userIdKey].(int64)
+	detail, retBuf, err := banAccount(userId, ctx.Param[banDurationKey].(int64))
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	return addAdminAuditLog(ctx, entities.ReviewBan, entities.ReportUser, userId, detail)
+}
+
+func adminUnbanUser(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	userId := ctx.Param[userIdKey].(int64)
+	if err = db.BanUser(nil, userId, 0); err == db.MysqlErrorUserNotExist {
+		retBuf, err = errorHandlerHook(UserNotFound, "用户不存在")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	if err = setBotDisabled(userId, false); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return addAdminAuditLog(ctx, entities.AdminUnban, entities.ReportUser, userId, "")
+}
+
+func adminDissolveGroup(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	groupId := ctx.Param[groupIdKey].(int64)
+	if retBuf, err = dissolveGroup(groupId); len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	return addAdminAuditLog(ctx, entities.ReviewDissolveGroup, entities.ReportGroup, groupId, "")
+}
+
+// adminSendSystemAnnouncement 记录审计日志后在后台逐个用户写入并下发公告
+func adminSendSystemAnnouncement(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	form := ctx.Param[announcementBodyKey].(*systemAnnouncementForm)
+
+	target := "全体用户"
+	if len(form.UserIds) != 0 || len(form.GroupIds) != 0 {
+		target = fmt.Sprintf("%d 个用户，%d 个群组", len(form.UserIds), len(form.GroupIds))
+	}
+	message := []rune(form.Message)
+	if len(message) > maxAuditMessageLength {
+		message = append(message[:maxAuditMessageLength], []rune("...")...)
+	}
+	detail := fmt.Sprintf("%s: %s", target, string(message))
+	if retBuf, err = addAdminAuditLog(ctx, entities.AdminAnnounce, entities.AuditSystem, 0, detail); len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	go deliverSystemAnnouncement(form)
+	return
+}
+
+func addAdminAuditLog(ctx *controllers.ProcessContext, action string, targetType byte, targetId int64, detail string) (retBuf []byte, err error) {
+	auditLog := entities.NewOperatorAuditLog(ctx.Param[operatorIdKey].(string), action, targetType, targetId, detail)
+	if err = db.AddAuditLog(nil, auditLog); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
+
+// forceLogout 使用户的 token 失效并断开其在所有节点上的连接
+func forceLogout(userId int64) (retBuf []byte, err error) {
+	if err = controllers.RevokeToken(userId); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	controllers.KickUserOff(userId)
+	return
+}
+
+// banAccount 封禁用户 durationSeconds 秒并使其下线，为 0 时永久封禁，返回写入审计日志的详情
+func banAccount(userId, durationSeconds int64) (detail string, retBuf []byte, err error) {
+	bannedUntil := int64(math.MaxInt64)
+	detail = "永久封禁"
+	if durationSeconds > 0 {
+		bannedUntil = time.Now().Add(time.Duration(durationSeconds) * time.Second).UnixMilli()
+		detail = fmt.Sprintf("封禁 %d 秒", durationSeconds)
+	}
+
+	if err = db.BanUser(nil, userId, bannedUntil); err == db.MysqlErrorUserNotExist {
+		retBuf, err = errorHandlerHook(UserNotFound, "用户不存在")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	// 机器人以 API Key 鉴权，不受 token 失效的影响，需要停用
+	if err = setBotDisabled(userId, true); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	retBuf, err = forceLogout(userId)
+	return
+}
+
+// setBotDisabled userId 为机器人时随封禁停用或重新启用机器人，并刷新本节点的缓存
+func setBotDisabled(userId int64, isDisabled bool) error {
+	info, err := controllers.GetBotInfo(userId, true)
+	if err != nil || info == nil {
+		return err
+	}
+
+	if err = db.SetBotDisabled(nil, userId, isDisabled); err != nil {
+		return err
+	}
+	if _, err = controllers.GetBotInfo(userId, true); err != nil {
+		return err
+	}
+	_, err = controllers.GetBotBannedUntil(userId, true)
+	return err
+}
+
+// dissolveGroup 由运维解散群组并通知解散前的全部成员，通知的处理人为 0
+func dissolveGroup(groupId int64) (retBuf []byte, err error) {
+	var notifications []*entities.Notification
+	if db.StartDbTransaction(func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) error {
+		if notifications, err = dissolveGroupInTx(mysqlTx, mongoTx, groupId, 0); err == db.MysqlErrorGroupNotExist {
+			retBuf, err = errorHandlerHook(GroupNotFound, "群组不存在")
+			return errorAbortTransaction
+		} else if err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return errorAbortTransaction
+		}
+		return nil
+	}) != nil {
+		return
+	}
+
+	if err = controllers.RefreshGroupInfoCache(groupId); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	for _, noti := range notifications {
+		SendNotification(noti)
+	}
+	webhook.Publish(webhook.EventGroupDeleted, groupId, &webhook.GroupData{GroupId: groupId})
+	return
+}
+
+func deliverSystemAnnouncement(form *systemAnnouncementForm) {
+	if len(form.UserIds) == 0 && len(form.GroupIds) == 0 {
+		for cursor := int64(0); ; {
+			userIds, err := db.SelectUserIds(nil, cursor, announcementDeliverBatch)
+			if err != nil {
+				log.Error(fmt.Sprintf("获取系统公告接收者失败: %s", err.Error()))
+				return
+			}
+			for _, userId := range userIds {
+				sendSystemAnnouncement(userId, form.Message)
+			}
+			if len(userIds) < announcementDeliverBatch {
+				return
+			}
+			cursor = userIds[len(userIds)-1]
+		}
+	}
+
+	recipients := make(map[int64]struct{}, len(form.UserIds))
+	for _, userId := range form.UserIds {
+		recipients[userId] = struct{}{}
+	}
+	for _, groupId := range form.GroupIds {
+		members, err := db.SelectGroupMemberList(nil, groupId)
+		if err != nil {
+			log.Error(fmt.Sprintf("获取群组 %d 成员失败: %s", groupId, err.Error()))
+			continue
+		}
+		for _, member := range members {
+			recipients[member.MemberId] = struct{}{}
+		}
+	}
+
+	for userId := range recipients {
+		sendSystemAnnouncement(userId, form.Message)
+	}
+}
+
+func sendSystemAnnouncement(userId int64, message string) {
+	noti := entities.NewNotification(0, userId, entities.Announce, entities.System, true, true)
+	noti.Message = message
+	if _, err := db.AddAndReturnNotification(context.Background(), noti); err != nil {
+		log.Error(fmt.Sprintf("写入用户 %d 的系统公告失败: %s", userId, err.Error()))
+		return
+	}
+	SendNotification(noti)
+}
@@ -0,0 +1,1436 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	entities "liveChat/entities"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonC7391280DecodeLiveChatHttp(in *jlexer.Lexer, out *systemAnnouncementForm) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "message":
+			out.Message = string(in.String())
+		case "userIds":
+			if in.IsNull() {
+				in.Skip()
+				out.UserIds = nil
+			} else {
+				in.Delim('[')
+				if out.UserIds == nil {
+					if !in.IsDelim(']') {
+						out.UserIds = make([]int64, 0, 8)
+					} else {
+						out.UserIds = []int64{}
+					}
+				} else {
+					out.UserIds = (out.UserIds)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 int64
+					v1 = int64(in.Int64())
+					out.UserIds = append(out.UserIds, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "groupIds":
+			if in.IsNull() {
+				in.Skip()
+				out.GroupIds = nil
+			} else {
+				in.Delim('[')
+				if out.GroupIds == nil {
+					if !in.IsDelim(']') {
+						out.GroupIds = make([]int64, 0, 8)
+					} else {
+						out.GroupIds = []int64{}
+					}
+				} else {
+					out.GroupIds = (out.GroupIds)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 int64
+					v2 = int64(in.Int64())
+					out.GroupIds = append(out.GroupIds, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC7391280EncodeLiveChatHttp(out *jwriter.Writer, in systemAnnouncementForm) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"userIds\":"
+		out.RawString(prefix)
+		if in.UserIds == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v3, v4 := range in.UserIds {
+				if v3 > 0 {
+					out.RawByte(',')
+				}
+				out.Int64(int64(v4))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"groupIds\":"
+		out.RawString(prefix)
+		if in.GroupIds == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.GroupIds {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.Int64(int64(v6))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v systemAnnouncementForm) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC7391280EncodeLiveChatHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v systemAnnouncementForm) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC7391280EncodeLiveChatHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *systemAnnouncementForm) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC7391280DecodeLiveChatHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *systemAnnouncementForm) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC7391280DecodeLiveChatHttp(l, v)
+}
+func easyjsonC7391280DecodeLiveChatHttp1(in *jlexer.Lexer, out *UserConnectionListBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "nodes":
+			if in.IsNull() {
+				in.Skip()
+				out.Nodes = nil
+			} else {
+				in.Delim('[')
+				if out.Nodes == nil {
+					if !in.IsDelim(']') {
+						out.Nodes = make([]NodeConnections, 0, 1)
+					} else {
+						out.Nodes = []NodeConnections{}
+					}
+				} else {
+					out.Nodes = (out.Nodes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 NodeConnections
+					(v7).UnmarshalEasyJSON(in)
+					out.Nodes = append(out.Nodes, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC7391280EncodeLiveChatHttp1(out *jwriter.Writer, in UserConnectionListBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"nodes\":"
+		out.RawString(prefix[1:])
+		if in.Nodes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Nodes {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				(v9).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserConnectionListBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC7391280EncodeLiveChatHttp1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserConnectionListBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC7391280EncodeLiveChatHttp1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserConnectionListBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC7391280DecodeLiveChatHttp1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserConnectionListBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC7391280DecodeLiveChatHttp1(l, v)
+}
+func easyjsonC7391280DecodeLiveChatHttp2(in *jlexer.Lexer, out *UserConnection) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "platform":
+			out.Platform = string(in.String())
+		case "remoteAddress":
+			out.RemoteAddress = string(in.String())
+		case "connectedAt":
+			out.ConnectedAt = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC7391280EncodeLiveChatHttp2(out *jwriter.Writer, in UserConnection) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"platform\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Platform))
+	}
+	{
+		const prefix string = ",\"remoteAddress\":"
+		out.RawString(prefix)
+		out.String(string(in.RemoteAddress))
+	}
+	{
+		const prefix string = ",\"connectedAt\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ConnectedAt))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserConnection) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC7391280EncodeLiveChatHttp2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserConnection) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC7391280EncodeLiveChatHttp2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserConnection) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC7391280DecodeLiveChatHttp2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserConnection) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC7391280DecodeLiveChatHttp2(l, v)
+}
+func easyjsonC7391280DecodeLiveChatHttp3(in *jlexer.Lexer, out *NodeConnections) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "nodeId":
+			out.NodeId = int64(in.Int64())
+		case "connections":
+			if in.IsNull() {
+				in.Skip()
+				out.Connections = nil
+			} else {
+				in.Delim('[')
+				if out.Connections == nil {
+					if !in.IsDelim(']') {
+						out.Connections = make([]UserConnection, 0, 1)
+					} else {
+						out.Connections = []UserConnection{}
+					}
+				} else {
+					out.Connections = (out.Connections)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 UserConnection
+					(v10).UnmarshalEasyJSON(in)
+					out.Connections = append(out.Connections, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "error":
+			out.Error = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC7391280EncodeLiveChatHttp3(out *jwriter.Writer, in NodeConnections) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"nodeId\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.NodeId))
+	}
+	{
+		const prefix string = ",\"connections\":"
+		out.RawString(prefix)
+		if in.Connections == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.Connections {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				(v12).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v NodeConnections) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC7391280EncodeLiveChatHttp3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v NodeConnections) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC7391280EncodeLiveChatHttp3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *NodeConnections) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC7391280DecodeLiveChatHttp3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *NodeConnections) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC7391280DecodeLiveChatHttp3(l, v)
+}
+func easyjsonC7391280DecodeLiveChatHttp4(in *jlexer.Lexer, out *AdminUserBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user":
+			if in.IsNull() {
+				in.Skip()
+				out.User = nil
+			} else {
+				if out.User == nil {
+					out.User = new(entities.UserInfo)
+				}
+				easyjsonC7391280DecodeLiveChatEntities(in, out.User)
+			}
+		case "isDeleted":
+			out.IsDeleted = bool(in.Bool())
+		case "isMuted":
+			out.IsMuted = bool(in.Bool())
+		case "bannedUntil":
+			out.BannedUntil = int64(in.Int64())
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC7391280EncodeLiveChatHttp4(out *jwriter.Writer, in AdminUserBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user\":"
+		out.RawString(prefix[1:])
+		if in.User == nil {
+			out.RawString("null")
+		} else {
+			easyjsonC7391280EncodeLiveChatEntities(out, *in.User)
+		}
+	}
+	{
+		const prefix string = ",\"isDeleted\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsDeleted))
+	}
+	{
+		const prefix string = ",\"isMuted\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsMuted))
+	}
+	{
+		const prefix string = ",\"bannedUntil\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BannedUntil))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AdminUserBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC7391280EncodeLiveChatHttp4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AdminUserBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC7391280EncodeLiveChatHttp4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AdminUserBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC7391280DecodeLiveChatHttp4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AdminUserBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC7391280DecodeLiveChatHttp4(l, v)
+}
+func easyjsonC7391280DecodeLiveChatEntities(in *jlexer.Lexer, out *entities.UserInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.Id = int64(in.Int64())
+		case "username":
+			out.Username = string(in.String())
+		case "avatar":
+			out.UserAvatar = string(in.String())
+		case "introduction":
+			out.UserIntroduction = string(in.String())
+		case "isBot":
+			out.IsBot = bool(in.Bool())
+		case "discoverableBy":
+			out.DiscoverableBy = uint8(in.Uint8())
+		case "addFriendPolicy":
+			out.AddFriendPolicy = uint8(in.Uint8())
+		case "retentionPolicy":
+			if in.IsNull() {
+				in.Skip()
+				out.RetentionPolicy = nil
+			} else {
+				if out.RetentionPolicy == nil {
+					out.RetentionPolicy = new(entities.RetentionPolicy)
+				}
+				easyjsonC7391280DecodeLiveChatEntities1(in, out.RetentionPolicy)
+			}
+		case "friendships":
+			if in.IsNull() {
+				in.Skip()
+				out.Friendships = nil
+			} else {
+				in.Delim('[')
+				if out.Friendships == nil {
+					if !in.IsDelim(']') {
+						out.Friendships = make([]entities.Friendship, 0, 0)
+					} else {
+						out.Friendships = []entities.Friendship{}
+					}
+				} else {
+					out.Friendships = (out.Friendships)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 entities.Friendship
+					easyjsonC7391280DecodeLiveChatEntities2(in, &v13)
+					out.Friendships = append(out.Friendships, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "groupList":
+			if in.IsNull() {
+				in.Skip()
+				out.Groups = nil
+			} else {
+				in.Delim('[')
+				if out.Groups == nil {
+					if !in.IsDelim(']') {
+						out.Groups = make([]entities.GroupMember, 0, 0)
+					} else {
+						out.Groups = []entities.GroupMember{}
+					}
+				} else {
+					out.Groups = (out.Groups)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v14 entities.GroupMember
+					easyjsonC7391280DecodeLiveChatEntities3(in, &v14)
+					out.Groups = append(out.Groups, v14)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "contactGroups":
+			if in.IsNull() {
+				in.Skip()
+				out.ContactGroups = nil
+			} else {
+				in.Delim('[')
+				if out.ContactGroups == nil {
+					if !in.IsDelim(']') {
+						out.ContactGroups = make([]entities.ContactGroup, 0, 2)
+					} else {
+						out.ContactGroups = []entities.ContactGroup{}
+					}
+				} else {
+					out.ContactGroups = (out.ContactGroups)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v15 entities.ContactGroup
+					easyjsonC7391280DecodeLiveChatEntities4(in, &v15)
+					out.ContactGroups = append(out.ContactGroups, v15)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC7391280EncodeLiveChatEntities(out *jwriter.Writer, in entities.UserInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Id))
+	}
+	{
+		const prefix string = ",\"username\":"
+		out.RawString(prefix)
+		out.String(string(in.Username))
+	}
+	{
+		const prefix string = ",\"avatar\":"
+		out.RawString(prefix)
+		out.String(string(in.UserAvatar))
+	}
+	{
+		const prefix string = ",\"introduction\":"
+		out.RawString(prefix)
+		out.String(string(in.UserIntroduction))
+	}
+	{
+		const prefix string = ",\"isBot\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsBot))
+	}
+	{
+		const prefix string = ",\"discoverableBy\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.DiscoverableBy))
+	}
+	{
+		const prefix string = ",\"addFriendPolicy\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.AddFriendPolicy))
+	}
+	{
+		const prefix string = ",\"retentionPolicy\":"
+		out.RawString(prefix)
+		if in.RetentionPolicy == nil {
+			out.RawString("null")
+		} else {
+			easyjsonC7391280EncodeLiveChatEntities1(out, *in.RetentionPolicy)
+		}
+	}
+	{
+		const prefix string = ",\"friendships\":"
+		out.RawString(prefix)
+		if in.Friendships == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v16, v17 := range in.Friendships {
+				if v16 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonC7391280EncodeLiveChatEntities2(out, v17)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"groupList\":"
+		out.RawString(prefix)
+		if in.Groups == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v18, v19 := range in.Groups {
+				if v18 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonC7391280EncodeLiveChatEntities3(out, v19)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"contactGroups\":"
+		out.RawString(prefix)
+		if in.ContactGroups == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v20, v21 := range in.ContactGroups {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonC7391280EncodeLiveChatEntities4(out, v21)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonC7391280DecodeLiveChatEntities4(in *jlexer.Lexer, out *entities.ContactGroup) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.Id = int64(in.Int64())
+		case "name":
+			out.Name = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC7391280EncodeLiveChatEntities4(out *jwriter.Writer, in entities.ContactGroup) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Id))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	out.RawByte('}')
+}
+func easyjsonC7391280DecodeLiveChatEntities3(in *jlexer.Lexer, out *entities.GroupMember) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "groupId":
+			out.GroupId = int64(in.Int64())
+		case "memberId":
+			out.MemberId = int64(in.Int64())
+		case "isAdministrator":
+			out.IsAdministrator = bool(in.Bool())
+		case "role":
+			out.Role = string(in.String())
+		case "mutedUntil":
+			out.MutedUntil = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC7391280EncodeLiveChatEntities3(out *jwriter.Writer, in entities.GroupMember) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"groupId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.GroupId))
+	}
+	{
+		const prefix string = ",\"memberId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MemberId))
+	}
+	{
+		const prefix string = ",\"isAdministrator\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsAdministrator))
+	}
+	{
+		const prefix string = ",\"role\":"
+		out.RawString(prefix)
+		out.String(string(in.Role))
+	}
+	{
+		const prefix string = ",\"mutedUntil\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MutedUntil))
+	}
+	out.RawByte('}')
+}
+func easyjsonC7391280DecodeLiveChatEntities2(in *jlexer.Lexer, out *entities.Friendship) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "selfId":
+			out.SelfId = int64(in.Int64())
+		case "friendId":
+			out.FriendId = int64(in.Int64())
+		case "chatId":
+			out.ChatId = int64(in.Int64())
+		case "remark":
+			out.Remark = string(in.String())
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v22 string
+					v22 = string(in.String())
+					out.Tags = append(out.Tags, v22)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "contactGroupId":
+			out.ContactGroupId = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC7391280EncodeLiveChatEntities2(out *jwriter.Writer, in entities.Friendship) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"selfId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.SelfId))
+	}
+	{
+		const prefix string = ",\"friendId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.FriendId))
+	}
+	{
+		const prefix string = ",\"chatId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ChatId))
+	}
+	{
+		const prefix string = ",\"remark\":"
+		out.RawString(prefix)
+		out.String(string(in.Remark))
+	}
+	{
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		if in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v23, v24 := range in.Tags {
+				if v23 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v24))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"contactGroupId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ContactGroupId))
+	}
+	out.RawByte('}')
+}
+func easyjsonC7391280DecodeLiveChatEntities1(in *jlexer.Lexer, out *entities.RetentionPolicy) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "keepDays":
+			out.KeepDays = int64(in.Int64())
+		case "keepLast":
+			out.KeepLast = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC7391280EncodeLiveChatEntities1(out *jwriter.Writer, in entities.RetentionPolicy) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"keepDays\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.KeepDays))
+	}
+	{
+		const prefix string = ",\"keepLast\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.KeepLast))
+	}
+	out.RawByte('}')
+}
+func easyjsonC7391280DecodeLiveChatHttp5(in *jlexer.Lexer, out *AdminGroupBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "group":
+			if in.IsNull() {
+				in.Skip()
+				out.Group = nil
+			} else {
+				if out.Group == nil {
+					out.Group = new(entities.GroupInfo)
+				}
+				easyjsonC7391280DecodeLiveChatEntities5(in, out.Group)
+			}
+		case "isDeleted":
+			out.IsDeleted = bool(in.Bool())
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC7391280EncodeLiveChatHttp5(out *jwriter.Writer, in AdminGroupBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"group\":"
+		out.RawString(prefix[1:])
+		if in.Group == nil {
+			out.RawString("null")
+		} else {
+			easyjsonC7391280EncodeLiveChatEntities5(out, *in.Group)
+		}
+	}
+	{
+		const prefix string = ",\"isDeleted\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsDeleted))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AdminGroupBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC7391280EncodeLiveChatHttp5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AdminGroupBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC7391280EncodeLiveChatHttp5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AdminGroupBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC7391280DecodeLiveChatHttp5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AdminGroupBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC7391280DecodeLiveChatHttp5(l, v)
+}
+func easyjsonC7391280DecodeLiveChatEntities5(in *jlexer.Lexer, out *entities.GroupInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.Id = int64(in.Int64())
+		case "ownerId":
+			out.Owner = int64(in.Int64())
+		case "name":
+			out.Name = string(in.String())
+		case "introduction":
+			out.Introduction = string(in.String())
+		case "avatar":
+			out.Avatar = string(in.String())
+		case "isMuteAll":
+			out.IsMuteAll = bool(in.Bool())
+		case "joinPolicy":
+			out.JoinPolicy = uint8(in.Uint8())
+		case "successionPolicy":
+			out.SuccessionPolicy = uint8(in.Uint8())
+		case "retentionPolicy":
+			if in.IsNull() {
+				in.Skip()
+				out.RetentionPolicy = nil
+			} else {
+				if out.RetentionPolicy == nil {
+					out.RetentionPolicy = new(entities.RetentionPolicy)
+				}
+				easyjsonC7391280DecodeLiveChatEntities1(in, out.RetentionPolicy)
+			}
+		case "members":
+			if in.IsNull() {
+				in.Skip()
+				out.Members = nil
+			} else {
+				in.Delim('[')
+				if out.Members == nil {
+					if !in.IsDelim(']') {
+						out.Members = make([]entities.GroupMember, 0, 0)
+					} else {
+						out.Members = []entities.GroupMember{}
+					}
+				} else {
+					out.Members = (out.Members)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v25 entities.GroupMember
+					easyjsonC7391280DecodeLiveChatEntities3(in, &v25)
+					out.Members = append(out.Members, v25)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "roles":
+			if in.IsNull() {
+				in.Skip()
+				out.Roles = nil
+			} else {
+				in.Delim('[')
+				if out.Roles == nil {
+					if !in.IsDelim(']') {
+						out.Roles = make([]entities.GroupRole, 0, 1)
+					} else {
+						out.Roles = []entities.GroupRole{}
+					}
+				} else {
+					out.Roles = (out.Roles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v26 entities.GroupRole
+					easyjsonC7391280DecodeLiveChatEntities6(in, &v26)
+					out.Roles = append(out.Roles, v26)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC7391280EncodeLiveChatEntities5(out *jwriter.Writer, in entities.GroupInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Id))
+	}
+	{
+		const prefix string = ",\"ownerId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Owner))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"introduction\":"
+		out.RawString(prefix)
+		out.String(string(in.Introduction))
+	}
+	{
+		const prefix string = ",\"avatar\":"
+		out.RawString(prefix)
+		out.String(string(in.Avatar))
+	}
+	{
+		const prefix string = ",\"isMuteAll\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsMuteAll))
+	}
+	{
+		const prefix string = ",\"joinPolicy\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.JoinPolicy))
+	}
+	{
+		const prefix string = ",\"successionPolicy\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.SuccessionPolicy))
+	}
+	{
+		const prefix string = ",\"retentionPolicy\":"
+		out.RawString(prefix)
+		if in.RetentionPolicy == nil {
+			out.RawString("null")
+		} else {
+			easyjsonC7391280EncodeLiveChatEntities1(out, *in.RetentionPolicy)
+		}
+	}
+	{
+		const prefix string = ",\"members\":"
+		out.RawString(prefix)
+		if in.Members == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v27, v28 := range in.Members {
+				if v27 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonC7391280EncodeLiveChatEntities3(out, v28)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"roles\":"
+		out.RawString(prefix)
+		if in.Roles == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v29, v30 := range in.Roles {
+				if v29 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonC7391280EncodeLiveChatEntities6(out, v30)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonC7391280DecodeLiveChatEntities6(in *jlexer.Lexer, out *entities.GroupRole) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "permissions":
+			out.Permissions = entities.GroupPermission(in.Uint32())
+		case "isBuiltin":
+			out.IsBuiltin = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC7391280EncodeLiveChatEntities6(out *jwriter.Writer, in entities.GroupRole) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"permissions\":"
+		out.RawString(prefix)
+		out.Uint32(uint32(in.Permissions))
+	}
+	{
+		const prefix string = ",\"isBuiltin\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsBuiltin))
+	}
+	out.RawByte('}')
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+)
+
+type BlocklistBody struct {
+	ResponseHeader
+	Blocked []entities.Block `json:"blocked"`
+}
+
+func blockUser(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		friendId = ctx.Param[friendIdKey].(int64)
+	)
+
+	if err = db.BlockUser(nil, userId, friendId); err == db.MysqlErrorUserNotExist {
+		retBuf, err = errorHandlerHook(UserNotFound, "用户不存在")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	if _, err = controllers.CheckIsUserBlocked(userId, friendId, true); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
+
+func unblockUser(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		friendId = ctx.Param[friendIdKey].(int64)
+	)
+
+	if err = db.UnblockUser(nil, userId, friendId); err == db.MysqlErrorNoLine {
+		retBuf, err = errorHandlerHook(IllegalRequest, "用户不在黑名单中")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	if _, err = controllers.CheckIsUserBlocked(userId, friendId, true); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
+
+func returnBlocklistBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	userId := ctx.Param[userIdFromTokenKey].(int64)
+
+	blocked, err := db.SelectBlockedUsers(nil, userId)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&BlocklistBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Blocked:        blocked,
+	}).MarshalJSON()
+}
+
+// rejectRequestFromBlockedUser 对方将当前用户加入黑名单时拒绝请求
+func rejectRequestFromBlockedUser(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		friendId = ctx.Param[friendIdKey].(int64)
+	)
+
+	flag, err := controllers.CheckIsUserBlocked(friendId, userId, false)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	} else if flag {
+		retBuf, err = errorHandlerHook(IllegalRequest, "对方拒绝接收你的请求")
+	}
+	return
+}
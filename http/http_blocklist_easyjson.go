@@ -0,0 +1,186 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	entities "liveChat/entities"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonCb9b3caeDecodeLiveChatHttp(in *jlexer.Lexer, out *BlocklistBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "blocked":
+			if in.IsNull() {
+				in.Skip()
+				out.Blocked = nil
+			} else {
+				in.Delim('[')
+				if out.Blocked == nil {
+					if !in.IsDelim(']') {
+						out.Blocked = make([]entities.Block, 0, 0)
+					} else {
+						out.Blocked = []entities.Block{}
+					}
+				} else {
+					out.Blocked = (out.Blocked)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 entities.Block
+					easyjsonCb9b3caeDecodeLiveChatEntities(in, &v1)
+					out.Blocked = append(out.Blocked, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCb9b3caeEncodeLiveChatHttp(out *jwriter.Writer, in BlocklistBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"blocked\":"
+		out.RawString(prefix[1:])
+		if in.Blocked == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Blocked {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonCb9b3caeEncodeLiveChatEntities(out, v3)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BlocklistBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonCb9b3caeEncodeLiveChatHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BlocklistBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCb9b3caeEncodeLiveChatHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BlocklistBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonCb9b3caeDecodeLiveChatHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BlocklistBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCb9b3caeDecodeLiveChatHttp(l, v)
+}
+func easyjsonCb9b3caeDecodeLiveChatEntities(in *jlexer.Lexer, out *entities.Block) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "userId":
+			out.UserId = int64(in.Int64())
+		case "blockedId":
+			out.BlockedId = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCb9b3caeEncodeLiveChatEntities(out *jwriter.Writer, in entities.Block) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"userId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.UserId))
+	}
+	{
+		const prefix string = ",\"blockedId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BlockedId))
+	}
+	out.RawByte('}')
+}
@@ -0,0 +1,325 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+	"liveChat/bot"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"liveChat/rpc"
+	"liveChat/tcp"
+	"liveChat/webhook"
+	"time"
+)
+
+const (
+	botKeyHeaderParam = "x-bot-key"
+
+	botIdParam            = "botId"
+	botWebhookUrlParam    = "url"
+	botWebhookSecretParam = "secret"
+	pollTimeoutParam      = "timeout"
+
+	botIdKey            = "botId"
+	botWebhookUrlKey    = "botWebhookUrl"
+	botWebhookSecretKey = "botWebhookSecret"
+	botMessageKey       = "botMessage"
+	pollTimeoutKey      = "pollTimeout"
+)
+
+const defaultPollTimeoutInSecond = 25
+
+type BotKeyBody struct {
+	ResponseHeader
+	BotId  int64  `json:"botId"`
+	ApiKey string `json:"apiKey"`
+}
+
+type BotMessageBody struct {
+	ResponseHeader
+	Seq       uint64 `json:"seq"`
+	Timestamp uint64 `json:"timestamp"`
+}
+
+type BotInboxBody struct {
+	ResponseHeader
+	Events []json.RawMessage `json:"events"`
+}
+
+type botMessageForm struct {
+	Receiver   int64   `json:"receiver"`
+	Type       int32   `json:"type"`
+	Content    string  `json:"content"`
+	Mentions   []int64 `json:"mentions"`
+	MentionAll bool    `json:"mention_all"`
+}
+
+// validateBotKey 以请求头中的 API Key 鉴权，成功后机器人 id 与用户 Token 鉴权一样存放在 userIdFromTokenKey 中
+func validateBotKey(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	key := ctx.Ctx.(*gin.Context).GetHeader(botKeyHeaderParam)
+	if key == "" {
+		retBuf, err = errorHandlerHook(LackOfParameter, "缺少机器人密钥")
+		return
+	}
+
+	info, err := controllers.GetBotInfoByApiKey(key)
+	if err == db.MysqlErrorBotNotExist {
+		retBuf, err = errorHandlerHook(TokenInvalid, "机器人密钥无效")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	bannedUntil, err := controllers.GetBotBannedUntil(info.Id, false)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	switch err = info.CheckUsable(bannedUntil, time.Now().UnixMilli()); err {
+	case entities.ErrorBotDisabled:
+		retBuf, err = errorHandlerHook(TokenInvalid, err.Error())
+		return
+	case entities.ErrorBotBanned:
+		retBuf, err = errorHandlerHook(AccountBanned, err.Error())
+		return
+	}
+
+	ctx.Param[userIdFromTokenKey] = info.Id
+	return
+}
+
+func getBotIdFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	botId, retBuf, err := getInt64ParamFromURL(ctx, botIdParam, "缺少机器人 id", LackOfParameter)
+	if len(retBuf) == 0 && err == nil {
+		ctx.Param[botIdKey] = botId
+	}
+	return
+}
+
+func getBotWebhookFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	ginCtx := ctx.Ctx.(*gin.Context)
+	webhookUrl, secret := ginCtx.Query(botWebhookUrlParam), ginCtx.Query(botWebhookSecretParam)
+
+	if webhookUrl != "" {
+		if e := webhook.ValidatePublicUrl(ginCtx.Request.Context(), webhookUrl); e != nil {
+			retBuf, err = errorHandlerHook(UserParamTypeIllegal, e.Error())
+			return
+		} else if secret == "" {
+			retBuf, err = errorHandlerHook(LackOfParameter, "缺少签名密钥")
+			return
+		}
+	}
+
+	ctx.Param[botWebhookUrlKey] = webhookUrl
+	ctx.Param[botWebhookSecretKey] = secret
+	return
+}
+
+func getPollTimeoutFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	timeout, retBuf, err := getOptionalInt64ParamFromURL(ctx, pollTimeoutParam, defaultPollTimeoutInSecond)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	// 为 0 时不等待，直接返回收件箱中已有的事件
+	if timeout < 0 {
+		timeout = 0
+	}
+	ctx.Param[pollTimeoutKey] = time.Duration(timeout) * time.Second
+	return
+}
+
+func getBotMessagePostInBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	ginCtx := ctx.Ctx.(*gin.Context)
+
+	form := &botMessageForm{}
+	err = ginCtx.BindJSON(form)
+	if err != nil {
+		retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "Json 表单解析错误")
+		return
+	}
+
+	if form.Receiver == 0 || form.Content == "" {
+		retBuf, err = errorHandlerHook(LackOfParameter, "缺少消息接收方或内容")
+		return
+	} else if _, ok := rpc.MessageContentType_name[form.Type]; !ok {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "消息类型无效")
+		return
+	}
+
+	ctx.Param[botMessageKey] = form
+	return
+}
+
+// checkBotOwner 校验 Token 对应用户是否为机器人的创建者
+func checkBotOwner(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		botId  = ctx.Param[botIdKey].(int64)
+	)
+
+	info, err := controllers.GetBotInfo(botId, true)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	} else if info == nil {
+		retBuf, err = errorHandlerHook(UserNotFound, "机器人不存在")
+		return
+	} else if info.OwnerId != userId {
+		retBuf, err = errorHandlerHook(IllegalRequest, "无权操作该机器人")
+		return
+	}
+	return
+}
+
+func createBot(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		username = ctx.Param[usernameKey].(string)
+	)
+
+	key, hash := controllers.GenerateBotApiKey()
+	botId, err := db.RegisterBot(nil, userId, username, hash)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&BotKeyBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		BotId:          botId,
+		ApiKey:         key,
+	}).MarshalJSON()
+}
+
+func resetBotApiKey(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	botId := ctx.Param[botIdKey].(int64)
+
+	key, hash := controllers.GenerateBotApiKey()
+	if err = db.UpdateBotApiKey(nil, botId, hash); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&BotKeyBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		BotId:          botId,
+		ApiKey:         key,
+	}).MarshalJSON()
+}
+
+func setBotWebhook(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		botId      = ctx.Param[botIdKey].(int64)
+		webhookUrl = ctx.Param[botWebhookUrlKey].(string)
+		secret     = ctx.Param[botWebhookSecretKey].(string)
+	)
+
+	if err = db.UpdateBotWebhook(nil, botId, webhookUrl, secret); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	if _, err = controllers.GetBotInfo(botId, true); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
+
+func addBotToGroup(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId  = ctx.Param[userIdFromTokenKey].(int64)
+		botId   = ctx.Param[botIdKey].(int64)
+		groupId = ctx.Param[groupIdKey].(int64)
+	)
+
+	if !hasGroupPermission(ctx, entities.PermissionApproveJoin) {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+		return
+	}
+
+	flag, err := controllers.CheckIsUserInGroup(botId, groupId, true)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	} else if flag {
+		retBuf, err = errorHandlerHook(IllegalRequest, "机器人已在群组中")
+		return
+	}
+
+	db.StartDbTransaction(func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) error {
+		if err = db.AgreeJoinGroup(mysqlTx, botId, groupId); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return err
+		}
+
+		noti := entities.NewNotification(botId, groupId, entities.Add, entities.Group, true, true)
+		noti.HandleUserId = userId
+		noti, err = db.AddAndReturnNotification(mongoTx, noti)
+		if err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return err
+		}
+
+		if _, err = controllers.CheckIsUserInGroup(botId, groupId, true); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return err
+		}
+
+		SendNotification(noti)
+		return nil
+	})
+	return
+}
+
+func sendBotMessage(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		botId = ctx.Param[userIdFromTokenKey].(int64)
+		form  = ctx.Param[botMessageKey].(*botMessageForm)
+	)
+
+	entity := entities.NewMessage(0, botId, form.Receiver, uint64(time.Now().UnixMilli()), entities.ContentType(form.Type), form.Content)
+	entity.Mentions = form.Mentions
+	entity.MentionAll = form.MentionAll
+
+	message := entities.TransferMessageToProtoBuf(entity)
+	err = tcp.PostMessage(message)
+	if errors.Is(err, tcp.ErrorMessageStoreFailed) {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(IllegalRequest, err.Error())
+		return
+	}
+
+	return (&BotMessageBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Seq:            message.Id,
+		Timestamp:      message.Timestamp,
+	}).MarshalJSON()
+}
+
+func returnBotInboxBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		botId   = ctx.Param[userIdFromTokenKey].(int64)
+		timeout = ctx.Param[pollTimeoutKey].(time.Duration)
+		limit   = ctx.Param[limitKey].(int64)
+	)
+
+	events, err := bot.Poll(ctx.Ctx.(*gin.Context).Request.Context(), botId, timeout, int(limit))
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&BotInboxBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Events:         events,
+	}).MarshalJSON()
+}
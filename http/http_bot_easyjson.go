@@ -0,0 +1,434 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	jsontext "encoding/json/jsontext"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonD77487eDecodeLiveChatHttp(in *jlexer.Lexer, out *botMessageForm) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "receiver":
+			out.Receiver = int64(in.Int64())
+		case "type":
+			out.Type = int32(in.Int32())
+		case "content":
+			out.Content = string(in.String())
+		case "mentions":
+			if in.IsNull() {
+				in.Skip()
+				out.Mentions = nil
+			} else {
+				in.Delim('[')
+				if out.Mentions == nil {
+					if !in.IsDelim(']') {
+						out.Mentions = make([]int64, 0, 8)
+					} else {
+						out.Mentions = []int64{}
+					}
+				} else {
+					out.Mentions = (out.Mentions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 int64
+					v1 = int64(in.Int64())
+					out.Mentions = append(out.Mentions, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "mention_all":
+			out.MentionAll = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD77487eEncodeLiveChatHttp(out *jwriter.Writer, in botMessageForm) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"receiver\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Receiver))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Type))
+	}
+	{
+		const prefix string = ",\"content\":"
+		out.RawString(prefix)
+		out.String(string(in.Content))
+	}
+	{
+		const prefix string = ",\"mentions\":"
+		out.RawString(prefix)
+		if in.Mentions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Mentions {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.Int64(int64(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"mention_all\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.MentionAll))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v botMessageForm) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD77487eEncodeLiveChatHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v botMessageForm) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD77487eEncodeLiveChatHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *botMessageForm) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD77487eDecodeLiveChatHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *botMessageForm) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD77487eDecodeLiveChatHttp(l, v)
+}
+func easyjsonD77487eDecodeLiveChatHttp1(in *jlexer.Lexer, out *BotMessageBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "seq":
+			out.Seq = uint64(in.Uint64())
+		case "timestamp":
+			out.Timestamp = uint64(in.Uint64())
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD77487eEncodeLiveChatHttp1(out *jwriter.Writer, in BotMessageBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"seq\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.Seq))
+	}
+	{
+		const prefix string = ",\"timestamp\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Timestamp))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BotMessageBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD77487eEncodeLiveChatHttp1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BotMessageBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD77487eEncodeLiveChatHttp1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BotMessageBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD77487eDecodeLiveChatHttp1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BotMessageBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD77487eDecodeLiveChatHttp1(l, v)
+}
+func easyjsonD77487eDecodeLiveChatHttp2(in *jlexer.Lexer, out *BotKeyBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "botId":
+			out.BotId = int64(in.Int64())
+		case "apiKey":
+			out.ApiKey = string(in.String())
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD77487eEncodeLiveChatHttp2(out *jwriter.Writer, in BotKeyBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"botId\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.BotId))
+	}
+	{
+		const prefix string = ",\"apiKey\":"
+		out.RawString(prefix)
+		out.String(string(in.ApiKey))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BotKeyBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD77487eEncodeLiveChatHttp2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BotKeyBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD77487eEncodeLiveChatHttp2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BotKeyBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD77487eDecodeLiveChatHttp2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BotKeyBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD77487eDecodeLiveChatHttp2(l, v)
+}
+func easyjsonD77487eDecodeLiveChatHttp3(in *jlexer.Lexer, out *BotInboxBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "events":
+			if in.IsNull() {
+				in.Skip()
+				out.Events = nil
+			} else {
+				in.Delim('[')
+				if out.Events == nil {
+					if !in.IsDelim(']') {
+						out.Events = make([]jsontext.Value, 0, 2)
+					} else {
+						out.Events = []jsontext.Value{}
+					}
+				} else {
+					out.Events = (out.Events)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 jsontext.Value
+					if data := in.Raw(); in.Ok() {
+						in.AddError((v4).UnmarshalJSON(data))
+					}
+					out.Events = append(out.Events, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD77487eEncodeLiveChatHttp3(out *jwriter.Writer, in BotInboxBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"events\":"
+		out.RawString(prefix[1:])
+		if in.Events == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Events {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.Raw((v6).MarshalJSON())
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BotInboxBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD77487eEncodeLiveChatHttp3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BotInboxBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD77487eEncodeLiveChatHttp3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BotInboxBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD77487eDecodeLiveChatHttp3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BotInboxBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD77487eDecodeLiveChatHttp3(l, v)
+}
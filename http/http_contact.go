@@ -0,0 +1,187 @@
+package http
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	contactGroupIdParam   = "contactGroupId"
+	contactGroupNameParam = "name"
+
+	contactGroupIdKey   = "contactGroupId"
+	contactGroupNameKey = "contactGroupName"
+	friendSettingKey    = "friendSetting"
+)
+
+const (
+	maxFriendRemarkLength     = 32
+	maxContactGroupNameLength = 32
+)
+
+type friendSettingForm struct {
+	Remark         string   `json:"remark"`
+	Tags           []string `json:"tags"`
+	ContactGroupId int64    `json:"contact_group_id"`
+}
+
+type ContactGroupBody struct {
+	ResponseHeader
+	ContactGroup entities.ContactGroup `json:"contactGroup"`
+}
+
+func getFriendSettingPostInBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	ginCtx := ctx.Ctx.(*gin.Context)
+
+	form := &friendSettingForm{}
+	err = ginCtx.BindJSON(form)
+	if err != nil {
+		retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "Json 表单解析错误")
+		return
+	}
+
+	var ok bool
+	form.Remark = strings.TrimSpace(form.Remark)
+	if utf8.RuneCountInString(form.Remark) > maxFriendRemarkLength {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "备注过长")
+		return
+	} else if form.Tags, ok = entities.NormalizeFriendTags(form.Tags); !ok {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "标签数量或长度超出限制")
+		return
+	} else if form.ContactGroupId < 0 {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "分组 id 不合法")
+		return
+	}
+
+	ctx.Param[friendSettingKey] = form
+	return
+}
+
+func getContactGroupIdFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	contactGroupId, retBuf, err := getInt64ParamFromURL(ctx, contactGroupIdParam, "缺少分组 id", LackOfParameter)
+	if len(retBuf) == 0 && err == nil {
+		ctx.Param[contactGroupIdKey] = contactGroupId
+	}
+	return
+}
+
+func getContactGroupNameFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	name, retBuf, err := getParamFromURL(ctx, contactGroupNameParam, "缺少分组名", LackOfParameter)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if name = strings.TrimSpace(name); name == "" {
+		retBuf, err = errorHandlerHook(LackOfParameter, "缺少分组名")
+		return
+	} else if utf8.RuneCountInString(name) > maxContactGroupNameLength {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "分组名过长")
+		return
+	}
+
+	ctx.Param[contactGroupNameKey] = name
+	return
+}
+
+func updateFriendSetting(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		friendId = ctx.Param[friendIdKey].(int64)
+		form     = ctx.Param[friendSettingKey].(*friendSettingForm)
+	)
+
+	err = db.UpdateFriendSetting(nil, userId, friendId, form.Remark, form.Tags, form.ContactGroupId)
+	if err == db.MysqlErrorUserNotExist {
+		retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "非好友关系")
+		return
+	} else if err == db.MysqlErrorContactGroupNotExist {
+		retBuf, err = errorHandlerHook(IllegalRequest, "分组不存在")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return sendContactSyncNotification(friendId, userId)
+}
+
+func createContactGroup(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		name   = ctx.Param[contactGroupNameKey].(string)
+	)
+
+	group := entities.NewContactGroup(userId, name)
+	if err = db.AddContactGroup(nil, group); err == db.MysqlErrorContactGroupExist {
+		retBuf, err = errorHandlerHook(IllegalRequest, "分组已存在")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	if retBuf, err = sendContactSyncNotification(userId, userId); len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	return (&ContactGroupBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		ContactGroup:   *group,
+	}).MarshalJSON()
+}
+
+func renameContactGroup(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId         = ctx.Param[userIdFromTokenKey].(int64)
+		contactGroupId = ctx.Param[contactGroupIdKey].(int64)
+		name           = ctx.Param[contactGroupNameKey].(string)
+	)
+
+	if err = db.RenameContactGroup(nil, userId, contactGroupId, name); err == db.MysqlErrorContactGroupNotExist {
+		retBuf, err = errorHandlerHook(IllegalRequest, "分组不存在")
+		return
+	} else if err == db.MysqlErrorContactGroupExist {
+		retBuf, err = errorHandlerHook(IllegalRequest, "分组已存在")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return sendContactSyncNotification(userId, userId)
+}
+
+func deleteContactGroup(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId         = ctx.Param[userIdFromTokenKey].(int64)
+		contactGroupId = ctx.Param[contactGroupIdKey].(int64)
+	)
+
+	if err = db.DeleteContactGroup(nil, userId, contactGroupId); err == db.MysqlErrorContactGroupNotExist {
+		retBuf, err = errorHandlerHook(IllegalRequest, "分组不存在")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return sendContactSyncNotification(userId, userId)
+}
+
+// sendContactSyncNotification 向用户自己下发联系人变更通知，用于在用户的多个设备间同步
+// 修改好友设置时 senderId 为好友 id，修改分组时为用户自己
+func sendContactSyncNotification(senderId, userId int64) (retBuf []byte, err error) {
+	noti := entities.NewNotification(senderId, userId, entities.Update, entities.Contact, true, true)
+	noti.HandleUserId = userId
+	if noti, err = db.AddAndReturnNotification(context.Background(), noti); err != nil {
+		return errorHandlerHook(InternalError, err.Error())
+	}
+
+	SendNotification(noti)
+	return
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"liveChat/log"
+	"liveChat/security"
+	nethttp "net/http"
+	"strings"
+)
+
+const (
+	corsAllowMethods = "GET, POST, OPTIONS"
+	// 浏览器只有在响应中声明了这些自定义请求头后才允许跨域携带
+	corsDefaultAllowHeaders = "Content-Type, " + tokenHeaderParam + ", " + operatorKeyHeaderParam + ", " + botKeyHeaderParam
+	corsMaxAge              = "600"
+)
+
+var originPolicy *security.OriginPolicy
+
+// InitOriginPolicy 设置 HTTP 接口允许的来源，需要在 InitHttpServer 之前调用
+func InitOriginPolicy(policy *security.OriginPolicy) {
+	originPolicy = policy
+}
+
+// originPolicyMiddleware 拒绝不在允许列表中的 Host 与 Origin，并为允许的跨域请求返回 CORS 响应头，
+// 预检请求在这里直接返回
+func originPolicyMiddleware(ctx *gin.Context) {
+	if originPolicy == nil {
+		ctx.Next()
+		return
+	}
+
+	origin := ctx.GetHeader("Origin")
+	err := originPolicy.CheckHost(ctx.Request.Host)
+	if err == nil {
+		err = originPolicy.CheckOrigin(origin)
+	}
+	if err != nil {
+		log.Error(fmt.Sprintf("拒绝来自 %s 的 HTTP 请求 %s: %s", ctx.ClientIP(), ctx.Request.URL.Path, err.Error()))
+		ctx.AbortWithStatus(nethttp.StatusForbidden)
+		return
+	}
+
+	if origin != "" {
+		header := ctx.Writer.Header()
+		header.Set("Access-Control-Allow-Origin", origin)
+		header.Add("Vary", "Origin")
+		header.Set("Access-Control-Allow-Methods", corsAllowMethods)
+		header.Set("Access-Control-Allow-Headers", corsDefaultAllowHeaders)
+		header.Set("Access-Control-Max-Age", corsMaxAge)
+	}
+
+	if ctx.Request.Method == nethttp.MethodOptions && origin != "" &&
+		strings.TrimSpace(ctx.GetHeader("Access-Control-Request-Method")) != "" {
+		ctx.AbortWithStatus(nethttp.StatusNoContent)
+		return
+	}
+	ctx.Next()
+}
@@ -0,0 +1,199 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"liveChat/log"
+	"strconv"
+)
+
+const (
+	deviceIdParam = "deviceId"
+
+	deviceIdKey      = "deviceId"
+	deviceKeyKey     = "deviceKey"
+	oneTimePreKeyKey = "oneTimePreKeys"
+)
+
+type PreKeyBundleBody struct {
+	ResponseHeader
+	UserId  int64                   `json:"userId"`
+	Bundles []entities.PreKeyBundle `json:"bundles"`
+}
+
+type OneTimePreKeyCountBody struct {
+	ResponseHeader
+	DeviceId int64 `json:"deviceId"`
+	Count    int64 `json:"count"`
+}
+
+type deviceKeyForm struct {
+	DeviceId              int64                    `json:"deviceId"`
+	IdentityKey           string                   `json:"identityKey"`
+	SignedPreKeyId        int64                    `json:"signedPreKeyId"`
+	SignedPreKey          string                   `json:"signedPreKey"`
+	SignedPreKeySignature string                   `json:"signedPreKeySignature"`
+	OneTimePreKeys        []entities.OneTimePreKey `json:"oneTimePreKeys"`
+}
+
+func getDeviceKeyPostInBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	form := &deviceKeyForm{}
+	if err = ctx.Ctx.(*gin.Context).BindJSON(form); err != nil {
+		retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "Json 表单解析错误")
+		return
+	} else if form.DeviceId <= 0 {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "设备 id 无效")
+		return
+	}
+
+	key := &entities.DeviceKey{
+		DeviceId:              form.DeviceId,
+		IdentityKey:           form.IdentityKey,
+		SignedPreKeyId:        form.SignedPreKeyId,
+		SignedPreKey:          form.SignedPreKey,
+		SignedPreKeySignature: form.SignedPreKeySignature,
+	}
+	if err = key.Validate(form.OneTimePreKeys); err != nil {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, err.Error())
+		return
+	}
+
+	ctx.Param[deviceKeyKey] = key
+	ctx.Param[oneTimePreKeyKey] = form.OneTimePreKeys
+	return
+}
+
+func getDeviceIdFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	deviceId, retBuf, err := getInt64ParamFromURL(ctx, deviceIdParam, "缺少设备 id", LackOfParameter)
+	if len(retBuf) == 0 && err == nil {
+		ctx.Param[deviceIdKey] = deviceId
+	}
+	return
+}
+
+func uploadDeviceKey(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId  = ctx.Param[userIdFromTokenKey].(int64)
+		key     = ctx.Param[deviceKeyKey].(*entities.DeviceKey)
+		preKeys = ctx.Param[oneTimePreKeyKey].([]entities.OneTimePreKey)
+	)
+
+	key.UserId = userId
+	isIdentityChanged, err := db.SaveDeviceKey(nil, key, preKeys)
+	if errors.Is(err, db.MysqlErrorDeviceKeyTooMany) {
+		retBuf, err = errorHandlerHook(IllegalRequest, err.Error())
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	if isIdentityChanged {
+		sendIdentityKeyNotification(userId, key.DeviceId)
+	}
+	return returnOneTimePreKeyCount(userId, key.DeviceId)
+}
+
+func removeDeviceKey(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		deviceId = ctx.Param[deviceIdKey].(int64)
+	)
+
+	if err = db.DeleteDeviceKey(nil, userId, deviceId); errors.Is(err, db.MysqlErrorDeviceKeyNotExist) {
+		retBuf, err = errorHandlerHook(IllegalRequest, err.Error())
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	sendIdentityKeyNotification(userId, deviceId)
+	return
+}
+
+func returnOneTimePreKeyCountBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	return returnOneTimePreKeyCount(ctx.Param[userIdFromTokenKey].(int64), ctx.Param[deviceIdKey].(int64))
+}
+
+func returnOneTimePreKeyCount(userId, deviceId int64) (retBuf []byte, err error) {
+	count, err := db.CountOneTimePreKeys(nil, userId, deviceId)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&OneTimePreKeyCountBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		DeviceId:       deviceId,
+		Count:          count,
+	}).MarshalJSON()
+}
+
+// claimPreKeyBundles 端到端加密只用于私聊，只有好友且未被对方拉黑时才能领取对方的密钥
+func claimPreKeyBundles(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		friendId = ctx.Param[friendIdKey].(int64)
+		flag     bool
+	)
+
+	if flag, err = controllers.CheckAreUsersFriend(userId, friendId, false); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	} else if !flag {
+		retBuf, err = errorHandlerHook(IllegalRequest, "与目标用户不是好友关系")
+		return
+	}
+
+	if flag, err = controllers.CheckIsUserBlocked(friendId, userId, false); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	} else if flag {
+		retBuf, err = errorHandlerHook(IllegalRequest, "已被目标用户加入黑名单")
+		return
+	}
+
+	bundles, err := db.ClaimPreKeyBundles(nil, friendId)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&PreKeyBundleBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		UserId:         friendId,
+		Bundles:        bundles,
+	}).MarshalJSON()
+}
+
+// sendIdentityKeyNotification 向用户的全部好友及用户自己的其他设备下发身份公钥变更通知，
+// 通知的 Message 为发生变更的设备 id。密钥已经保存，通知失败只记录日志
+func sendIdentityKeyNotification(userId, deviceId int64) {
+	friendships, err := db.SelectFriendShip(nil, userId)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+
+	receivers := make([]int64, 0, len(friendships)+1)
+	receivers = append(receivers, userId)
+	for _, friendship := range friendships {
+		receivers = append(receivers, friendship.FriendId)
+	}
+
+	for _, receiver := range receivers {
+		noti := entities.NewNotification(userId, receiver, entities.Update, entities.IdentityKey, true, true)
+		noti.HandleUserId = userId
+		noti.Message = strconv.FormatInt(deviceId, 10)
+		if noti, err = db.AddAndReturnNotification(context.Background(), noti); err != nil {
+			log.Error(err.Error())
+			continue
+		}
+		SendNotification(noti)
+	}
+}
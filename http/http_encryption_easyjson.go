@@ -0,0 +1,497 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	entities "liveChat/entities"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson85d15a7aDecodeLiveChatHttp(in *jlexer.Lexer, out *deviceKeyForm) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "deviceId":
+			out.DeviceId = int64(in.Int64())
+		case "identityKey":
+			out.IdentityKey = string(in.String())
+		case "signedPreKeyId":
+			out.SignedPreKeyId = int64(in.Int64())
+		case "signedPreKey":
+			out.SignedPreKey = string(in.String())
+		case "signedPreKeySignature":
+			out.SignedPreKeySignature = string(in.String())
+		case "oneTimePreKeys":
+			if in.IsNull() {
+				in.Skip()
+				out.OneTimePreKeys = nil
+			} else {
+				in.Delim('[')
+				if out.OneTimePreKeys == nil {
+					if !in.IsDelim(']') {
+						out.OneTimePreKeys = make([]entities.OneTimePreKey, 0, 1)
+					} else {
+						out.OneTimePreKeys = []entities.OneTimePreKey{}
+					}
+				} else {
+					out.OneTimePreKeys = (out.OneTimePreKeys)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 entities.OneTimePreKey
+					easyjson85d15a7aDecodeLiveChatEntities(in, &v1)
+					out.OneTimePreKeys = append(out.OneTimePreKeys, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85d15a7aEncodeLiveChatHttp(out *jwriter.Writer, in deviceKeyForm) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"deviceId\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.DeviceId))
+	}
+	{
+		const prefix string = ",\"identityKey\":"
+		out.RawString(prefix)
+		out.String(string(in.IdentityKey))
+	}
+	{
+		const prefix string = ",\"signedPreKeyId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.SignedPreKeyId))
+	}
+	{
+		const prefix string = ",\"signedPreKey\":"
+		out.RawString(prefix)
+		out.String(string(in.SignedPreKey))
+	}
+	{
+		const prefix string = ",\"signedPreKeySignature\":"
+		out.RawString(prefix)
+		out.String(string(in.SignedPreKeySignature))
+	}
+	{
+		const prefix string = ",\"oneTimePreKeys\":"
+		out.RawString(prefix)
+		if in.OneTimePreKeys == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.OneTimePreKeys {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				easyjson85d15a7aEncodeLiveChatEntities(out, v3)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v deviceKeyForm) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85d15a7aEncodeLiveChatHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v deviceKeyForm) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85d15a7aEncodeLiveChatHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *deviceKeyForm) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85d15a7aDecodeLiveChatHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *deviceKeyForm) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85d15a7aDecodeLiveChatHttp(l, v)
+}
+func easyjson85d15a7aDecodeLiveChatEntities(in *jlexer.Lexer, out *entities.OneTimePreKey) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "keyId":
+			out.KeyId = int64(in.Int64())
+		case "publicKey":
+			out.PublicKey = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85d15a7aEncodeLiveChatEntities(out *jwriter.Writer, in entities.OneTimePreKey) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"keyId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.KeyId))
+	}
+	{
+		const prefix string = ",\"publicKey\":"
+		out.RawString(prefix)
+		out.String(string(in.PublicKey))
+	}
+	out.RawByte('}')
+}
+func easyjson85d15a7aDecodeLiveChatHttp1(in *jlexer.Lexer, out *PreKeyBundleBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "userId":
+			out.UserId = int64(in.Int64())
+		case "bundles":
+			if in.IsNull() {
+				in.Skip()
+				out.Bundles = nil
+			} else {
+				in.Delim('[')
+				if out.Bundles == nil {
+					if !in.IsDelim(']') {
+						out.Bundles = make([]entities.PreKeyBundle, 0, 0)
+					} else {
+						out.Bundles = []entities.PreKeyBundle{}
+					}
+				} else {
+					out.Bundles = (out.Bundles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 entities.PreKeyBundle
+					easyjson85d15a7aDecodeLiveChatEntities1(in, &v4)
+					out.Bundles = append(out.Bundles, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85d15a7aEncodeLiveChatHttp1(out *jwriter.Writer, in PreKeyBundleBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"userId\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.UserId))
+	}
+	{
+		const prefix string = ",\"bundles\":"
+		out.RawString(prefix)
+		if in.Bundles == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Bundles {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				easyjson85d15a7aEncodeLiveChatEntities1(out, v6)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PreKeyBundleBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85d15a7aEncodeLiveChatHttp1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PreKeyBundleBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85d15a7aEncodeLiveChatHttp1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PreKeyBundleBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85d15a7aDecodeLiveChatHttp1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PreKeyBundleBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85d15a7aDecodeLiveChatHttp1(l, v)
+}
+func easyjson85d15a7aDecodeLiveChatEntities1(in *jlexer.Lexer, out *entities.PreKeyBundle) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "deviceId":
+			out.DeviceId = int64(in.Int64())
+		case "identityKey":
+			out.IdentityKey = string(in.String())
+		case "signedPreKeyId":
+			out.SignedPreKeyId = int64(in.Int64())
+		case "signedPreKey":
+			out.SignedPreKey = string(in.String())
+		case "signedPreKeySignature":
+			out.SignedPreKeySignature = string(in.String())
+		case "oneTimePreKeyId":
+			out.OneTimePreKeyId = int64(in.Int64())
+		case "oneTimePreKey":
+			out.OneTimePreKey = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85d15a7aEncodeLiveChatEntities1(out *jwriter.Writer, in entities.PreKeyBundle) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"deviceId\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.DeviceId))
+	}
+	{
+		const prefix string = ",\"identityKey\":"
+		out.RawString(prefix)
+		out.String(string(in.IdentityKey))
+	}
+	{
+		const prefix string = ",\"signedPreKeyId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.SignedPreKeyId))
+	}
+	{
+		const prefix string = ",\"signedPreKey\":"
+		out.RawString(prefix)
+		out.String(string(in.SignedPreKey))
+	}
+	{
+		const prefix string = ",\"signedPreKeySignature\":"
+		out.RawString(prefix)
+		out.String(string(in.SignedPreKeySignature))
+	}
+	if in.OneTimePreKeyId != 0 {
+		const prefix string = ",\"oneTimePreKeyId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.OneTimePreKeyId))
+	}
+	if in.OneTimePreKey != "" {
+		const prefix string = ",\"oneTimePreKey\":"
+		out.RawString(prefix)
+		out.String(string(in.OneTimePreKey))
+	}
+	out.RawByte('}')
+}
+func easyjson85d15a7aDecodeLiveChatHttp2(in *jlexer.Lexer, out *OneTimePreKeyCountBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "deviceId":
+			out.DeviceId = int64(in.Int64())
+		case "count":
+			out.Count = int64(in.Int64())
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson85d15a7aEncodeLiveChatHttp2(out *jwriter.Writer, in OneTimePreKeyCountBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"deviceId\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.DeviceId))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Count))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OneTimePreKeyCountBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson85d15a7aEncodeLiveChatHttp2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OneTimePreKeyCountBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson85d15a7aEncodeLiveChatHttp2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OneTimePreKeyCountBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson85d15a7aDecodeLiveChatHttp2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OneTimePreKeyCountBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson85d15a7aDecodeLiveChatHttp2(l, v)
+}
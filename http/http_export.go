@@ -0,0 +1,255 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"liveChat/export"
+	"liveChat/tools"
+	nethttp "net/http"
+)
+
+const (
+	exportFormatParam = "format"
+	exportJobIdParam  = "jobId"
+
+	exportFormatKey = "exportFormat"
+	exportJobIdKey  = "exportJobId"
+	exportJobKey    = "exportJob"
+)
+
+type ExportJobInfo struct {
+	entities.ExportJob
+	Progress    int64  `json:"progress"`
+	DownloadUrl string `json:"downloadUrl,omitempty"`
+}
+
+type ExportJobBody struct {
+	ResponseHeader
+	Job ExportJobInfo `json:"job"`
+}
+
+type ExportJobListBody struct {
+	ResponseHeader
+	Jobs []ExportJobInfo `json:"jobs"`
+}
+
+func newExportJobInfo(job *entities.ExportJob) ExportJobInfo {
+	info := ExportJobInfo{ExportJob: *job, Progress: job.Progress()}
+	if job.Status == entities.ExportDone {
+		if job.IsByOperator {
+			info.DownloadUrl = fmt.Sprintf("%s?%s=%d", operatorDownloadExportRoute, exportJobIdParam, job.Id)
+		} else {
+			info.DownloadUrl = fmt.Sprintf("%s?%s=%d", downloadExportRoute, exportJobIdParam, job.Id)
+		}
+	}
+	return info
+}
+
+// exportPostHandler 导出文件已经由处理函数写入响应，只有出错时才需要返回 json
+func exportPostHandler(ctx *controllers.ProcessContext, retBuf []byte, err error) {
+	if err != nil || len(retBuf) != 0 {
+		postHandler(ctx, retBuf, err)
+	}
+}
+
+func getExportFormatFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	format := ctx.Ctx.(*gin.Context).DefaultQuery(exportFormatParam, entities.ExportFormatJsonLines)
+	if !entities.IsValidExportFormat(format) {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "导出格式无效")
+		return
+	}
+
+	ctx.Param[exportFormatKey] = format
+	return
+}
+
+func getExportJobIdFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	id, retBuf, err := getInt64ParamFromURL(ctx, exportJobIdParam, "缺少导出任务 id", LackOfParameter)
+	if len(retBuf) == 0 && err == nil {
+		ctx.Param[exportJobIdKey] = id
+	}
+	return
+}
+
+// checkExportChatAuth 用户只能导出自己所在的群聊或与好友的私聊
+func checkExportChatAuth(userId, chatId int64) (retBuf []byte, err error) {
+	var flag bool
+	if chatId == 0 || chatId == userId {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "会话 id 无效")
+		return
+	} else if chatId < 0 {
+		flag, err = controllers.CheckIsUserInGroup(userId, chatId, false)
+	} else {
+		flag, err = controllers.CheckAreUsersFriend(userId, chatId, false)
+	}
+
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	} else if !flag {
+		retBuf, err = errorHandlerHook(IllegalRequest, "用户不在该会话中")
+	}
+	return
+}
+
+func submitExportJob(job *entities.ExportJob) (retBuf []byte, err error) {
+	if err = db.AddExportJob(nil, job); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	export.Submit()
+
+	return (&ExportJobBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Job:            newExportJobInfo(job),
+	}).MarshalJSON()
+}
+
+func createChatExportJob(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		chatId = ctx.Param[chatIdKey].(int64)
+		format = ctx.Param[exportFormatKey].(string)
+	)
+
+	if retBuf, err = checkExportChatAuth(userId, chatId); len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	return submitExportJob(entities.NewExportJob(tools.GenerateSnowflakeId(false), userId,
+		entities.ExportChat, chatId, format, false))
+}
+
+func createAccountExportJob(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		format = ctx.Param[exportFormatKey].(string)
+	)
+
+	return submitExportJob(entities.NewExportJob(tools.GenerateSnowflakeId(false), userId,
+		entities.ExportAccount, 0, format, false))
+}
+
+// createOperatorChatExportJob 运维接口可以导出任意用户所在的会话，任务归属于该用户但仅运维接口可见
+func createOperatorChatExportJob(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdKey].(int64)
+		chatId = ctx.Param[chatIdKey].(int64)
+		format = ctx.Param[exportFormatKey].(string)
+	)
+
+	if retBuf, err = checkExportChatAuth(userId, chatId); len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	return submitExportJob(entities.NewExportJob(tools.GenerateSnowflakeId(false), userId,
+		entities.ExportChat, chatId, format, true))
+}
+
+func loadExportJob(ctx *controllers.ProcessContext) (job *entities.ExportJob, retBuf []byte, err error) {
+	job, err = db.GetExportJob(nil, ctx.Param[exportJobIdKey].(int64))
+	if errors.Is(err, db.MysqlErrorExportJobNotExist) {
+		retBuf, err = errorHandlerHook(IllegalRequest, "导出任务不存在")
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
+
+// getOwnExportJob 用户只能查看自己发起的导出任务
+func getOwnExportJob(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	job, retBuf, err := loadExportJob(ctx)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if job.IsByOperator || job.UserId != ctx.Param[userIdFromTokenKey].(int64) {
+		retBuf, err = errorHandlerHook(IllegalRequest, "导出任务不存在")
+		return
+	}
+
+	ctx.Param[exportJobKey] = job
+	return
+}
+
+func getOperatorExportJob(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	job, retBuf, err := loadExportJob(ctx)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if !job.IsByOperator {
+		retBuf, err = errorHandlerHook(IllegalRequest, "导出任务不存在")
+		return
+	}
+
+	ctx.Param[exportJobKey] = job
+	return
+}
+
+func returnExportJobBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	job := ctx.Param[exportJobKey].(*entities.ExportJob)
+
+	return (&ExportJobBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Job:            newExportJobInfo(job),
+	}).MarshalJSON()
+}
+
+func returnExportJobListBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	userId := ctx.Param[userIdFromTokenKey].(int64)
+
+	jobs, err := db.SelectExportJobs(nil, userId)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	infos := make([]ExportJobInfo, 0, len(jobs))
+	for i := range jobs {
+		infos = append(infos, newExportJobInfo(&jobs[i]))
+	}
+
+	return (&ExportJobListBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Jobs:           infos,
+	}).MarshalJSON()
+}
+
+func downloadExportFile(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	job := ctx.Param[exportJobKey].(*entities.ExportJob)
+
+	if job.Status == entities.ExportExpired {
+		retBuf, err = errorHandlerHook(IllegalRequest, "导出文件已过期")
+		return
+	} else if job.Status != entities.ExportDone {
+		retBuf, err = errorHandlerHook(IllegalRequest, "导出任务尚未完成")
+		return
+	}
+
+	var name string
+	if job.Type == entities.ExportAccount {
+		name = fmt.Sprintf("account_%d.%s", job.UserId, job.Format)
+	} else {
+		name = fmt.Sprintf("chat_%d_%d.%s", job.UserId, job.ChatId, job.Format)
+	}
+
+	file, size, err := export.Open(job)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+	defer file.Close()
+
+	contentType := "application/x-ndjson"
+	if job.Format == entities.ExportFormatHtml {
+		contentType = "text/html; charset=utf-8"
+	}
+	ctx.Ctx.(*gin.Context).DataFromReader(nethttp.StatusOK, size, contentType, file,
+		map[string]string{"Content-Disposition": fmt.Sprintf(`attachment; filename="%s"`, name)})
+	return
+}
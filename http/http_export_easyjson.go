@@ -0,0 +1,368 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson18bbea17DecodeLiveChatHttp(in *jlexer.Lexer, out *ExportJobListBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "jobs":
+			if in.IsNull() {
+				in.Skip()
+				out.Jobs = nil
+			} else {
+				in.Delim('[')
+				if out.Jobs == nil {
+					if !in.IsDelim(']') {
+						out.Jobs = make([]ExportJobInfo, 0, 0)
+					} else {
+						out.Jobs = []ExportJobInfo{}
+					}
+				} else {
+					out.Jobs = (out.Jobs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 ExportJobInfo
+					(v1).UnmarshalEasyJSON(in)
+					out.Jobs = append(out.Jobs, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson18bbea17EncodeLiveChatHttp(out *jwriter.Writer, in ExportJobListBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"jobs\":"
+		out.RawString(prefix[1:])
+		if in.Jobs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Jobs {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ExportJobListBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson18bbea17EncodeLiveChatHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ExportJobListBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson18bbea17EncodeLiveChatHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ExportJobListBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson18bbea17DecodeLiveChatHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ExportJobListBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson18bbea17DecodeLiveChatHttp(l, v)
+}
+func easyjson18bbea17DecodeLiveChatHttp1(in *jlexer.Lexer, out *ExportJobInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "progress":
+			out.Progress = int64(in.Int64())
+		case "downloadUrl":
+			out.DownloadUrl = string(in.String())
+		case "id":
+			out.Id = int64(in.Int64())
+		case "userId":
+			out.UserId = int64(in.Int64())
+		case "isByOperator":
+			out.IsByOperator = bool(in.Bool())
+		case "type":
+			out.Type = uint8(in.Uint8())
+		case "chatId":
+			out.ChatId = int64(in.Int64())
+		case "format":
+			out.Format = string(in.String())
+		case "status":
+			out.Status = uint8(in.Uint8())
+		case "processed":
+			out.Processed = int64(in.Int64())
+		case "total":
+			out.Total = int64(in.Int64())
+		case "error":
+			out.Error = string(in.String())
+		case "createdAt":
+			out.CreatedAt = int64(in.Int64())
+		case "finishedAt":
+			out.FinishedAt = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson18bbea17EncodeLiveChatHttp1(out *jwriter.Writer, in ExportJobInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"progress\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Progress))
+	}
+	if in.DownloadUrl != "" {
+		const prefix string = ",\"downloadUrl\":"
+		out.RawString(prefix)
+		out.String(string(in.DownloadUrl))
+	}
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Id))
+	}
+	{
+		const prefix string = ",\"userId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.UserId))
+	}
+	{
+		const prefix string = ",\"isByOperator\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsByOperator))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.Type))
+	}
+	{
+		const prefix string = ",\"chatId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ChatId))
+	}
+	{
+		const prefix string = ",\"format\":"
+		out.RawString(prefix)
+		out.String(string(in.Format))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.Status))
+	}
+	{
+		const prefix string = ",\"processed\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Processed))
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Total))
+	}
+	{
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
+	{
+		const prefix string = ",\"createdAt\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CreatedAt))
+	}
+	{
+		const prefix string = ",\"finishedAt\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.FinishedAt))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ExportJobInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson18bbea17EncodeLiveChatHttp1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ExportJobInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson18bbea17EncodeLiveChatHttp1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ExportJobInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson18bbea17DecodeLiveChatHttp1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ExportJobInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson18bbea17DecodeLiveChatHttp1(l, v)
+}
+func easyjson18bbea17DecodeLiveChatHttp2(in *jlexer.Lexer, out *ExportJobBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "job":
+			(out.Job).UnmarshalEasyJSON(in)
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson18bbea17EncodeLiveChatHttp2(out *jwriter.Writer, in ExportJobBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"job\":"
+		out.RawString(prefix[1:])
+		(in.Job).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ExportJobBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson18bbea17EncodeLiveChatHttp2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ExportJobBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson18bbea17EncodeLiveChatHttp2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ExportJobBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson18bbea17DecodeLiveChatHttp2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ExportJobBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson18bbea17DecodeLiveChatHttp2(l, v)
+}
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"time"
+)
+
+const (
+	receiverParam = "receiver"
+
+	receiverKey = "receiver"
+)
+
+type PinnedMessageListBody struct {
+	ResponseHeader
+	PinnedMessages []entities.PinnedMessage `json:"pinnedMessages"`
+}
+
+// getReceiverFromUrl 获取私聊消息的接收者，私聊消息按接收者存放，与 seq 一起定位一条消息
+func getReceiverFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	receiver, retBuf, err := getInt64ParamFromURL(ctx, receiverParam, "缺少消息的接收者", LackOfParameter)
+	if len(retBuf) == 0 && err == nil {
+		ctx.Param[receiverKey] = receiver
+	}
+	return
+}
+
+// checkDirectChat 私聊双方必须是好友，消息的接收者必须是双方之一
+func checkDirectChat(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		friendId = ctx.Param[friendIdKey].(int64)
+	)
+
+	if receiver, ok := ctx.Param[receiverKey].(int64); ok && receiver != userId && receiver != friendId {
+		retBuf, err = errorHandlerHook(IllegalRequest, "消息不属于该私聊")
+		return
+	}
+
+	flag, err := controllers.CheckAreUsersFriend(userId, friendId, false)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	} else if !flag {
+		retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "非好友关系")
+	}
+	return
+}
+
+// pinDirectMessage 私聊的双方都可以置顶消息，置顶后通知对方
+func pinDirectMessage(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		friendId = ctx.Param[friendIdKey].(int64)
+		receiver = ctx.Param[receiverKey].(int64)
+		seq      = ctx.Param[seqKey].(uint64)
+		peerId   = directChatPeer(userId, friendId, receiver)
+	)
+
+	return notifyFriend(userId, friendId, entities.Pin, func(mongoTx mongo.SessionContext) ([]byte, error) {
+		if message, err := db.GetMessageInSeq(mongoTx, receiver, seq); err == mongo.ErrNoDocuments || (err == nil && message.Sender != peerId) {
+			return errorHandlerHook(IllegalRequest, "消息不存在")
+		} else if err != nil {
+			return errorHandlerHook(InternalError, err.Error())
+		}
+
+		pinned := entities.NewPinnedMessage(receiver, seq, userId, time.Now().UnixMilli())
+		pinned.PeerId = peerId
+		if err := db.AddPinnedMessage(mongoTx, pinned); err == db.MongoErrorMessagePinned {
+			return errorHandlerHook(IllegalRequest, "消息已置顶")
+		} else if err != nil {
+			return errorHandlerHook(InternalError, err.Error())
+		}
+		return nil, nil
+	})
+}
+
+func unpinDirectMessage(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		friendId = ctx.Param[friendIdKey].(int64)
+		receiver = ctx.Param[receiverKey].(int64)
+		seq      = ctx.Param[seqKey].(uint64)
+	)
+
+	return notifyFriend(userId, friendId, entities.Unpin, func(mongoTx mongo.SessionContext) ([]byte, error) {
+		err := db.DeleteDirectPinnedMessage(mongoTx, receiver, seq, directChatPeer(userId, friendId, receiver))
+		if err == db.MongoErrorNoPinned {
+			return errorHandlerHook(IllegalRequest, "消息未置顶")
+		} else if err != nil {
+			return errorHandlerHook(InternalError, err.Error())
+		}
+		return nil, nil
+	})
+}
+
+func returnDirectPinnedMessagesBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		friendId = ctx.Param[friendIdKey].(int64)
+	)
+
+	pinned, err := db.GetDirectPinnedMessages(context.Background(), userId, friendId)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&PinnedMessageListBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		PinnedMessages: pinned,
+	}).MarshalJSON()
+}
+
+// directChatPeer 返回私聊消息的发送者，即接收者之外的另一方
+func directChatPeer(userId, friendId, receiver int64) int64 {
+	if receiver == userId {
+		return friendId
+	}
+	return userId
+}
+
+// notifyFriend 在事务中执行 op 并写入发给好友的通知，事务提交后下发通知
+func notifyFriend(userId, friendId int64, opType byte, op func(mongoTx mongo.SessionContext) ([]byte, error)) (retBuf []byte, err error) {
+	noti := entities.NewNotification(userId, friendId, opType, entities.Friend, true, true)
+	noti.HandleUserId = userId
+
+	if db.StartDbTransaction(func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) error {
+		if retBuf, err = op(mongoTx); len(retBuf) != 0 || err != nil {
+			return errorAbortTransaction
+		}
+
+		if _, err = db.AddAndReturnNotification(mongoTx, noti); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return errorAbortTransaction
+		}
+		return nil
+	}) != nil {
+		return
+	}
+
+	SendNotification(noti)
+	return
+}
@@ -0,0 +1,202 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	entities "liveChat/entities"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson384e1235DecodeLiveChatHttp(in *jlexer.Lexer, out *PinnedMessageListBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "pinnedMessages":
+			if in.IsNull() {
+				in.Skip()
+				out.PinnedMessages = nil
+			} else {
+				in.Delim('[')
+				if out.PinnedMessages == nil {
+					if !in.IsDelim(']') {
+						out.PinnedMessages = make([]entities.PinnedMessage, 0, 1)
+					} else {
+						out.PinnedMessages = []entities.PinnedMessage{}
+					}
+				} else {
+					out.PinnedMessages = (out.PinnedMessages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 entities.PinnedMessage
+					easyjson384e1235DecodeLiveChatEntities(in, &v1)
+					out.PinnedMessages = append(out.PinnedMessages, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson384e1235EncodeLiveChatHttp(out *jwriter.Writer, in PinnedMessageListBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"pinnedMessages\":"
+		out.RawString(prefix[1:])
+		if in.PinnedMessages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.PinnedMessages {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				easyjson384e1235EncodeLiveChatEntities(out, v3)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PinnedMessageListBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson384e1235EncodeLiveChatHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PinnedMessageListBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson384e1235EncodeLiveChatHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PinnedMessageListBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson384e1235DecodeLiveChatHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PinnedMessageListBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson384e1235DecodeLiveChatHttp(l, v)
+}
+func easyjson384e1235DecodeLiveChatEntities(in *jlexer.Lexer, out *entities.PinnedMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "chatId":
+			out.ChatId = int64(in.Int64())
+		case "seq":
+			out.Seq = uint64(in.Uint64())
+		case "peerId":
+			out.PeerId = int64(in.Int64())
+		case "pinnedBy":
+			out.PinnedBy = int64(in.Int64())
+		case "timestamp":
+			out.Timestamp = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson384e1235EncodeLiveChatEntities(out *jwriter.Writer, in entities.PinnedMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"chatId\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ChatId))
+	}
+	{
+		const prefix string = ",\"seq\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Seq))
+	}
+	if in.PeerId != 0 {
+		const prefix string = ",\"peerId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.PeerId))
+	}
+	{
+		const prefix string = ",\"pinnedBy\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.PinnedBy))
+	}
+	{
+		const prefix string = ",\"timestamp\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Timestamp))
+	}
+	out.RawByte('}')
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"unicode/utf8"
+)
+
+const announcementFormKey = "announcementForm"
+
+const (
+	maxAnnouncementTitleLength = 64
+	maxAnnouncementBodyLength  = 4096
+)
+
+type announcementForm struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+func getAnnouncementPostInBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	ginCtx := ctx.Ctx.(*gin.Context)
+
+	form := &announcementForm{}
+	err = ginCtx.BindJSON(form)
+	if err != nil {
+		retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "Json 表单解析错误")
+		return
+	}
+
+	if form.Title == "" {
+		retBuf, err = errorHandlerHook(LackOfParameter, "缺少公告标题")
+		return
+	} else if utf8.RuneCountInString(form.Title) > maxAnnouncementTitleLength {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "公告标题过长")
+		return
+	} else if utf8.RuneCountInString(form.Body) > maxAnnouncementBodyLength {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "公告内容过长")
+		return
+	}
+
+	ctx.Param[announcementFormKey] = form
+	return
+}
+
+func publishAnnouncement(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId  = ctx.Param[userIdFromTokenKey].(int64)
+		groupId = ctx.Param[groupIdKey].(int64)
+		form    = ctx.Param[announcementFormKey].(*announcementForm)
+	)
+
+	if !hasGroupPermission(ctx, entities.PermissionEditGroupInfo) {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+		return
+	}
+
+	return notifyGroupMembers(groupId, userId, entities.Announce, func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) ([]byte, error) {
+		if err := db.AddGroupAnnouncement(mysqlTx, entities.NewGroupAnnouncement(groupId, userId, form.Title, form.Body)); err != nil {
+			return errorHandlerHook(InternalError, err.Error())
+		}
+		return nil, nil
+	})
+}
+
+// acknowledgeAnnouncement 确认群组当前的公告
+func acknowledgeAnnouncement(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId  = ctx.Param[userIdFromTokenKey].(int64)
+		groupId = ctx.Param[groupIdKey].(int64)
+		isIn    = ctx.Param[groupMemberIsInKey].(bool)
+	)
+
+	if !isIn {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "用户不在群组中")
+		return
+	}
+
+	announcement, err := db.GetLatestGroupAnnouncement(nil, groupId)
+	if err == db.MysqlErrorNoLine {
+		retBuf, err = errorHandlerHook(IllegalRequest, "群组没有公告")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	if err = db.AcknowledgeGroupAnnouncement(nil, announcement.Id, userId); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
+
+// notifyGroupMembers 在事务中执行 op 并写入下发给全体成员的群组通知，事务提交后下发通知
+// op 返回错误回包时整个事务回滚
+func notifyGroupMembers(groupId, operatorId int64, opType byte,
+	op func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) ([]byte, error)) (retBuf []byte, err error) {
+	noti := entities.NewNotification(groupId, groupId, opType, entities.Group, true, true)
+	noti.HandleUserId = operatorId
+
+	if db.StartDbTransaction(func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) error {
+		if retBuf, err = op(mysqlTx, mongoTx); len(retBuf) != 0 || err != nil {
+			return errorAbortTransaction
+		}
+
+		if _, err = db.AddAndReturnNotification(mongoTx, noti); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return errorAbortTransaction
+		}
+		return nil
+	}) != nil {
+		return
+	}
+
+	SendNotification(noti)
+	return
+}
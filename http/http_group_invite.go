@@ -0,0 +1,397 @@
+package http
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"time"
+)
+
+const (
+	joinPolicyParam      = "policy"
+	inviteCodeParam      = "code"
+	inviteMaxUsesParam   = "maxUses"
+	inviteExpiresInParam = "expiresIn"
+
+	joinPolicyKey      = "joinPolicy"
+	inviteCodeKey      = "inviteCode"
+	inviteMaxUsesKey   = "inviteMaxUses"
+	inviteExpiresInKey = "inviteExpiresIn"
+)
+
+const (
+	defaultInviteExpiresInSecond = 7 * 24 * 60 * 60
+	maxInviteExpiresInSecond     = 30 * 24 * 60 * 60
+)
+
+type GroupInviteBody struct {
+	ResponseHeader
+	Invite entities.GroupInvite `json:"invite"`
+}
+
+type GroupInviteListBody struct {
+	ResponseHeader
+	Invites []entities.GroupInvite `json:"invites"`
+}
+
+func getJoinPolicyFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	policy, retBuf, err := getInt64ParamFromURL(ctx, joinPolicyParam, "缺少加入规则", LackOfParameter)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if policy < 0 || !entities.IsValidJoinPolicy(byte(policy)) {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "加入规则不合法")
+		return
+	}
+
+	ctx.Param[joinPolicyKey] = byte(policy)
+	return
+}
+
+func getInviteCodeFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	code, retBuf, err := getParamFromURL(ctx, inviteCodeParam, "缺少邀请码", LackOfParameter)
+	if len(retBuf) == 0 && err == nil {
+		ctx.Param[inviteCodeKey] = code
+	}
+	return
+}
+
+func getInviteLimitFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	maxUses, retBuf, err := getOptionalInt64ParamFromURL(ctx, inviteMaxUsesParam, 0)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	expiresIn, retBuf, err := getOptionalInt64ParamFromURL(ctx, inviteExpiresInParam, defaultInviteExpiresInSecond)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if maxUses < 0 {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "使用次数不合法")
+		return
+	} else if expiresIn <= 0 || expiresIn > maxInviteExpiresInSecond {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "有效期超出范围")
+		return
+	}
+
+	ctx.Param[inviteMaxUsesKey] = maxUses
+	ctx.Param[inviteExpiresInKey] = expiresIn
+	return
+}
+
+func updateJoinPolicy(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		groupId = ctx.Param[groupIdKey].(int64)
+		policy  = ctx.Param[joinPolicyKey].(byte)
+	)
+
+	if !hasGroupPermission(ctx, entities.PermissionEditGroupInfo) {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+		return
+	}
+
+	if err = db.UpdateGroupJoinPolicy(nil, groupId, policy); err == db.MysqlErrorGroupNotExist {
+		retBuf, err = errorHandlerHook(GroupNotFound, "群组不存在")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	if err = controllers.RefreshGroupInfoCache(groupId); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
+
+// inviteMember 邀请好友加入群组，邀请通知中 SenderId 为群组 id，HandleUserId 为邀请者
+func inviteMember(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		groupId  = ctx.Param[groupIdKey].(int64)
+		friendId = ctx.Param[friendIdKey].(int64)
+		info     = ctx.Param[groupInfoKey].(*entities.GroupInfo)
+		isIn     = ctx.Param[groupMemberIsInKey].(bool)
+	)
+
+	if !isIn {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "用户不在群组中")
+		return
+	} else if info.JoinActionFor(userId) == entities.JoinRejected {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "群组当前不允许加入")
+		return
+	}
+
+	if _, isFriendIn := info.PermissionsOf(friendId); isFriendIn {
+		retBuf, err = errorHandlerHook(IllegalRequest, "用户已在群组中")
+		return
+	}
+
+	flag, err := controllers.CheckAreUsersFriend(userId, friendId, false)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	} else if !flag {
+		retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "非好友关系")
+		return
+	}
+
+	noti := entities.NewNotification(groupId, friendId, entities.Add, entities.Invitation, false, false)
+	noti.HandleUserId = userId
+	if noti, err = db.AddOrRefreshPendingNotification(context.Background(), noti); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	SendNotification(noti)
+	return
+}
+
+func acceptInvitation(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	return handleInvitation(ctx, true)
+}
+
+func refuseInvitation(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	return handleInvitation(ctx, false)
+}
+
+func handleInvitation(ctx *controllers.ProcessContext, isAgree bool) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		seq    = ctx.Param[notificationSeqKey].(uint64)
+	)
+
+	invitation, err := db.GetNotificationInSeq(context.Background(), userId, seq)
+	if err != nil || invitation == nil || invitation.ReceiveType != entities.Invitation {
+		retBuf, err = errorHandlerHook(IllegalRequest, "无相关通知")
+		return
+	} else if invitation.IsExpired {
+		retBuf, err = errorHandlerHook(IllegalRequest, "邀请已过期")
+		return
+	} else if invitation.IsHandled {
+		retBuf, err = errorHandlerHook(IllegalRequest, "无相关通知")
+		return
+	}
+
+	var (
+		groupId   = invitation.SenderId
+		inviterId = invitation.HandleUserId
+	)
+
+	markHandled := func(ctx context.Context) ([]byte, error) {
+		if _, err := db.HandleNotification(ctx, userId, userId, seq, isAgree); err == db.MongoErrorNoNotification {
+			return errorHandlerHook(IllegalRequest, "无相关通知")
+		} else if err == db.MongoErrorNotificationExpired {
+			return errorHandlerHook(IllegalRequest, "邀请已过期")
+		} else if err != nil {
+			return errorHandlerHook(InternalError, err.Error())
+		}
+		return nil, nil
+	}
+
+	if !isAgree {
+		return markHandled(context.Background())
+	}
+
+	info, err := db.SearchGroupInfo(nil, groupId, true)
+	if err == db.MysqlErrorGroupNotExist {
+		return errorHandlerHook(GroupNotFound, "群组不存在")
+	} else if err != nil {
+		return errorHandlerHook(InternalError, err.Error())
+	} else if info.IsDeleted {
+		return errorHandlerHook(GroupNotFound, "群组已解散")
+	}
+
+	if _, isIn := info.PermissionsOf(userId); isIn {
+		return errorHandlerHook(IllegalRequest, "用户已在群组中")
+	}
+
+	return joinGroupByPolicy(info.JoinActionFor(inviterId), userId, groupId, inviterId, "",
+		func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) ([]byte, error) {
+			return markHandled(mongoTx)
+		})
+}
+
+func createInviteLink(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId    = ctx.Param[userIdFromTokenKey].(int64)
+		groupId   = ctx.Param[groupIdKey].(int64)
+		info      = ctx.Param[groupInfoKey].(*entities.GroupInfo)
+		maxUses   = ctx.Param[inviteMaxUsesKey].(int64)
+		expiresIn = ctx.Param[inviteExpiresInKey].(int64)
+	)
+
+	if !hasGroupPermission(ctx, entities.PermissionApproveJoin) {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+		return
+	} else if info.JoinPolicy == entities.JoinPolicyClosed {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "群组当前不允许加入")
+		return
+	}
+
+	expiresAt := time.Now().Add(time.Duration(expiresIn) * time.Second).UnixMilli()
+	invite := entities.NewGroupInvite(controllers.GenerateInviteCode(), groupId, userId, maxUses, expiresAt)
+	if err = db.AddGroupInvite(nil, invite); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&GroupInviteBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Invite:         *invite,
+	}).MarshalJSON()
+}
+
+func returnGroupInviteListBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	groupId := ctx.Param[groupIdKey].(int64)
+
+	if !hasGroupPermission(ctx, entities.PermissionApproveJoin) {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+		return
+	}
+
+	invites, err := db.SelectGroupInvites(nil, groupId, time.Now().UnixMilli())
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&GroupInviteListBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Invites:        invites,
+	}).MarshalJSON()
+}
+
+func revokeInviteLink(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		groupId = ctx.Param[groupIdKey].(int64)
+		code    = ctx.Param[inviteCodeKey].(string)
+	)
+
+	if !hasGroupPermission(ctx, entities.PermissionApproveJoin) {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+		return
+	}
+
+	if err = db.RevokeGroupInvite(nil, groupId, code); err == db.MysqlErrorInviteInvalid {
+		retBuf, err = errorHandlerHook(IllegalRequest, "邀请链接不存在或已撤销")
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
+
+func redeemInviteLink(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		code   = ctx.Param[inviteCodeKey].(string)
+	)
+
+	invite, err := db.GetGroupInvite(nil, code)
+	if err == db.MysqlErrorInviteInvalid {
+		return errorHandlerHook(IllegalRequest, "邀请链接不存在或已失效")
+	} else if err != nil {
+		return errorHandlerHook(InternalError, err.Error())
+	} else if !invite.IsUsableAt(time.Now().UnixMilli()) {
+		return errorHandlerHook(IllegalRequest, "邀请链接不存在或已失效")
+	}
+
+	info, err := db.SearchGroupInfo(nil, invite.GroupId, true)
+	if err == db.MysqlErrorGroupNotExist {
+		return errorHandlerHook(GroupNotFound, "群组不存在")
+	} else if err != nil {
+		return errorHandlerHook(InternalError, err.Error())
+	} else if info.IsDeleted {
+		return errorHandlerHook(GroupNotFound, "群组已解散")
+	}
+
+	if _, isIn := info.PermissionsOf(userId); isIn {
+		return errorHandlerHook(IllegalRequest, "用户已在群组中")
+	}
+
+	action := info.JoinActionFor(invite.CreatorId)
+	return joinGroupByPolicy(action, userId, invite.GroupId, invite.CreatorId, "",
+		func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) ([]byte, error) {
+			// 需要审批时只提交入群申请，重复申请会合并到同一条通知，不消耗使用次数
+			if action != entities.JoinDirectly {
+				return nil, nil
+			}
+
+			if err := db.UseGroupInvite(mysqlTx, code, time.Now().UnixMilli()); err == db.MysqlErrorInviteInvalid {
+				return errorHandlerHook(IllegalRequest, "邀请链接不存在或已失效")
+			} else if err != nil {
+				return errorHandlerHook(InternalError, err.Error())
+			}
+			return nil, nil
+		})
+}
+
+// joinGroupByPolicy 按加入规则将用户直接加入群组或向群组发送入群申请，handlerId 为邀请者，greeting 为申请附带的验证消息
+// beforeJoin 与加入操作在同一事务中执行，返回错误回包时整个事务回滚
+func joinGroupByPolicy(action byte, userId, groupId, handlerId int64, greeting string,
+	beforeJoin func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) ([]byte, error)) (retBuf []byte, err error) {
+	if action == entities.JoinRejected {
+		return errorHandlerHook(GroupOpNoAuth, "群组当前不允许加入")
+	}
+
+	notifications := make([]*entities.Notification, 0, 2)
+	if db.StartDbTransaction(func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) error {
+		if beforeJoin != nil {
+			if retBuf, err = beforeJoin(mysqlTx, mongoTx); len(retBuf) != 0 || err != nil {
+				return errorAbortTransaction
+			}
+		}
+
+		if action == entities.JoinNeedApproval {
+			notifications = append(notifications, entities.NewNotification(userId, groupId, entities.Add, entities.Group, false, false))
+		} else {
+			if err = db.AgreeJoinGroup(mysqlTx, userId, groupId); err != nil {
+				retBuf, err = errorHandlerHook(InternalError, err.Error())
+				return errorAbortTransaction
+			}
+
+			notifications = append(notifications,
+				entities.NewNotification(groupId, userId, entities.Approve, entities.Group, true, true),
+				entities.NewNotification(userId, groupId, entities.Add, entities.Group, true, true))
+		}
+
+		for i, noti := range notifications {
+			if noti.IsHandled {
+				noti.HandleUserId = handlerId
+			}
+			if noti.OpType == entities.Add {
+				noti.Message = greeting
+			}
+
+			addNotification := db.AddAndReturnNotification
+			if !noti.IsHandled {
+				// 重复的入群申请只刷新已有通知
+				addNotification = db.AddOrRefreshPendingNotification
+			}
+			if notifications[i], err = addNotification(mongoTx, noti); err != nil {
+				retBuf, err = errorHandlerHook(InternalError, err.Error())
+				return errorAbortTransaction
+			}
+		}
+		return nil
+	}) != nil {
+		return
+	}
+
+	if action == entities.JoinDirectly {
+		if err = controllers.RefreshGroupInfoCache(groupId); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return
+		}
+	}
+
+	for _, noti := range notifications {
+		SendNotification(noti)
+	}
+	return
+}
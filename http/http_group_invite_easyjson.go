@@ -0,0 +1,296 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	entities "liveChat/entities"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson9130367eDecodeLiveChatHttp(in *jlexer.Lexer, out *GroupInviteListBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "invites":
+			if in.IsNull() {
+				in.Skip()
+				out.Invites = nil
+			} else {
+				in.Delim('[')
+				if out.Invites == nil {
+					if !in.IsDelim(']') {
+						out.Invites = make([]entities.GroupInvite, 0, 0)
+					} else {
+						out.Invites = []entities.GroupInvite{}
+					}
+				} else {
+					out.Invites = (out.Invites)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 entities.GroupInvite
+					easyjson9130367eDecodeLiveChatEntities(in, &v1)
+					out.Invites = append(out.Invites, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9130367eEncodeLiveChatHttp(out *jwriter.Writer, in GroupInviteListBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"invites\":"
+		out.RawString(prefix[1:])
+		if in.Invites == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Invites {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				easyjson9130367eEncodeLiveChatEntities(out, v3)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GroupInviteListBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9130367eEncodeLiveChatHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GroupInviteListBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9130367eEncodeLiveChatHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GroupInviteListBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9130367eDecodeLiveChatHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GroupInviteListBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9130367eDecodeLiveChatHttp(l, v)
+}
+func easyjson9130367eDecodeLiveChatEntities(in *jlexer.Lexer, out *entities.GroupInvite) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "code":
+			out.Code = string(in.String())
+		case "groupId":
+			out.GroupId = int64(in.Int64())
+		case "creatorId":
+			out.CreatorId = int64(in.Int64())
+		case "maxUses":
+			out.MaxUses = int64(in.Int64())
+		case "usedCount":
+			out.UsedCount = int64(in.Int64())
+		case "expiresAt":
+			out.ExpiresAt = int64(in.Int64())
+		case "isRevoked":
+			out.IsRevoked = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9130367eEncodeLiveChatEntities(out *jwriter.Writer, in entities.GroupInvite) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Code))
+	}
+	{
+		const prefix string = ",\"groupId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.GroupId))
+	}
+	{
+		const prefix string = ",\"creatorId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CreatorId))
+	}
+	{
+		const prefix string = ",\"maxUses\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MaxUses))
+	}
+	{
+		const prefix string = ",\"usedCount\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.UsedCount))
+	}
+	{
+		const prefix string = ",\"expiresAt\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ExpiresAt))
+	}
+	{
+		const prefix string = ",\"isRevoked\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsRevoked))
+	}
+	out.RawByte('}')
+}
+func easyjson9130367eDecodeLiveChatHttp1(in *jlexer.Lexer, out *GroupInviteBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "invite":
+			easyjson9130367eDecodeLiveChatEntities(in, &out.Invite)
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9130367eEncodeLiveChatHttp1(out *jwriter.Writer, in GroupInviteBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"invite\":"
+		out.RawString(prefix[1:])
+		easyjson9130367eEncodeLiveChatEntities(out, in.Invite)
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GroupInviteBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9130367eEncodeLiveChatHttp1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GroupInviteBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9130367eEncodeLiveChatHttp1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GroupInviteBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9130367eDecodeLiveChatHttp1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GroupInviteBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9130367eDecodeLiveChatHttp1(l, v)
+}
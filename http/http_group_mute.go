@@ -0,0 +1,150 @@
+package http
+
+import (
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"time"
+)
+
+const (
+	muteDurationParam = "duration"
+
+	muteDurationKey = "muteDuration"
+)
+
+const maxMuteDurationInSecond = 30 * 24 * 60 * 60
+
+func getMuteDurationFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	duration, retBuf, err := getInt64ParamFromURL(ctx, muteDurationParam, "缺少禁言时长", LackOfParameter)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if duration <= 0 || duration > maxMuteDurationInSecond {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "禁言时长超出范围")
+		return
+	}
+
+	ctx.Param[muteDurationKey] = duration
+	return
+}
+
+func muteAllMembers(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	return muteOrUnmuteAllMembers(ctx, true)
+}
+
+func unmuteAllMembers(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	return muteOrUnmuteAllMembers(ctx, false)
+}
+
+func muteGroupMember(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	mutedUntil := time.Now().Add(time.Duration(ctx.Param[muteDurationKey].(int64)) * time.Second).UnixMilli()
+	return muteOrUnmuteGroupMember(ctx, mutedUntil)
+}
+
+func unmuteGroupMember(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	return muteOrUnmuteGroupMember(ctx, 0)
+}
+
+func muteOrUnmuteAllMembers(ctx *controllers.ProcessContext, isMute bool) (retBuf []byte, err error) {
+	var (
+		userId  = ctx.Param[userIdFromTokenKey].(int64)
+		groupId = ctx.Param[groupIdKey].(int64)
+	)
+
+	if !hasGroupPermission(ctx, entities.PermissionMuteMember) {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+		return
+	}
+
+	var noti *entities.Notification
+	if db.StartDbTransaction(func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) error {
+		if err = db.UpdateGroupMuteAll(mysqlTx, groupId, isMute); err == db.MysqlErrorGroupNotExist {
+			retBuf, err = errorHandlerHook(GroupNotFound, "群组不存在")
+			return errorAbortTransaction
+		} else if err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return errorAbortTransaction
+		}
+
+		noti = entities.NewNotification(groupId, groupId, muteOpType(isMute), entities.Group, true, true)
+		noti.HandleUserId = userId
+		if _, err = db.AddAndReturnNotification(mongoTx, noti); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return errorAbortTransaction
+		}
+		return nil
+	}) != nil {
+		return
+	}
+
+	return sendMuteNotification(groupId, noti)
+}
+
+func muteOrUnmuteGroupMember(ctx *controllers.ProcessContext, mutedUntil int64) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		groupId  = ctx.Param[groupIdKey].(int64)
+		friendId = ctx.Param[friendIdKey].(int64)
+		info     = ctx.Param[groupInfoKey].(*entities.GroupInfo)
+		isMute   = mutedUntil != 0
+	)
+
+	operatorPermissions := ctx.Param[groupMemberPermissionKey].(entities.GroupPermission)
+	if !operatorPermissions.Has(entities.PermissionMuteMember) {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+		return
+	}
+
+	// 不能禁言群主以及权限高于自身的成员
+	if targetPermissions, isIn := info.PermissionsOf(friendId); !isIn {
+		retBuf, err = errorHandlerHook(UserNotFound, "用户不在群组中")
+		return
+	} else if friendId == info.Owner || !operatorPermissions.Has(targetPermissions) {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+		return
+	}
+
+	var noti *entities.Notification
+	if db.StartDbTransaction(func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) error {
+		if err = db.UpdateGroupMemberMute(mysqlTx, friendId, groupId, mutedUntil); err == db.MysqlErrorUserNotExist {
+			retBuf, err = errorHandlerHook(UserNotFound, "用户不在群组中")
+			return errorAbortTransaction
+		} else if err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return errorAbortTransaction
+		}
+
+		noti = entities.NewNotification(friendId, groupId, muteOpType(isMute), entities.Group, true, true)
+		noti.HandleUserId = userId
+		if _, err = db.AddAndReturnNotification(mongoTx, noti); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return errorAbortTransaction
+		}
+		return nil
+	}) != nil {
+		return
+	}
+
+	return sendMuteNotification(groupId, noti)
+}
+
+// sendMuteNotification 在事务提交后刷新群组缓存并向全体成员下发禁言通知
+func sendMuteNotification(groupId int64, noti *entities.Notification) (retBuf []byte, err error) {
+	if err = controllers.RefreshGroupInfoCache(groupId); err != nil {
+		return errorHandlerHook(InternalError, err.Error())
+	}
+
+	SendNotification(noti)
+	return
+}
+
+func muteOpType(isMute bool) byte {
+	if isMute {
+		return entities.Mute
+	}
+	return entities.Unmute
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+)
+
+const (
+	successionPolicyParam = "policy"
+
+	successionPolicyKey = "successionPolicy"
+)
+
+func getSuccessionPolicyFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	policy, retBuf, err := getInt64ParamFromURL(ctx, successionPolicyParam, "缺少继任规则", LackOfParameter)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if policy < 0 || !entities.IsValidSuccessionPolicy(byte(policy)) {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "继任规则不合法")
+		return
+	}
+
+	ctx.Param[successionPolicyKey] = byte(policy)
+	return
+}
+
+func transferGroupOwnership(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		groupId  = ctx.Param[groupIdKey].(int64)
+		friendId = ctx.Param[friendIdKey].(int64)
+		info     = ctx.Param[groupInfoKey].(*entities.GroupInfo)
+		isOwner  = ctx.Param[groupMemberIsOwnerKey].(bool)
+	)
+
+	if !isOwner {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+		return
+	}
+
+	if _, isIn := info.PermissionsOf(friendId); !isIn {
+		retBuf, err = errorHandlerHook(UserNotFound, "用户不在群组中")
+		return
+	}
+
+	var noti *entities.Notification
+	if db.StartDbTransaction(func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) error {
+		noti, retBuf, err = handOverGroup(mysqlTx, mongoTx, groupId, userId, friendId)
+		if len(retBuf) != 0 || err != nil {
+			return errorAbortTransaction
+		}
+		return nil
+	}) != nil {
+		return
+	}
+
+	// 事务提交后再刷新缓存，否则会读到转让前的群组信息
+	if err = controllers.RefreshGroupInfoCache(groupId); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	SendNotification(noti)
+	return
+}
+
+func updateSuccessionPolicy(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		groupId = ctx.Param[groupIdKey].(int64)
+		policy  = ctx.Param[successionPolicyKey].(byte)
+		isOwner = ctx.Param[groupMemberIsOwnerKey].(bool)
+	)
+
+	if !isOwner {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+		return
+	}
+
+	if err = db.UpdateGroupSuccessionPolicy(nil, groupId, policy); err == db.MysqlErrorGroupNotExist {
+		retBuf, err = errorHandlerHook(GroupNotFound, "群组不存在")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	if err = controllers.RefreshGroupInfoCache(groupId); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
+
+// handOverGroup 转让群主并生成通知全体成员的转让通知，调用方在事务提交后刷新缓存并发送通知
+func handOverGroup(mysqlTx *gorm.DB, mongoTx mongo.SessionContext, groupId, oldOwner, newOwner int64) (noti *entities.Notification, retBuf []byte, err error) {
+	if err = db.TransferGroupOwnership(mysqlTx, groupId, oldOwner, newOwner); err == db.MysqlErrorGroupNotExist {
+		retBuf, err = errorHandlerHook(GroupNotFound, "群组不存在")
+		return
+	} else if err == db.MysqlErrorUserNotExist {
+		retBuf, err = errorHandlerHook(UserNotFound, "用户不在群组中")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	noti = entities.NewNotification(newOwner, groupId, entities.Transfer, entities.Group, true, true)
+	noti.HandleUserId = oldOwner
+	if noti, err = db.AddAndReturnNotification(mongoTx, noti); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"time"
+)
+
+func pinMessage(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId  = ctx.Param[userIdFromTokenKey].(int64)
+		groupId = ctx.Param[groupIdKey].(int64)
+		seq     = ctx.Param[seqKey].(uint64)
+	)
+
+	if !hasGroupPermission(ctx, entities.PermissionPinMessage) {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+		return
+	}
+
+	return notifyGroupMembers(groupId, userId, entities.Pin, func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) ([]byte, error) {
+		if _, err := db.GetMessageInSeq(mongoTx, groupId, seq); err == mongo.ErrNoDocuments {
+			return errorHandlerHook(IllegalRequest, "消息不存在")
+		} else if err != nil {
+			return errorHandlerHook(InternalError, err.Error())
+		}
+
+		pinned := entities.NewPinnedMessage(groupId, seq, userId, time.Now().UnixMilli())
+		if err := db.AddPinnedMessage(mongoTx, pinned); err == db.MongoErrorMessagePinned {
+			return errorHandlerHook(IllegalRequest, "消息已置顶")
+		} else if err != nil {
+			return errorHandlerHook(InternalError, err.Error())
+		}
+		return nil, nil
+	})
+}
+
+func unpinMessage(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId  = ctx.Param[userIdFromTokenKey].(int64)
+		groupId = ctx.Param[groupIdKey].(int64)
+		seq     = ctx.Param[seqKey].(uint64)
+	)
+
+	if !hasGroupPermission(ctx, entities.PermissionPinMessage) {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+		return
+	}
+
+	return notifyGroupMembers(groupId, userId, entities.Unpin, func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) ([]byte, error) {
+		if err := db.DeletePinnedMessage(mongoTx, groupId, seq); err == db.MongoErrorNoPinned {
+			return errorHandlerHook(IllegalRequest, "消息未置顶")
+		} else if err != nil {
+			return errorHandlerHook(InternalError, err.Error())
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,203 @@
+package http
+
+import (
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"unicode/utf8"
+)
+
+const (
+	roleNameParam        = "roleName"
+	rolePermissionsParam = "permissions"
+
+	roleNameKey        = "roleName"
+	rolePermissionsKey = "rolePermissions"
+)
+
+const maxRoleNameLength = 32
+
+type GroupRoleListBody struct {
+	ResponseHeader
+	Roles []entities.GroupRole `json:"roles"`
+}
+
+func getRoleNameFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	name, retBuf, err := getParamFromURL(ctx, roleNameParam, "缺少角色名", LackOfParameter)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if utf8.RuneCountInString(name) > maxRoleNameLength {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "角色名过长")
+		return
+	}
+
+	ctx.Param[roleNameKey] = name
+	return
+}
+
+func getRolePermissionsFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	permissions, retBuf, err := getInt64ParamFromURL(ctx, rolePermissionsParam, "缺少角色权限", LackOfParameter)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if permissions < 0 || entities.GroupPermission(permissions)&^entities.PermissionAll != 0 {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "角色权限不合法")
+		return
+	}
+
+	ctx.Param[rolePermissionsKey] = entities.GroupPermission(permissions)
+	return
+}
+
+// checkManageRole 校验操作者可以管理角色，且不能定义或分配超出自身权限的角色
+func checkManageRole(ctx *controllers.ProcessContext, permissions entities.GroupPermission) (retBuf []byte, err error) {
+	operatorPermissions := ctx.Param[groupMemberPermissionKey].(entities.GroupPermission)
+	if !operatorPermissions.Has(entities.PermissionManageRoles) || !operatorPermissions.Has(permissions) {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+	}
+	return
+}
+
+func returnGroupRoleListBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		info = ctx.Param[groupInfoKey].(*entities.GroupInfo)
+		isIn = ctx.Param[groupMemberIsInKey].(bool)
+	)
+
+	if !isIn {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "用户不在群组中")
+		return
+	}
+
+	return (&GroupRoleListBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Roles:          info.EffectiveRoles(),
+	}).MarshalJSON()
+}
+
+func saveGroupRole(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		groupId     = ctx.Param[groupIdKey].(int64)
+		name        = ctx.Param[roleNameKey].(string)
+		permissions = ctx.Param[rolePermissionsKey].(entities.GroupPermission)
+	)
+
+	if name == entities.RoleOwner {
+		retBuf, err = errorHandlerHook(IllegalRequest, "不能修改群主的权限")
+		return
+	}
+
+	if retBuf, err = checkManageRole(ctx, permissions); len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if err = db.SaveGroupRole(nil, entities.NewGroupRole(groupId, name, permissions)); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	if err = controllers.RefreshGroupInfoCache(groupId); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
+
+func deleteGroupRole(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		groupId = ctx.Param[groupIdKey].(int64)
+		info    = ctx.Param[groupInfoKey].(*entities.GroupInfo)
+		name    = ctx.Param[roleNameKey].(string)
+	)
+
+	if name == entities.RoleOwner || name == entities.RoleMember {
+		retBuf, err = errorHandlerHook(IllegalRequest, "不能删除该角色")
+		return
+	}
+
+	permissions, ok := info.RolePermissions(name)
+	if !ok {
+		retBuf, err = errorHandlerHook(IllegalRequest, "角色不存在")
+		return
+	}
+
+	if retBuf, err = checkManageRole(ctx, permissions); len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if err = db.DeleteGroupRole(nil, groupId, name); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	if err = controllers.RefreshGroupInfoCache(groupId); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
+
+func setGroupMemberRole(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		groupId  = ctx.Param[groupIdKey].(int64)
+		friendId = ctx.Param[friendIdKey].(int64)
+		info     = ctx.Param[groupInfoKey].(*entities.GroupInfo)
+		name     = ctx.Param[roleNameKey].(string)
+	)
+
+	if name == entities.RoleOwner || friendId == info.Owner {
+		retBuf, err = errorHandlerHook(IllegalRequest, "不能变更群主的角色")
+		return
+	}
+
+	permissions, ok := info.RolePermissions(name)
+	if !ok {
+		retBuf, err = errorHandlerHook(IllegalRequest, "角色不存在")
+		return
+	}
+
+	if retBuf, err = checkManageRole(ctx, permissions); len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	// 同样不能变更权限高于自身的成员
+	if targetPermissions, isIn := info.PermissionsOf(friendId); !isIn {
+		retBuf, err = errorHandlerHook(UserNotFound, "用户不在群组中")
+		return
+	} else if retBuf, err = checkManageRole(ctx, targetPermissions); len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	var noti *entities.Notification
+	if db.StartDbTransaction(func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) error {
+		if err = db.SetGroupMemberRole(mysqlTx, friendId, groupId, name); err == db.MysqlErrorUserNotExist {
+			retBuf, err = errorHandlerHook(UserNotFound, "用户不在群组中")
+			return errorAbortTransaction
+		} else if err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return errorAbortTransaction
+		}
+
+		noti = entities.NewNotification(friendId, groupId, entities.Add, entities.MemberRole, true, true)
+		noti.HandleUserId = userId
+		if noti, err = db.AddAndReturnNotification(mongoTx, noti); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return errorAbortTransaction
+		}
+		return nil
+	}) != nil {
+		return
+	}
+
+	if err = controllers.RefreshGroupInfoCache(groupId); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	SendNotification(noti)
+	return
+}
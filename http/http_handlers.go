@@ -33,6 +33,7 @@ var (
 					Add(getTokenFromHeader).
 					Add(getUsernameFromUrl).
 					Add(validateToken).
+					Add(moderateUsername).
 					Add(updateUsername).
 					Add(returnSuccessBody)
 
@@ -40,6 +41,7 @@ var (
 						Add(getTokenFromHeader).
 						Add(getUserIntroductionFromUrl).
 						Add(validateToken).
+						Add(moderateUserIntroduction).
 						Add(updateUserIntroduction).
 						Add(returnSuccessBody)
 
@@ -53,12 +55,92 @@ var (
 	addFriendProcessChain = controllers.NewProcessChain().
 				Add(getTokenFromHeader).
 				Add(getFriendIdFromUrl).
+				Add(getGreetingFromUrl).
 				Add(validateToken).
 				Add(tellIsSameUserCompareTokenAndFriendId).
 				Add(rejectRequestFromOneSelf).
+				Add(rejectRequestFromBlockedUser).
 				Add(sendAddFriendNotificationToOther).
 				Add(returnSuccessBody)
 
+	updateFriendSettingProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getFriendIdFromUrl).
+					Add(getFriendSettingPostInBody).
+					Add(validateToken).
+					Add(updateFriendSetting).
+					Add(returnSuccessBody)
+
+	createContactGroupProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getContactGroupNameFromUrl).
+					Add(validateToken).
+					Add(createContactGroup)
+
+	renameContactGroupProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getContactGroupIdFromUrl).
+					Add(getContactGroupNameFromUrl).
+					Add(validateToken).
+					Add(renameContactGroup).
+					Add(returnSuccessBody)
+
+	deleteContactGroupProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getContactGroupIdFromUrl).
+					Add(validateToken).
+					Add(deleteContactGroup).
+					Add(returnSuccessBody)
+
+	searchUserProcessChain = controllers.NewProcessChain().
+				Add(getTokenFromHeader).
+				Add(getKeywordFromUrl).
+				Add(getPageFromUrl).
+				Add(validateToken).
+				Add(returnUserSearchBody)
+
+	updateDiscoverableByProcessChain = controllers.NewProcessChain().
+						Add(getTokenFromHeader).
+						Add(getDiscoverableByFromUrl).
+						Add(validateToken).
+						Add(updateDiscoverableBy).
+						Add(returnSuccessBody)
+
+	updateAddFriendPolicyProcessChain = controllers.NewProcessChain().
+						Add(getTokenFromHeader).
+						Add(getAddFriendPolicyFromUrl).
+						Add(validateToken).
+						Add(updateAddFriendPolicy).
+						Add(returnSuccessBody)
+
+	updateUserRetentionPolicyProcessChain = controllers.NewProcessChain().
+						Add(getTokenFromHeader).
+						Add(getRetentionPolicyFromUrl).
+						Add(validateToken).
+						Add(updateUserRetentionPolicy).
+						Add(returnSuccessBody)
+
+	blockUserProcessChain = controllers.NewProcessChain().
+				Add(getTokenFromHeader).
+				Add(getFriendIdFromUrl).
+				Add(validateToken).
+				Add(tellIsSameUserCompareTokenAndFriendId).
+				Add(rejectRequestFromOneSelf).
+				Add(blockUser).
+				Add(returnSuccessBody)
+
+	unblockUserProcessChain = controllers.NewProcessChain().
+				Add(getTokenFromHeader).
+				Add(getFriendIdFromUrl).
+				Add(validateToken).
+				Add(unblockUser).
+				Add(returnSuccessBody)
+
+	getBlocklistProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(validateToken).
+					Add(returnBlocklistBody)
+
 	approveFriendApplicationProcessChain = controllers.NewProcessChain().
 						Add(getTokenFromHeader).
 						Add(getNotificationSeqFromUrl).
@@ -82,6 +164,33 @@ var (
 					Add(sendDeleteFriendNotificationToOther).
 					Add(returnSuccessBody)
 
+	getMentionsProcessChain = controllers.NewProcessChain().
+				Add(getTokenFromHeader).
+				Add(getOptionalChatIdFromUrl).
+				Add(getUnreadOnlyFromUrl).
+				Add(getPageFromUrl).
+				Add(validateToken).
+				Add(returnMentionListBody)
+
+	getMentionCountsProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(validateToken).
+					Add(returnMentionCountBody)
+
+	readMentionsProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getChatIdFromUrl).
+					Add(getSeqFromUrl).
+					Add(validateToken).
+					Add(readMentions)
+
+	deleteAccountProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getPasswordFromUrl).
+					Add(validateToken).
+					Add(deleteAccount).
+					Add(returnSuccessBody)
+
 	getGroupInfoProcessChain = controllers.NewProcessChain().
 					Add(getTokenFromHeader).
 					Add(getGroupIdFromUrl).
@@ -92,6 +201,8 @@ var (
 				Add(getTokenFromHeader).
 				Add(getCreateGroupPostInBody).
 				Add(validateToken).
+				Add(moderateGroupName).
+				Add(moderateGroupIntroduction).
 				Add(createGroup).
 				Add(returnGroupInfoBody)
 
@@ -109,6 +220,7 @@ var (
 					Add(getGroupNameFromUrl).
 					Add(validateToken).
 					Add(checkGroupAuth).
+					Add(moderateGroupName).
 					Add(updateGroupName).
 					Add(returnSuccessBody)
 
@@ -118,6 +230,7 @@ var (
 						Add(getGroupIntroductionFromUrl).
 						Add(validateToken).
 						Add(checkGroupAuth).
+						Add(moderateGroupIntroduction).
 						Add(updateGroupIntroduction).
 						Add(returnSuccessBody)
 
@@ -133,6 +246,7 @@ var (
 	joinGroupProcessChain = controllers.NewProcessChain().
 				Add(getTokenFromHeader).
 				Add(getGroupIdFromUrl).
+				Add(getGreetingFromUrl).
 				Add(validateToken).
 				Add(checkGroupAuth).
 				Add(sendJoinGroupNotification).
@@ -189,6 +303,517 @@ var (
 					Add(rejectRequestFromOneSelf).
 					Add(deleteAdministrator).
 					Add(returnSuccessBody)
+
+	getGroupRolesProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getGroupIdFromUrl).
+					Add(validateToken).
+					Add(checkGroupAuth).
+					Add(returnGroupRoleListBody)
+
+	saveGroupRoleProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getGroupIdFromUrl).
+					Add(getRoleNameFromUrl).
+					Add(getRolePermissionsFromUrl).
+					Add(validateToken).
+					Add(checkGroupAuth).
+					Add(saveGroupRole).
+					Add(returnSuccessBody)
+
+	deleteGroupRoleProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getGroupIdFromUrl).
+					Add(getRoleNameFromUrl).
+					Add(validateToken).
+					Add(checkGroupAuth).
+					Add(deleteGroupRole).
+					Add(returnSuccessBody)
+
+	setGroupMemberRoleProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getGroupIdFromUrl).
+					Add(getFriendIdFromUrl).
+					Add(getRoleNameFromUrl).
+					Add(validateToken).
+					Add(checkGroupAuth).
+					Add(tellIsSameUserCompareTokenAndFriendId).
+					Add(rejectRequestFromOneSelf).
+					Add(setGroupMemberRole).
+					Add(returnSuccessBody)
+
+	muteAllMembersProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getGroupIdFromUrl).
+					Add(validateToken).
+					Add(checkGroupAuth).
+					Add(muteAllMembers).
+					Add(returnSuccessBody)
+
+	unmuteAllMembersProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getGroupIdFromUrl).
+					Add(validateToken).
+					Add(checkGroupAuth).
+					Add(unmuteAllMembers).
+					Add(returnSuccessBody)
+
+	muteGroupMemberProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getGroupIdFromUrl).
+					Add(getFriendIdFromUrl).
+					Add(getMuteDurationFromUrl).
+					Add(validateToken).
+					Add(checkGroupAuth).
+					Add(tellIsSameUserCompareTokenAndFriendId).
+					Add(rejectRequestFromOneSelf).
+					Add(muteGroupMember).
+					Add(returnSuccessBody)
+
+	unmuteGroupMemberProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getGroupIdFromUrl).
+					Add(getFriendIdFromUrl).
+					Add(validateToken).
+					Add(checkGroupAuth).
+					Add(tellIsSameUserCompareTokenAndFriendId).
+					Add(rejectRequestFromOneSelf).
+					Add(unmuteGroupMember).
+					Add(returnSuccessBody)
+
+	transferGroupOwnershipProcessChain = controllers.NewProcessChain().
+						Add(getTokenFromHeader).
+						Add(getGroupIdFromUrl).
+						Add(getFriendIdFromUrl).
+						Add(validateToken).
+						Add(checkGroupAuth).
+						Add(tellIsSameUserCompareTokenAndFriendId).
+						Add(rejectRequestFromOneSelf).
+						Add(transferGroupOwnership).
+						Add(returnSuccessBody)
+
+	updateSuccessionPolicyProcessChain = controllers.NewProcessChain().
+						Add(getTokenFromHeader).
+						Add(getGroupIdFromUrl).
+						Add(getSuccessionPolicyFromUrl).
+						Add(validateToken).
+						Add(checkGroupAuth).
+						Add(updateSuccessionPolicy).
+						Add(returnSuccessBody)
+
+	updateJoinPolicyProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getGroupIdFromUrl).
+					Add(getJoinPolicyFromUrl).
+					Add(validateToken).
+					Add(checkGroupAuth).
+					Add(updateJoinPolicy).
+					Add(returnSuccessBody)
+
+	updateGroupRetentionPolicyProcessChain = controllers.NewProcessChain().
+						Add(getTokenFromHeader).
+						Add(getGroupIdFromUrl).
+						Add(getRetentionPolicyFromUrl).
+						Add(validateToken).
+						Add(checkGroupAuth).
+						Add(updateGroupRetentionPolicy).
+						Add(returnSuccessBody)
+
+	inviteGroupMemberProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getGroupIdFromUrl).
+					Add(getFriendIdFromUrl).
+					Add(validateToken).
+					Add(checkGroupAuth).
+					Add(tellIsSameUserCompareTokenAndFriendId).
+					Add(rejectRequestFromOneSelf).
+					Add(rejectRequestFromBlockedUser).
+					Add(inviteMember).
+					Add(returnSuccessBody)
+
+	acceptGroupInvitationProcessChain = controllers.NewProcessChain().
+						Add(getTokenFromHeader).
+						Add(getNotificationSeqFromUrl).
+						Add(validateToken).
+						Add(acceptInvitation).
+						Add(returnSuccessBody)
+
+	refuseGroupInvitationProcessChain = controllers.NewProcessChain().
+						Add(getTokenFromHeader).
+						Add(getNotificationSeqFromUrl).
+						Add(validateToken).
+						Add(refuseInvitation).
+						Add(returnSuccessBody)
+
+	createInviteLinkProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getGroupIdFromUrl).
+					Add(getInviteLimitFromUrl).
+					Add(validateToken).
+					Add(checkGroupAuth).
+					Add(createInviteLink)
+
+	getInviteLinksProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getGroupIdFromUrl).
+					Add(validateToken).
+					Add(checkGroupAuth).
+					Add(returnGroupInviteListBody)
+
+	revokeInviteLinkProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getGroupIdFromUrl).
+					Add(getInviteCodeFromUrl).
+					Add(validateToken).
+					Add(checkGroupAuth).
+					Add(revokeInviteLink).
+					Add(returnSuccessBody)
+
+	redeemInviteLinkProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getInviteCodeFromUrl).
+					Add(validateToken).
+					Add(redeemInviteLink).
+					Add(returnSuccessBody)
+
+	publishAnnouncementProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getGroupIdFromUrl).
+					Add(getAnnouncementPostInBody).
+					Add(validateToken).
+					Add(checkGroupAuth).
+					Add(publishAnnouncement).
+					Add(returnSuccessBody)
+
+	acknowledgeAnnouncementProcessChain = controllers.NewProcessChain().
+						Add(getTokenFromHeader).
+						Add(getGroupIdFromUrl).
+						Add(validateToken).
+						Add(checkGroupAuth).
+						Add(acknowledgeAnnouncement).
+						Add(returnSuccessBody)
+
+	pinMessageProcessChain = controllers.NewProcessChain().
+				Add(getTokenFromHeader).
+				Add(getGroupIdFromUrl).
+				Add(getSeqFromUrl).
+				Add(validateToken).
+				Add(checkGroupAuth).
+				Add(pinMessage).
+				Add(returnSuccessBody)
+
+	unpinMessageProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getGroupIdFromUrl).
+					Add(getSeqFromUrl).
+					Add(validateToken).
+					Add(checkGroupAuth).
+					Add(unpinMessage).
+					Add(returnSuccessBody)
+
+	pinDirectMessageProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getFriendIdFromUrl).
+					Add(getReceiverFromUrl).
+					Add(getSeqFromUrl).
+					Add(validateToken).
+					Add(checkDirectChat).
+					Add(pinDirectMessage).
+					Add(returnSuccessBody)
+
+	unpinDirectMessageProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getFriendIdFromUrl).
+					Add(getReceiverFromUrl).
+					Add(getSeqFromUrl).
+					Add(validateToken).
+					Add(checkDirectChat).
+					Add(unpinDirectMessage).
+					Add(returnSuccessBody)
+
+	getDirectPinnedMessagesProcessChain = controllers.NewProcessChain().
+						Add(getTokenFromHeader).
+						Add(getFriendIdFromUrl).
+						Add(validateToken).
+						Add(checkDirectChat).
+						Add(returnDirectPinnedMessagesBody)
+
+	searchMessageProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getKeywordFromUrl).
+					Add(getOptionalChatIdFromUrl).
+					Add(getSearchFilterFromUrl).
+					Add(getPageFromUrl).
+					Add(validateToken).
+					Add(returnSearchResultBody)
+
+	registerPushDeviceProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getPushDeviceFromUrl).
+					Add(validateToken).
+					Add(registerPushDevice).
+					Add(returnSuccessBody)
+
+	unregisterPushDeviceProcessChain = controllers.NewProcessChain().
+						Add(getTokenFromHeader).
+						Add(getPushTokenFromUrl).
+						Add(validateToken).
+						Add(unregisterPushDevice).
+						Add(returnSuccessBody)
+
+	getPushSettingProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(validateToken).
+					Add(returnPushSettingBody)
+
+	updatePushSettingProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getPushSettingPostInBody).
+					Add(validateToken).
+					Add(updatePushSetting).
+					Add(returnPushSettingBody)
+
+	mutePushOfChatProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getChatIdFromUrl).
+					Add(validateToken).
+					Add(mutePushOfChat).
+					Add(returnPushSettingBody)
+
+	unmutePushOfChatProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getChatIdFromUrl).
+					Add(validateToken).
+					Add(unmutePushOfChat).
+					Add(returnPushSettingBody)
+
+	createWebhookSubscriptionProcessChain = controllers.NewProcessChain().
+						Add(validateOperatorKey).
+						Add(getWebhookSubscriptionPostInBody).
+						Add(createWebhookSubscription)
+
+	deleteWebhookSubscriptionProcessChain = controllers.NewProcessChain().
+						Add(validateOperatorKey).
+						Add(getSubscriptionIdFromUrl).
+						Add(deleteWebhookSubscription).
+						Add(returnSuccessBody)
+
+	getWebhookSubscriptionsProcessChain = controllers.NewProcessChain().
+						Add(validateOperatorKey).
+						Add(returnWebhookSubscriptionListBody)
+
+	getWebhookDeliveriesProcessChain = controllers.NewProcessChain().
+						Add(validateOperatorKey).
+						Add(getDeliveryFilterFromUrl).
+						Add(getPageFromUrl).
+						Add(returnWebhookDeliveryListBody)
+
+	replayWebhookDeliveryProcessChain = controllers.NewProcessChain().
+						Add(validateOperatorKey).
+						Add(getDeliveryIdFromUrl).
+						Add(replayWebhookDelivery)
+
+	createChatExportProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getChatIdFromUrl).
+					Add(getExportFormatFromUrl).
+					Add(validateToken).
+					Add(createChatExportJob)
+
+	createAccountExportProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getExportFormatFromUrl).
+					Add(validateToken).
+					Add(createAccountExportJob)
+
+	getExportJobsProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(validateToken).
+					Add(returnExportJobListBody)
+
+	getExportJobProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getExportJobIdFromUrl).
+					Add(validateToken).
+					Add(getOwnExportJob).
+					Add(returnExportJobBody)
+
+	downloadExportProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getExportJobIdFromUrl).
+					Add(validateToken).
+					Add(getOwnExportJob).
+					Add(downloadExportFile)
+
+	operatorChatExportProcessChain = controllers.NewProcessChain().
+					Add(validateOperatorKey).
+					Add(getUserIdFromUrl).
+					Add(getChatIdFromUrl).
+					Add(getExportFormatFromUrl).
+					Add(createOperatorChatExportJob)
+
+	operatorGetExportJobProcessChain = controllers.NewProcessChain().
+						Add(validateOperatorKey).
+						Add(getExportJobIdFromUrl).
+						Add(getOperatorExportJob).
+						Add(returnExportJobBody)
+
+	operatorDownloadExportProcessChain = controllers.NewProcessChain().
+						Add(validateOperatorKey).
+						Add(getExportJobIdFromUrl).
+						Add(getOperatorExportJob).
+						Add(downloadExportFile)
+
+	uploadDeviceKeyProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getDeviceKeyPostInBody).
+					Add(validateToken).
+					Add(uploadDeviceKey)
+
+	claimPreKeyBundleProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getFriendIdFromUrl).
+					Add(validateToken).
+					Add(claimPreKeyBundles)
+
+	getOneTimePreKeyCountProcessChain = controllers.NewProcessChain().
+						Add(getTokenFromHeader).
+						Add(getDeviceIdFromUrl).
+						Add(validateToken).
+						Add(returnOneTimePreKeyCountBody)
+
+	removeDeviceKeyProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getDeviceIdFromUrl).
+					Add(validateToken).
+					Add(removeDeviceKey).
+					Add(returnSuccessBody)
+
+	submitReportProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getReportPostInBody).
+					Add(validateToken).
+					Add(submitReport)
+
+	operatorReportsProcessChain = controllers.NewProcessChain().
+					Add(validateOperatorKey).
+					Add(getReportStatusFromUrl).
+					Add(getPageFromUrl).
+					Add(returnReportListBody)
+
+	operatorReportProcessChain = controllers.NewProcessChain().
+					Add(validateOperatorKey).
+					Add(getReportIdFromUrl).
+					Add(getReport).
+					Add(returnReportBody)
+
+	operatorReviewProcessChain = controllers.NewProcessChain().
+					Add(validateOperatorKey).
+					Add(getReviewPostInBody).
+					Add(getReviewReport).
+					Add(reviewReport)
+
+	operatorAuditLogsProcessChain = controllers.NewProcessChain().
+					Add(validateOperatorKey).
+					Add(getOptionalReportIdFromUrl).
+					Add(getPageFromUrl).
+					Add(returnAuditLogListBody)
+
+	adminUserProcessChain = controllers.NewProcessChain().
+				Add(validateOperatorKey).
+				Add(getUserIdFromUrl).
+				Add(returnAdminUserBody)
+
+	adminGroupProcessChain = controllers.NewProcessChain().
+				Add(validateOperatorKey).
+				Add(getGroupIdFromUrl).
+				Add(returnAdminGroupBody)
+
+	adminConnectionsProcessChain = controllers.NewProcessChain().
+					Add(validateOperatorKey).
+					Add(getUserIdFromUrl).
+					Add(returnUserConnectionListBody)
+
+	adminForceLogoutProcessChain = controllers.NewProcessChain().
+					Add(validateOperatorKey).
+					Add(getUserIdFromUrl).
+					Add(adminForceLogout).
+					Add(returnSuccessBody)
+
+	adminBanProcessChain = controllers.NewProcessChain().
+				Add(validateOperatorKey).
+				Add(getUserIdFromUrl).
+				Add(getBanDurationFromUrl).
+				Add(adminBanUser).
+				Add(returnSuccessBody)
+
+	adminUnbanProcessChain = controllers.NewProcessChain().
+				Add(validateOperatorKey).
+				Add(getUserIdFromUrl).
+				Add(adminUnbanUser).
+				Add(returnSuccessBody)
+
+	adminDissolveGroupProcessChain = controllers.NewProcessChain().
+					Add(validateOperatorKey).
+					Add(getGroupIdFromUrl).
+					Add(adminDissolveGroup).
+					Add(returnSuccessBody)
+
+	adminAnnounceProcessChain = controllers.NewProcessChain().
+					Add(validateOperatorKey).
+					Add(getSystemAnnouncementPostInBody).
+					Add(adminSendSystemAnnouncement).
+					Add(returnSuccessBody)
+
+	createBotProcessChain = controllers.NewProcessChain().
+				Add(getTokenFromHeader).
+				Add(getUsernameFromUrl).
+				Add(validateToken).
+				Add(moderateUsername).
+				Add(createBot)
+
+	resetBotApiKeyProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getBotIdFromUrl).
+					Add(validateToken).
+					Add(checkBotOwner).
+					Add(resetBotApiKey)
+
+	setBotWebhookProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getBotIdFromUrl).
+					Add(getBotWebhookFromUrl).
+					Add(validateToken).
+					Add(checkBotOwner).
+					Add(setBotWebhook).
+					Add(returnSuccessBody)
+
+	addBotToGroupProcessChain = controllers.NewProcessChain().
+					Add(getTokenFromHeader).
+					Add(getBotIdFromUrl).
+					Add(getGroupIdFromUrl).
+					Add(validateToken).
+					Add(checkBotOwner).
+					Add(checkGroupAuth).
+					Add(addBotToGroup).
+					Add(returnSuccessBody)
+
+	sendBotMessageProcessChain = controllers.NewProcessChain().
+					Add(validateBotKey).
+					Add(getBotMessagePostInBody).
+					Add(sendBotMessage)
+
+	botApproveFriendApplicationProcessChain = controllers.NewProcessChain().
+						Add(validateBotKey).
+						Add(getNotificationSeqFromUrl).
+						Add(approveFriendRequest).
+						Add(returnFriendshipBody)
+
+	pollBotInboxProcessChain = controllers.NewProcessChain().
+					Add(validateBotKey).
+					Add(getPollTimeoutFromUrl).
+					Add(getPageFromUrl).
+					Add(returnBotInboxBody)
 )
 
 func init() {
@@ -235,6 +860,66 @@ func refuseFriendshipApplicationHandler(ctx *gin.Context) {
 	refuseFriendApplicationProcessChain.Process(ctx, postHandler)
 }
 
+func getMentionsHandler(ctx *gin.Context) {
+	getMentionsProcessChain.Process(ctx, postHandler)
+}
+
+func getMentionCountsHandler(ctx *gin.Context) {
+	getMentionCountsProcessChain.Process(ctx, postHandler)
+}
+
+func readMentionsHandler(ctx *gin.Context) {
+	readMentionsProcessChain.Process(ctx, postHandler)
+}
+
+func deleteAccountHandler(ctx *gin.Context) {
+	deleteAccountProcessChain.Process(ctx, postHandler)
+}
+
+func blockUserHandler(ctx *gin.Context) {
+	blockUserProcessChain.Process(ctx, postHandler)
+}
+
+func unblockUserHandler(ctx *gin.Context) {
+	unblockUserProcessChain.Process(ctx, postHandler)
+}
+
+func getBlocklistHandler(ctx *gin.Context) {
+	getBlocklistProcessChain.Process(ctx, postHandler)
+}
+
+func updateFriendSettingHandler(ctx *gin.Context) {
+	updateFriendSettingProcessChain.Process(ctx, postHandler)
+}
+
+func createContactGroupHandler(ctx *gin.Context) {
+	createContactGroupProcessChain.Process(ctx, postHandler)
+}
+
+func renameContactGroupHandler(ctx *gin.Context) {
+	renameContactGroupProcessChain.Process(ctx, postHandler)
+}
+
+func deleteContactGroupHandler(ctx *gin.Context) {
+	deleteContactGroupProcessChain.Process(ctx, postHandler)
+}
+
+func searchUserHandler(ctx *gin.Context) {
+	searchUserProcessChain.Process(ctx, postHandler)
+}
+
+func updateDiscoverableByHandler(ctx *gin.Context) {
+	updateDiscoverableByProcessChain.Process(ctx, postHandler)
+}
+
+func updateAddFriendPolicyHandler(ctx *gin.Context) {
+	updateAddFriendPolicyProcessChain.Process(ctx, postHandler)
+}
+
+func updateUserRetentionPolicyHandler(ctx *gin.Context) {
+	updateUserRetentionPolicyProcessChain.Process(ctx, postHandler)
+}
+
 func getGroupInfoHandler(ctx *gin.Context) {
 	getGroupInfoProcessChain.Process(ctx, postHandler)
 }
@@ -279,6 +964,286 @@ func deleteAdministratorHandler(ctx *gin.Context) {
 	deleteAdministratorProcessChain.Process(ctx, postHandler)
 }
 
+func getGroupRolesHandler(ctx *gin.Context) {
+	getGroupRolesProcessChain.Process(ctx, postHandler)
+}
+
+func saveGroupRoleHandler(ctx *gin.Context) {
+	saveGroupRoleProcessChain.Process(ctx, postHandler)
+}
+
+func deleteGroupRoleHandler(ctx *gin.Context) {
+	deleteGroupRoleProcessChain.Process(ctx, postHandler)
+}
+
+func setGroupMemberRoleHandler(ctx *gin.Context) {
+	setGroupMemberRoleProcessChain.Process(ctx, postHandler)
+}
+
+func muteAllMembersHandler(ctx *gin.Context) {
+	muteAllMembersProcessChain.Process(ctx, postHandler)
+}
+
+func unmuteAllMembersHandler(ctx *gin.Context) {
+	unmuteAllMembersProcessChain.Process(ctx, postHandler)
+}
+
+func muteGroupMemberHandler(ctx *gin.Context) {
+	muteGroupMemberProcessChain.Process(ctx, postHandler)
+}
+
+func unmuteGroupMemberHandler(ctx *gin.Context) {
+	unmuteGroupMemberProcessChain.Process(ctx, postHandler)
+}
+
+func transferGroupOwnershipHandler(ctx *gin.Context) {
+	transferGroupOwnershipProcessChain.Process(ctx, postHandler)
+}
+
+func updateSuccessionPolicyHandler(ctx *gin.Context) {
+	updateSuccessionPolicyProcessChain.Process(ctx, postHandler)
+}
+
+func updateJoinPolicyHandler(ctx *gin.Context) {
+	updateJoinPolicyProcessChain.Process(ctx, postHandler)
+}
+
+func updateGroupRetentionPolicyHandler(ctx *gin.Context) {
+	updateGroupRetentionPolicyProcessChain.Process(ctx, postHandler)
+}
+
+func inviteGroupMemberHandler(ctx *gin.Context) {
+	inviteGroupMemberProcessChain.Process(ctx, postHandler)
+}
+
+func acceptGroupInvitationHandler(ctx *gin.Context) {
+	acceptGroupInvitationProcessChain.Process(ctx, postHandler)
+}
+
+func refuseGroupInvitationHandler(ctx *gin.Context) {
+	refuseGroupInvitationProcessChain.Process(ctx, postHandler)
+}
+
+func createInviteLinkHandler(ctx *gin.Context) {
+	createInviteLinkProcessChain.Process(ctx, postHandler)
+}
+
+func getInviteLinksHandler(ctx *gin.Context) {
+	getInviteLinksProcessChain.Process(ctx, postHandler)
+}
+
+func revokeInviteLinkHandler(ctx *gin.Context) {
+	revokeInviteLinkProcessChain.Process(ctx, postHandler)
+}
+
+func redeemInviteLinkHandler(ctx *gin.Context) {
+	redeemInviteLinkProcessChain.Process(ctx, postHandler)
+}
+
+func publishAnnouncementHandler(ctx *gin.Context) {
+	publishAnnouncementProcessChain.Process(ctx, postHandler)
+}
+
+func acknowledgeAnnouncementHandler(ctx *gin.Context) {
+	acknowledgeAnnouncementProcessChain.Process(ctx, postHandler)
+}
+
+func pinMessageHandler(ctx *gin.Context) {
+	pinMessageProcessChain.Process(ctx, postHandler)
+}
+
+func unpinMessageHandler(ctx *gin.Context) {
+	unpinMessageProcessChain.Process(ctx, postHandler)
+}
+
+func pinDirectMessageHandler(ctx *gin.Context) {
+	pinDirectMessageProcessChain.Process(ctx, postHandler)
+}
+
+func unpinDirectMessageHandler(ctx *gin.Context) {
+	unpinDirectMessageProcessChain.Process(ctx, postHandler)
+}
+
+func getDirectPinnedMessagesHandler(ctx *gin.Context) {
+	getDirectPinnedMessagesProcessChain.Process(ctx, postHandler)
+}
+
 func quitOrDeleteMemberHandler(ctx *gin.Context) {
 	quitOrDeleteMemberProcessChain.Process(ctx, postHandler)
 }
+
+func searchMessageHandler(ctx *gin.Context) {
+	searchMessageProcessChain.Process(ctx, postHandler)
+}
+
+func registerPushDeviceHandler(ctx *gin.Context) {
+	registerPushDeviceProcessChain.Process(ctx, postHandler)
+}
+
+func unregisterPushDeviceHandler(ctx *gin.Context) {
+	unregisterPushDeviceProcessChain.Process(ctx, postHandler)
+}
+
+func getPushSettingHandler(ctx *gin.Context) {
+	getPushSettingProcessChain.Process(ctx, postHandler)
+}
+
+func updatePushSettingHandler(ctx *gin.Context) {
+	updatePushSettingProcessChain.Process(ctx, postHandler)
+}
+
+func mutePushOfChatHandler(ctx *gin.Context) {
+	mutePushOfChatProcessChain.Process(ctx, postHandler)
+}
+
+func unmutePushOfChatHandler(ctx *gin.Context) {
+	unmutePushOfChatProcessChain.Process(ctx, postHandler)
+}
+
+func createWebhookSubscriptionHandler(ctx *gin.Context) {
+	createWebhookSubscriptionProcessChain.Process(ctx, postHandler)
+}
+
+func deleteWebhookSubscriptionHandler(ctx *gin.Context) {
+	deleteWebhookSubscriptionProcessChain.Process(ctx, postHandler)
+}
+
+func getWebhookSubscriptionsHandler(ctx *gin.Context) {
+	getWebhookSubscriptionsProcessChain.Process(ctx, postHandler)
+}
+
+func getWebhookDeliveriesHandler(ctx *gin.Context) {
+	getWebhookDeliveriesProcessChain.Process(ctx, postHandler)
+}
+
+func replayWebhookDeliveryHandler(ctx *gin.Context) {
+	replayWebhookDeliveryProcessChain.Process(ctx, postHandler)
+}
+
+func createChatExportHandler(ctx *gin.Context) {
+	createChatExportProcessChain.Process(ctx, postHandler)
+}
+
+func createAccountExportHandler(ctx *gin.Context) {
+	createAccountExportProcessChain.Process(ctx, postHandler)
+}
+
+func getExportJobsHandler(ctx *gin.Context) {
+	getExportJobsProcessChain.Process(ctx, postHandler)
+}
+
+func getExportJobHandler(ctx *gin.Context) {
+	getExportJobProcessChain.Process(ctx, postHandler)
+}
+
+func downloadExportHandler(ctx *gin.Context) {
+	downloadExportProcessChain.Process(ctx, exportPostHandler)
+}
+
+func operatorChatExportHandler(ctx *gin.Context) {
+	operatorChatExportProcessChain.Process(ctx, postHandler)
+}
+
+func operatorGetExportJobHandler(ctx *gin.Context) {
+	operatorGetExportJobProcessChain.Process(ctx, postHandler)
+}
+
+func operatorDownloadExportHandler(ctx *gin.Context) {
+	operatorDownloadExportProcessChain.Process(ctx, exportPostHandler)
+}
+
+func uploadDeviceKeyHandler(ctx *gin.Context) {
+	uploadDeviceKeyProcessChain.Process(ctx, postHandler)
+}
+
+func claimPreKeyBundleHandler(ctx *gin.Context) {
+	claimPreKeyBundleProcessChain.Process(ctx, postHandler)
+}
+
+func getOneTimePreKeyCountHandler(ctx *gin.Context) {
+	getOneTimePreKeyCountProcessChain.Process(ctx, postHandler)
+}
+
+func removeDeviceKeyHandler(ctx *gin.Context) {
+	removeDeviceKeyProcessChain.Process(ctx, postHandler)
+}
+
+func submitReportHandler(ctx *gin.Context) {
+	submitReportProcessChain.Process(ctx, postHandler)
+}
+
+func operatorReportsHandler(ctx *gin.Context) {
+	operatorReportsProcessChain.Process(ctx, postHandler)
+}
+
+func operatorReportHandler(ctx *gin.Context) {
+	operatorReportProcessChain.Process(ctx, postHandler)
+}
+
+func operatorReviewHandler(ctx *gin.Context) {
+	operatorReviewProcessChain.Process(ctx, postHandler)
+}
+
+func operatorAuditLogsHandler(ctx *gin.Context) {
+	operatorAuditLogsProcessChain.Process(ctx, postHandler)
+}
+
+func adminUserHandler(ctx *gin.Context) {
+	adminUserProcessChain.Process(ctx, postHandler)
+}
+
+func adminGroupHandler(ctx *gin.Context) {
+	adminGroupProcessChain.Process(ctx, postHandler)
+}
+
+func adminConnectionsHandler(ctx *gin.Context) {
+	adminConnectionsProcessChain.Process(ctx, postHandler)
+}
+
+func adminForceLogoutHandler(ctx *gin.Context) {
+	adminForceLogoutProcessChain.Process(ctx, postHandler)
+}
+
+func adminBanHandler(ctx *gin.Context) {
+	adminBanProcessChain.Process(ctx, postHandler)
+}
+
+func adminUnbanHandler(ctx *gin.Context) {
+	adminUnbanProcessChain.Process(ctx, postHandler)
+}
+
+func adminDissolveGroupHandler(ctx *gin.Context) {
+	adminDissolveGroupProcessChain.Process(ctx, postHandler)
+}
+
+func adminAnnounceHandler(ctx *gin.Context) {
+	adminAnnounceProcessChain.Process(ctx, postHandler)
+}
+
+func createBotHandler(ctx *gin.Context) {
+	createBotProcessChain.Process(ctx, postHandler)
+}
+
+func resetBotApiKeyHandler(ctx *gin.Context) {
+	resetBotApiKeyProcessChain.Process(ctx, postHandler)
+}
+
+func setBotWebhookHandler(ctx *gin.Context) {
+	setBotWebhookProcessChain.Process(ctx, postHandler)
+}
+
+func addBotToGroupHandler(ctx *gin.Context) {
+	addBotToGroupProcessChain.Process(ctx, postHandler)
+}
+
+func sendBotMessageHandler(ctx *gin.Context) {
+	sendBotMessageProcessChain.Process(ctx, postHandler)
+}
+
+func botApproveFriendApplicationHandler(ctx *gin.Context) {
+	botApproveFriendApplicationProcessChain.Process(ctx, postHandler)
+}
+
+func pollBotInboxHandler(ctx *gin.Context) {
+	pollBotInboxProcessChain.Process(ctx, postHandler)
+}
@@ -18,7 +18,87 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonDe1d482eDecodeLiveChat(in *jlexer.Lexer, out *UserInfoBody) {
+func easyjsonDe1d482eDecodeLiveChatHttp(in *jlexer.Lexer, out *createGroupForm) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "group_name":
+			out.GroupName = string(in.String())
+		case "group_introduction":
+			out.GroupIntroduction = string(in.String())
+		case "group_avatar":
+			out.GroupAvatar = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonDe1d482eEncodeLiveChatHttp(out *jwriter.Writer, in createGroupForm) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"group_name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.GroupName))
+	}
+	{
+		const prefix string = ",\"group_introduction\":"
+		out.RawString(prefix)
+		out.String(string(in.GroupIntroduction))
+	}
+	{
+		const prefix string = ",\"group_avatar\":"
+		out.RawString(prefix)
+		out.String(string(in.GroupAvatar))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v createGroupForm) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonDe1d482eEncodeLiveChatHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v createGroupForm) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonDe1d482eEncodeLiveChatHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *createGroupForm) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonDe1d482eDecodeLiveChatHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *createGroupForm) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonDe1d482eDecodeLiveChatHttp(l, v)
+}
+func easyjsonDe1d482eDecodeLiveChatHttp1(in *jlexer.Lexer, out *UserInfoBody) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -45,6 +125,22 @@ func easyjsonDe1d482eDecodeLiveChat(in *jlexer.Lexer, out *UserInfoBody) {
 			out.UserAvatar = string(in.String())
 		case "introduction":
 			out.UserIntroduction = string(in.String())
+		case "isBot":
+			out.IsBot = bool(in.Bool())
+		case "discoverableBy":
+			out.DiscoverableBy = uint8(in.Uint8())
+		case "addFriendPolicy":
+			out.AddFriendPolicy = uint8(in.Uint8())
+		case "retentionPolicy":
+			if in.IsNull() {
+				in.Skip()
+				out.RetentionPolicy = nil
+			} else {
+				if out.RetentionPolicy == nil {
+					out.RetentionPolicy = new(entities.RetentionPolicy)
+				}
+				easyjsonDe1d482eDecodeLiveChatEntities(in, out.RetentionPolicy)
+			}
 		case "friendships":
 			if in.IsNull() {
 				in.Skip()
@@ -62,7 +158,7 @@ func easyjsonDe1d482eDecodeLiveChat(in *jlexer.Lexer, out *UserInfoBody) {
 				}
 				for !in.IsDelim(']') {
 					var v1 entities.Friendship
-					easyjsonDe1d482eDecodeLiveChatEntities(in, &v1)
+					easyjsonDe1d482eDecodeLiveChatEntities1(in, &v1)
 					out.Friendships = append(out.Friendships, v1)
 					in.WantComma()
 				}
@@ -85,14 +181,39 @@ func easyjsonDe1d482eDecodeLiveChat(in *jlexer.Lexer, out *UserInfoBody) {
 				}
 				for !in.IsDelim(']') {
 					var v2 entities.GroupMember
-					easyjsonDe1d482eDecodeLiveChatEntities1(in, &v2)
+					easyjsonDe1d482eDecodeLiveChatEntities2(in, &v2)
 					out.Groups = append(out.Groups, v2)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "contactGroups":
+			if in.IsNull() {
+				in.Skip()
+				out.ContactGroups = nil
+			} else {
+				in.Delim('[')
+				if out.ContactGroups == nil {
+					if !in.IsDelim(']') {
+						out.ContactGroups = make([]entities.ContactGroup, 0, 2)
+					} else {
+						out.ContactGroups = []entities.ContactGroup{}
+					}
+				} else {
+					out.ContactGroups = (out.ContactGroups)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v3 entities.ContactGroup
+					easyjsonDe1d482eDecodeLiveChatEntities3(in, &v3)
+					out.ContactGroups = append(out.ContactGroups, v3)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		case "status":
 			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -103,7 +224,7 @@ func easyjsonDe1d482eDecodeLiveChat(in *jlexer.Lexer, out *UserInfoBody) {
 		in.Consumed()
 	}
 }
-func easyjsonDe1d482eEncodeLiveChat(out *jwriter.Writer, in UserInfoBody) {
+func easyjsonDe1d482eEncodeLiveChatHttp1(out *jwriter.Writer, in UserInfoBody) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -127,6 +248,30 @@ func easyjsonDe1d482eEncodeLiveChat(out *jwriter.Writer, in UserInfoBody) {
 		out.RawString(prefix)
 		out.String(string(in.UserIntroduction))
 	}
+	{
+		const prefix string = ",\"isBot\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsBot))
+	}
+	{
+		const prefix string = ",\"discoverableBy\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.DiscoverableBy))
+	}
+	{
+		const prefix string = ",\"addFriendPolicy\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.AddFriendPolicy))
+	}
+	{
+		const prefix string = ",\"retentionPolicy\":"
+		out.RawString(prefix)
+		if in.RetentionPolicy == nil {
+			out.RawString("null")
+		} else {
+			easyjsonDe1d482eEncodeLiveChatEntities(out, *in.RetentionPolicy)
+		}
+	}
 	{
 		const prefix string = ",\"friendships\":"
 		out.RawString(prefix)
@@ -134,11 +279,11 @@ func easyjsonDe1d482eEncodeLiveChat(out *jwriter.Writer, in UserInfoBody) {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v3, v4 := range in.Friendships {
-				if v3 > 0 {
+			for v4, v5 := range in.Friendships {
+				if v4 > 0 {
 					out.RawByte(',')
 				}
-				easyjsonDe1d482eEncodeLiveChatEntities(out, v4)
+				easyjsonDe1d482eEncodeLiveChatEntities1(out, v5)
 			}
 			out.RawByte(']')
 		}
@@ -150,11 +295,27 @@ func easyjsonDe1d482eEncodeLiveChat(out *jwriter.Writer, in UserInfoBody) {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v5, v6 := range in.Groups {
-				if v5 > 0 {
+			for v6, v7 := range in.Groups {
+				if v6 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonDe1d482eEncodeLiveChatEntities2(out, v7)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"contactGroups\":"
+		out.RawString(prefix)
+		if in.ContactGroups == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.ContactGroups {
+				if v8 > 0 {
 					out.RawByte(',')
 				}
-				easyjsonDe1d482eEncodeLiveChatEntities1(out, v6)
+				easyjsonDe1d482eEncodeLiveChatEntities3(out, v9)
 			}
 			out.RawByte(']')
 		}
@@ -164,33 +325,87 @@ func easyjsonDe1d482eEncodeLiveChat(out *jwriter.Writer, in UserInfoBody) {
 		out.RawString(prefix)
 		out.Int32(int32(in.Status))
 	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v UserInfoBody) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonDe1d482eEncodeLiveChat(&w, v)
+	easyjsonDe1d482eEncodeLiveChatHttp1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UserInfoBody) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonDe1d482eEncodeLiveChat(w, v)
+	easyjsonDe1d482eEncodeLiveChatHttp1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserInfoBody) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonDe1d482eDecodeLiveChat(&r, v)
+	easyjsonDe1d482eDecodeLiveChatHttp1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserInfoBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonDe1d482eDecodeLiveChat(l, v)
+	easyjsonDe1d482eDecodeLiveChatHttp1(l, v)
+}
+func easyjsonDe1d482eDecodeLiveChatEntities3(in *jlexer.Lexer, out *entities.ContactGroup) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.Id = int64(in.Int64())
+		case "name":
+			out.Name = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonDe1d482eEncodeLiveChatEntities3(out *jwriter.Writer, in entities.ContactGroup) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Id))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	out.RawByte('}')
 }
-func easyjsonDe1d482eDecodeLiveChatEntities1(in *jlexer.Lexer, out *entities.GroupMember) {
+func easyjsonDe1d482eDecodeLiveChatEntities2(in *jlexer.Lexer, out *entities.GroupMember) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -215,6 +430,10 @@ func easyjsonDe1d482eDecodeLiveChatEntities1(in *jlexer.Lexer, out *entities.Gro
 			out.MemberId = int64(in.Int64())
 		case "isAdministrator":
 			out.IsAdministrator = bool(in.Bool())
+		case "role":
+			out.Role = string(in.String())
+		case "mutedUntil":
+			out.MutedUntil = int64(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -225,7 +444,7 @@ func easyjsonDe1d482eDecodeLiveChatEntities1(in *jlexer.Lexer, out *entities.Gro
 		in.Consumed()
 	}
 }
-func easyjsonDe1d482eEncodeLiveChatEntities1(out *jwriter.Writer, in entities.GroupMember) {
+func easyjsonDe1d482eEncodeLiveChatEntities2(out *jwriter.Writer, in entities.GroupMember) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -249,9 +468,19 @@ func easyjsonDe1d482eEncodeLiveChatEntities1(out *jwriter.Writer, in entities.Gr
 		out.RawString(prefix)
 		out.Bool(bool(in.IsAdministrator))
 	}
+	{
+		const prefix string = ",\"role\":"
+		out.RawString(prefix)
+		out.String(string(in.Role))
+	}
+	{
+		const prefix string = ",\"mutedUntil\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MutedUntil))
+	}
 	out.RawByte('}')
 }
-func easyjsonDe1d482eDecodeLiveChatEntities(in *jlexer.Lexer, out *entities.Friendship) {
+func easyjsonDe1d482eDecodeLiveChatEntities1(in *jlexer.Lexer, out *entities.Friendship) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -276,6 +505,33 @@ func easyjsonDe1d482eDecodeLiveChatEntities(in *jlexer.Lexer, out *entities.Frie
 			out.FriendId = int64(in.Int64())
 		case "chatId":
 			out.ChatId = int64(in.Int64())
+		case "remark":
+			out.Remark = string(in.String())
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 string
+					v10 = string(in.String())
+					out.Tags = append(out.Tags, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "contactGroupId":
+			out.ContactGroupId = int64(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -286,7 +542,7 @@ func easyjsonDe1d482eDecodeLiveChatEntities(in *jlexer.Lexer, out *entities.Frie
 		in.Consumed()
 	}
 }
-func easyjsonDe1d482eEncodeLiveChatEntities(out *jwriter.Writer, in entities.Friendship) {
+func easyjsonDe1d482eEncodeLiveChatEntities1(out *jwriter.Writer, in entities.Friendship) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -310,9 +566,84 @@ func easyjsonDe1d482eEncodeLiveChatEntities(out *jwriter.Writer, in entities.Fri
 		out.RawString(prefix)
 		out.Int64(int64(in.ChatId))
 	}
+	{
+		const prefix string = ",\"remark\":"
+		out.RawString(prefix)
+		out.String(string(in.Remark))
+	}
+	{
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		if in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.Tags {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v12))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"contactGroupId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ContactGroupId))
+	}
+	out.RawByte('}')
+}
+func easyjsonDe1d482eDecodeLiveChatEntities(in *jlexer.Lexer, out *entities.RetentionPolicy) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "keepDays":
+			out.KeepDays = int64(in.Int64())
+		case "keepLast":
+			out.KeepLast = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonDe1d482eEncodeLiveChatEntities(out *jwriter.Writer, in entities.RetentionPolicy) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"keepDays\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.KeepDays))
+	}
+	{
+		const prefix string = ",\"keepLast\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.KeepLast))
+	}
 	out.RawByte('}')
 }
-func easyjsonDe1d482eDecodeLiveChat1(in *jlexer.Lexer, out *SuccessBody) {
+func easyjsonDe1d482eDecodeLiveChatHttp2(in *jlexer.Lexer, out *SuccessBody) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -333,6 +664,8 @@ func easyjsonDe1d482eDecodeLiveChat1(in *jlexer.Lexer, out *SuccessBody) {
 		switch key {
 		case "status":
 			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -343,7 +676,7 @@ func easyjsonDe1d482eDecodeLiveChat1(in *jlexer.Lexer, out *SuccessBody) {
 		in.Consumed()
 	}
 }
-func easyjsonDe1d482eEncodeLiveChat1(out *jwriter.Writer, in SuccessBody) {
+func easyjsonDe1d482eEncodeLiveChatHttp2(out *jwriter.Writer, in SuccessBody) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -352,33 +685,38 @@ func easyjsonDe1d482eEncodeLiveChat1(out *jwriter.Writer, in SuccessBody) {
 		out.RawString(prefix[1:])
 		out.Int32(int32(in.Status))
 	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v SuccessBody) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonDe1d482eEncodeLiveChat1(&w, v)
+	easyjsonDe1d482eEncodeLiveChatHttp2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v SuccessBody) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonDe1d482eEncodeLiveChat1(w, v)
+	easyjsonDe1d482eEncodeLiveChatHttp2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *SuccessBody) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonDe1d482eDecodeLiveChat1(&r, v)
+	easyjsonDe1d482eDecodeLiveChatHttp2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *SuccessBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonDe1d482eDecodeLiveChat1(l, v)
+	easyjsonDe1d482eDecodeLiveChatHttp2(l, v)
 }
-func easyjsonDe1d482eDecodeLiveChat2(in *jlexer.Lexer, out *ResponseHeader) {
+func easyjsonDe1d482eDecodeLiveChatHttp3(in *jlexer.Lexer, out *ResponseHeader) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -399,6 +737,8 @@ func easyjsonDe1d482eDecodeLiveChat2(in *jlexer.Lexer, out *ResponseHeader) {
 		switch key {
 		case "status":
 			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -409,7 +749,7 @@ func easyjsonDe1d482eDecodeLiveChat2(in *jlexer.Lexer, out *ResponseHeader) {
 		in.Consumed()
 	}
 }
-func easyjsonDe1d482eEncodeLiveChat2(out *jwriter.Writer, in ResponseHeader) {
+func easyjsonDe1d482eEncodeLiveChatHttp3(out *jwriter.Writer, in ResponseHeader) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -418,33 +758,38 @@ func easyjsonDe1d482eEncodeLiveChat2(out *jwriter.Writer, in ResponseHeader) {
 		out.RawString(prefix[1:])
 		out.Int32(int32(in.Status))
 	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ResponseHeader) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonDe1d482eEncodeLiveChat2(&w, v)
+	easyjsonDe1d482eEncodeLiveChatHttp3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ResponseHeader) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonDe1d482eEncodeLiveChat2(w, v)
+	easyjsonDe1d482eEncodeLiveChatHttp3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ResponseHeader) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonDe1d482eDecodeLiveChat2(&r, v)
+	easyjsonDe1d482eDecodeLiveChatHttp3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ResponseHeader) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonDe1d482eDecodeLiveChat2(l, v)
+	easyjsonDe1d482eDecodeLiveChatHttp3(l, v)
 }
-func easyjsonDe1d482eDecodeLiveChat3(in *jlexer.Lexer, out *RegisterOrLoginBody) {
+func easyjsonDe1d482eDecodeLiveChatHttp4(in *jlexer.Lexer, out *RegisterOrLoginBody) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -465,8 +810,12 @@ func easyjsonDe1d482eDecodeLiveChat3(in *jlexer.Lexer, out *RegisterOrLoginBody)
 		switch key {
 		case "token":
 			out.Token = string(in.String())
+		case "userId":
+			out.UserId = int64(in.Int64())
 		case "status":
 			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -477,7 +826,7 @@ func easyjsonDe1d482eDecodeLiveChat3(in *jlexer.Lexer, out *RegisterOrLoginBody)
 		in.Consumed()
 	}
 }
-func easyjsonDe1d482eEncodeLiveChat3(out *jwriter.Writer, in RegisterOrLoginBody) {
+func easyjsonDe1d482eEncodeLiveChatHttp4(out *jwriter.Writer, in RegisterOrLoginBody) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -486,38 +835,48 @@ func easyjsonDe1d482eEncodeLiveChat3(out *jwriter.Writer, in RegisterOrLoginBody
 		out.RawString(prefix[1:])
 		out.String(string(in.Token))
 	}
+	{
+		const prefix string = ",\"userId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.UserId))
+	}
 	{
 		const prefix string = ",\"status\":"
 		out.RawString(prefix)
 		out.Int32(int32(in.Status))
 	}
-	out.RawByte('}')
-}
-
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
 // MarshalJSON supports json.Marshaler interface
 func (v RegisterOrLoginBody) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonDe1d482eEncodeLiveChat3(&w, v)
+	easyjsonDe1d482eEncodeLiveChatHttp4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v RegisterOrLoginBody) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonDe1d482eEncodeLiveChat3(w, v)
+	easyjsonDe1d482eEncodeLiveChatHttp4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *RegisterOrLoginBody) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonDe1d482eDecodeLiveChat3(&r, v)
+	easyjsonDe1d482eDecodeLiveChatHttp4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *RegisterOrLoginBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonDe1d482eDecodeLiveChat3(l, v)
+	easyjsonDe1d482eDecodeLiveChatHttp4(l, v)
 }
-func easyjsonDe1d482eDecodeLiveChat4(in *jlexer.Lexer, out *GroupInfoBody) {
+func easyjsonDe1d482eDecodeLiveChatHttp5(in *jlexer.Lexer, out *GroupInfoBody) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -536,6 +895,41 @@ func easyjsonDe1d482eDecodeLiveChat4(in *jlexer.Lexer, out *GroupInfoBody) {
 			continue
 		}
 		switch key {
+		case "announcement":
+			if in.IsNull() {
+				in.Skip()
+				out.Announcement = nil
+			} else {
+				if out.Announcement == nil {
+					out.Announcement = new(entities.GroupAnnouncement)
+				}
+				easyjsonDe1d482eDecodeLiveChatEntities4(in, out.Announcement)
+			}
+		case "isAnnouncementAcknowledged":
+			out.IsAnnouncementAcknowledged = bool(in.Bool())
+		case "pinnedMessages":
+			if in.IsNull() {
+				in.Skip()
+				out.PinnedMessages = nil
+			} else {
+				in.Delim('[')
+				if out.PinnedMessages == nil {
+					if !in.IsDelim(']') {
+						out.PinnedMessages = make([]entities.PinnedMessage, 0, 2)
+					} else {
+						out.PinnedMessages = []entities.PinnedMessage{}
+					}
+				} else {
+					out.PinnedMessages = (out.PinnedMessages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 entities.PinnedMessage
+					easyjsonDe1d482eDecodeLiveChatEntities5(in, &v13)
+					out.PinnedMessages = append(out.PinnedMessages, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		case "id":
 			out.Id = int64(in.Int64())
 		case "ownerId":
@@ -546,6 +940,22 @@ func easyjsonDe1d482eDecodeLiveChat4(in *jlexer.Lexer, out *GroupInfoBody) {
 			out.Introduction = string(in.String())
 		case "avatar":
 			out.Avatar = string(in.String())
+		case "isMuteAll":
+			out.IsMuteAll = bool(in.Bool())
+		case "joinPolicy":
+			out.JoinPolicy = uint8(in.Uint8())
+		case "successionPolicy":
+			out.SuccessionPolicy = uint8(in.Uint8())
+		case "retentionPolicy":
+			if in.IsNull() {
+				in.Skip()
+				out.RetentionPolicy = nil
+			} else {
+				if out.RetentionPolicy == nil {
+					out.RetentionPolicy = new(entities.RetentionPolicy)
+				}
+				easyjsonDe1d482eDecodeLiveChatEntities(in, out.RetentionPolicy)
+			}
 		case "members":
 			if in.IsNull() {
 				in.Skip()
@@ -562,15 +972,40 @@ func easyjsonDe1d482eDecodeLiveChat4(in *jlexer.Lexer, out *GroupInfoBody) {
 					out.Members = (out.Members)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v7 entities.GroupMember
-					easyjsonDe1d482eDecodeLiveChatEntities1(in, &v7)
-					out.Members = append(out.Members, v7)
+					var v14 entities.GroupMember
+					easyjsonDe1d482eDecodeLiveChatEntities2(in, &v14)
+					out.Members = append(out.Members, v14)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "roles":
+			if in.IsNull() {
+				in.Skip()
+				out.Roles = nil
+			} else {
+				in.Delim('[')
+				if out.Roles == nil {
+					if !in.IsDelim(']') {
+						out.Roles = make([]entities.GroupRole, 0, 1)
+					} else {
+						out.Roles = []entities.GroupRole{}
+					}
+				} else {
+					out.Roles = (out.Roles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v15 entities.GroupRole
+					easyjsonDe1d482eDecodeLiveChatEntities6(in, &v15)
+					out.Roles = append(out.Roles, v15)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
 		case "status":
 			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -581,13 +1016,43 @@ func easyjsonDe1d482eDecodeLiveChat4(in *jlexer.Lexer, out *GroupInfoBody) {
 		in.Consumed()
 	}
 }
-func easyjsonDe1d482eEncodeLiveChat4(out *jwriter.Writer, in GroupInfoBody) {
+func easyjsonDe1d482eEncodeLiveChatHttp5(out *jwriter.Writer, in GroupInfoBody) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"announcement\":"
 		out.RawString(prefix[1:])
+		if in.Announcement == nil {
+			out.RawString("null")
+		} else {
+			easyjsonDe1d482eEncodeLiveChatEntities4(out, *in.Announcement)
+		}
+	}
+	{
+		const prefix string = ",\"isAnnouncementAcknowledged\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsAnnouncementAcknowledged))
+	}
+	{
+		const prefix string = ",\"pinnedMessages\":"
+		out.RawString(prefix)
+		if in.PinnedMessages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v16, v17 := range in.PinnedMessages {
+				if v16 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonDe1d482eEncodeLiveChatEntities5(out, v17)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix)
 		out.Int64(int64(in.Id))
 	}
 	{
@@ -610,6 +1075,30 @@ func easyjsonDe1d482eEncodeLiveChat4(out *jwriter.Writer, in GroupInfoBody) {
 		out.RawString(prefix)
 		out.String(string(in.Avatar))
 	}
+	{
+		const prefix string = ",\"isMuteAll\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsMuteAll))
+	}
+	{
+		const prefix string = ",\"joinPolicy\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.JoinPolicy))
+	}
+	{
+		const prefix string = ",\"successionPolicy\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.SuccessionPolicy))
+	}
+	{
+		const prefix string = ",\"retentionPolicy\":"
+		out.RawString(prefix)
+		if in.RetentionPolicy == nil {
+			out.RawString("null")
+		} else {
+			easyjsonDe1d482eEncodeLiveChatEntities(out, *in.RetentionPolicy)
+		}
+	}
 	{
 		const prefix string = ",\"members\":"
 		out.RawString(prefix)
@@ -617,11 +1106,27 @@ func easyjsonDe1d482eEncodeLiveChat4(out *jwriter.Writer, in GroupInfoBody) {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v8, v9 := range in.Members {
-				if v8 > 0 {
+			for v18, v19 := range in.Members {
+				if v18 > 0 {
 					out.RawByte(',')
 				}
-				easyjsonDe1d482eEncodeLiveChatEntities1(out, v9)
+				easyjsonDe1d482eEncodeLiveChatEntities2(out, v19)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"roles\":"
+		out.RawString(prefix)
+		if in.Roles == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v20, v21 := range in.Roles {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonDe1d482eEncodeLiveChatEntities6(out, v21)
 			}
 			out.RawByte(']')
 		}
@@ -631,33 +1136,38 @@ func easyjsonDe1d482eEncodeLiveChat4(out *jwriter.Writer, in GroupInfoBody) {
 		out.RawString(prefix)
 		out.Int32(int32(in.Status))
 	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v GroupInfoBody) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonDe1d482eEncodeLiveChat4(&w, v)
+	easyjsonDe1d482eEncodeLiveChatHttp5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v GroupInfoBody) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonDe1d482eEncodeLiveChat4(w, v)
+	easyjsonDe1d482eEncodeLiveChatHttp5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *GroupInfoBody) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonDe1d482eDecodeLiveChat4(&r, v)
+	easyjsonDe1d482eDecodeLiveChatHttp5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *GroupInfoBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonDe1d482eDecodeLiveChat4(l, v)
+	easyjsonDe1d482eDecodeLiveChatHttp5(l, v)
 }
-func easyjsonDe1d482eDecodeLiveChat5(in *jlexer.Lexer, out *FailBody) {
+func easyjsonDe1d482eDecodeLiveChatEntities6(in *jlexer.Lexer, out *entities.GroupRole) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -676,10 +1186,251 @@ func easyjsonDe1d482eDecodeLiveChat5(in *jlexer.Lexer, out *FailBody) {
 			continue
 		}
 		switch key {
-		case "reason":
-			out.Reason = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "permissions":
+			out.Permissions = entities.GroupPermission(in.Uint32())
+		case "isBuiltin":
+			out.IsBuiltin = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonDe1d482eEncodeLiveChatEntities6(out *jwriter.Writer, in entities.GroupRole) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"permissions\":"
+		out.RawString(prefix)
+		out.Uint32(uint32(in.Permissions))
+	}
+	{
+		const prefix string = ",\"isBuiltin\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsBuiltin))
+	}
+	out.RawByte('}')
+}
+func easyjsonDe1d482eDecodeLiveChatEntities5(in *jlexer.Lexer, out *entities.PinnedMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "chatId":
+			out.ChatId = int64(in.Int64())
+		case "seq":
+			out.Seq = uint64(in.Uint64())
+		case "pinnedBy":
+			out.PinnedBy = int64(in.Int64())
+		case "timestamp":
+			out.Timestamp = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonDe1d482eEncodeLiveChatEntities5(out *jwriter.Writer, in entities.PinnedMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"chatId\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ChatId))
+	}
+	{
+		const prefix string = ",\"seq\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Seq))
+	}
+	{
+		const prefix string = ",\"pinnedBy\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.PinnedBy))
+	}
+	{
+		const prefix string = ",\"timestamp\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Timestamp))
+	}
+	out.RawByte('}')
+}
+func easyjsonDe1d482eDecodeLiveChatEntities4(in *jlexer.Lexer, out *entities.GroupAnnouncement) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.Id = int64(in.Int64())
+		case "groupId":
+			out.GroupId = int64(in.Int64())
+		case "title":
+			out.Title = string(in.String())
+		case "body":
+			out.Body = string(in.String())
+		case "authorId":
+			out.AuthorId = int64(in.Int64())
+		case "createdAt":
+			out.CreatedAt = int64(in.Int64())
+		case "ackCount":
+			out.AckCount = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonDe1d482eEncodeLiveChatEntities4(out *jwriter.Writer, in entities.GroupAnnouncement) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Id))
+	}
+	{
+		const prefix string = ",\"groupId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.GroupId))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		out.String(string(in.Body))
+	}
+	{
+		const prefix string = ",\"authorId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.AuthorId))
+	}
+	{
+		const prefix string = ",\"createdAt\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CreatedAt))
+	}
+	{
+		const prefix string = ",\"ackCount\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.AckCount))
+	}
+	out.RawByte('}')
+}
+func easyjsonDe1d482eDecodeLiveChatHttp6(in *jlexer.Lexer, out *FriendshipBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "selfId":
+			out.SelfId = int64(in.Int64())
+		case "friendId":
+			out.FriendId = int64(in.Int64())
+		case "chatId":
+			out.ChatId = int64(in.Int64())
+		case "remark":
+			out.Remark = string(in.String())
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v22 string
+					v22 = string(in.String())
+					out.Tags = append(out.Tags, v22)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "contactGroupId":
+			out.ContactGroupId = int64(in.Int64())
 		case "status":
 			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -690,43 +1441,162 @@ func easyjsonDe1d482eDecodeLiveChat5(in *jlexer.Lexer, out *FailBody) {
 		in.Consumed()
 	}
 }
-func easyjsonDe1d482eEncodeLiveChat5(out *jwriter.Writer, in FailBody) {
+func easyjsonDe1d482eEncodeLiveChatHttp6(out *jwriter.Writer, in FriendshipBody) {
 	out.RawByte('{')
 	first := true
 	_ = first
+	{
+		const prefix string = ",\"selfId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.SelfId))
+	}
+	{
+		const prefix string = ",\"friendId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.FriendId))
+	}
+	{
+		const prefix string = ",\"chatId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ChatId))
+	}
+	{
+		const prefix string = ",\"remark\":"
+		out.RawString(prefix)
+		out.String(string(in.Remark))
+	}
+	{
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		if in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v23, v24 := range in.Tags {
+				if v23 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v24))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"contactGroupId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ContactGroupId))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
 	{
 		const prefix string = ",\"reason\":"
-		out.RawString(prefix[1:])
+		out.RawString(prefix)
 		out.String(string(in.Reason))
 	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FriendshipBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonDe1d482eEncodeLiveChatHttp6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FriendshipBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonDe1d482eEncodeLiveChatHttp6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FriendshipBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonDe1d482eDecodeLiveChatHttp6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FriendshipBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonDe1d482eDecodeLiveChatHttp6(l, v)
+}
+func easyjsonDe1d482eDecodeLiveChatHttp7(in *jlexer.Lexer, out *FailBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonDe1d482eEncodeLiveChatHttp7(out *jwriter.Writer, in FailBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
 	{
 		const prefix string = ",\"status\":"
-		out.RawString(prefix)
+		out.RawString(prefix[1:])
 		out.Int32(int32(in.Status))
 	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v FailBody) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonDe1d482eEncodeLiveChat5(&w, v)
+	easyjsonDe1d482eEncodeLiveChatHttp7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v FailBody) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonDe1d482eEncodeLiveChat5(w, v)
+	easyjsonDe1d482eEncodeLiveChatHttp7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *FailBody) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonDe1d482eDecodeLiveChat5(&r, v)
+	easyjsonDe1d482eDecodeLiveChatHttp7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *FailBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonDe1d482eDecodeLiveChat5(l, v)
+	easyjsonDe1d482eDecodeLiveChatHttp7(l, v)
 }
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"context"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+)
+
+const (
+	unreadOnlyParam = "unreadOnly"
+
+	unreadOnlyKey = "unreadOnly"
+)
+
+type MentionListBody struct {
+	ResponseHeader
+	Mentions   []entities.Mention `json:"mentions"`
+	NextCursor int64              `json:"nextCursor"`
+}
+
+type MentionCountBody struct {
+	ResponseHeader
+	Counts []entities.MentionCount `json:"counts"`
+}
+
+type ReadMentionBody struct {
+	ResponseHeader
+	ReadCount int64 `json:"readCount"`
+}
+
+func getUnreadOnlyFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	unreadOnly, retBuf, err := getOptionalBoolParamFromURL(ctx, unreadOnlyParam, false)
+	if len(retBuf) == 0 && err == nil {
+		ctx.Param[unreadOnlyKey] = unreadOnly
+	}
+	return
+}
+
+func returnMentionListBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId     = ctx.Param[userIdFromTokenKey].(int64)
+		chatId     = ctx.Param[chatIdKey].(int64)
+		unreadOnly = ctx.Param[unreadOnlyKey].(bool)
+		cursor     = ctx.Param[cursorKey].(int64)
+		limit      = ctx.Param[limitKey].(int64)
+	)
+
+	mentions, nextCursor, err := db.GetMentionsForUser(context.Background(), userId, chatId, unreadOnly, uint64(cursor), limit)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&MentionListBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Mentions:       mentions,
+		NextCursor:     int64(nextCursor),
+	}).MarshalJSON()
+}
+
+func returnMentionCountBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	userId := ctx.Param[userIdFromTokenKey].(int64)
+
+	counts, err := db.CountUnreadMentions(context.Background(), userId)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&MentionCountBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Counts:         counts,
+	}).MarshalJSON()
+}
+
+func readMentions(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		chatId = ctx.Param[chatIdKey].(int64)
+		seq    = ctx.Param[seqKey].(uint64)
+	)
+
+	count, err := db.ReadMentions(context.Background(), userId, chatId, seq)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&ReadMentionBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		ReadCount:      count,
+	}).MarshalJSON()
+}
@@ -0,0 +1,471 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	entities "liveChat/entities"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson215a39b3DecodeLiveChatHttp(in *jlexer.Lexer, out *ReadMentionBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "readCount":
+			out.ReadCount = int64(in.Int64())
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson215a39b3EncodeLiveChatHttp(out *jwriter.Writer, in ReadMentionBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"readCount\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ReadCount))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReadMentionBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson215a39b3EncodeLiveChatHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReadMentionBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson215a39b3EncodeLiveChatHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReadMentionBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson215a39b3DecodeLiveChatHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReadMentionBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson215a39b3DecodeLiveChatHttp(l, v)
+}
+func easyjson215a39b3DecodeLiveChatHttp1(in *jlexer.Lexer, out *MentionListBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "mentions":
+			if in.IsNull() {
+				in.Skip()
+				out.Mentions = nil
+			} else {
+				in.Delim('[')
+				if out.Mentions == nil {
+					if !in.IsDelim(']') {
+						out.Mentions = make([]entities.Mention, 0, 1)
+					} else {
+						out.Mentions = []entities.Mention{}
+					}
+				} else {
+					out.Mentions = (out.Mentions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 entities.Mention
+					easyjson215a39b3DecodeLiveChatEntities(in, &v1)
+					out.Mentions = append(out.Mentions, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "nextCursor":
+			out.NextCursor = int64(in.Int64())
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson215a39b3EncodeLiveChatHttp1(out *jwriter.Writer, in MentionListBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"mentions\":"
+		out.RawString(prefix[1:])
+		if in.Mentions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Mentions {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				easyjson215a39b3EncodeLiveChatEntities(out, v3)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"nextCursor\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.NextCursor))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MentionListBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson215a39b3EncodeLiveChatHttp1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MentionListBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson215a39b3EncodeLiveChatHttp1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MentionListBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson215a39b3DecodeLiveChatHttp1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MentionListBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson215a39b3DecodeLiveChatHttp1(l, v)
+}
+func easyjson215a39b3DecodeLiveChatEntities(in *jlexer.Lexer, out *entities.Mention) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "userId":
+			out.UserId = int64(in.Int64())
+		case "chatId":
+			out.ChatId = int64(in.Int64())
+		case "seq":
+			out.Seq = uint64(in.Uint64())
+		case "senderId":
+			out.SenderId = int64(in.Int64())
+		case "timestamp":
+			out.Timestamp = uint64(in.Uint64())
+		case "isAll":
+			out.IsAll = bool(in.Bool())
+		case "isRead":
+			out.IsRead = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson215a39b3EncodeLiveChatEntities(out *jwriter.Writer, in entities.Mention) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"userId\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.UserId))
+	}
+	{
+		const prefix string = ",\"chatId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ChatId))
+	}
+	{
+		const prefix string = ",\"seq\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Seq))
+	}
+	{
+		const prefix string = ",\"senderId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.SenderId))
+	}
+	{
+		const prefix string = ",\"timestamp\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Timestamp))
+	}
+	{
+		const prefix string = ",\"isAll\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsAll))
+	}
+	{
+		const prefix string = ",\"isRead\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsRead))
+	}
+	out.RawByte('}')
+}
+func easyjson215a39b3DecodeLiveChatHttp2(in *jlexer.Lexer, out *MentionCountBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "counts":
+			if in.IsNull() {
+				in.Skip()
+				out.Counts = nil
+			} else {
+				in.Delim('[')
+				if out.Counts == nil {
+					if !in.IsDelim(']') {
+						out.Counts = make([]entities.MentionCount, 0, 2)
+					} else {
+						out.Counts = []entities.MentionCount{}
+					}
+				} else {
+					out.Counts = (out.Counts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 entities.MentionCount
+					easyjson215a39b3DecodeLiveChatEntities1(in, &v4)
+					out.Counts = append(out.Counts, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson215a39b3EncodeLiveChatHttp2(out *jwriter.Writer, in MentionCountBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"counts\":"
+		out.RawString(prefix[1:])
+		if in.Counts == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Counts {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				easyjson215a39b3EncodeLiveChatEntities1(out, v6)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MentionCountBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson215a39b3EncodeLiveChatHttp2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MentionCountBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson215a39b3EncodeLiveChatHttp2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MentionCountBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson215a39b3DecodeLiveChatHttp2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MentionCountBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson215a39b3DecodeLiveChatHttp2(l, v)
+}
+func easyjson215a39b3DecodeLiveChatEntities1(in *jlexer.Lexer, out *entities.MentionCount) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "chatId":
+			out.ChatId = int64(in.Int64())
+		case "unreadCount":
+			out.UnreadCount = int64(in.Int64())
+		case "firstUnreadSeq":
+			out.FirstUnreadSeq = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson215a39b3EncodeLiveChatEntities1(out *jwriter.Writer, in entities.MentionCount) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"chatId\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ChatId))
+	}
+	{
+		const prefix string = ",\"unreadCount\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.UnreadCount))
+	}
+	{
+		const prefix string = ",\"firstUnreadSeq\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.FirstUnreadSeq))
+	}
+	out.RawByte('}')
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"errors"
+	"liveChat/controllers"
+	"liveChat/moderation"
+)
+
+func moderateUsername(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	return moderateParam(ctx, usernameKey, moderation.SceneUsername, "用户名包含违禁信息")
+}
+
+func moderateUserIntroduction(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	return moderateParam(ctx, userIntroductionKey, moderation.SceneUserIntroduction, "个人介绍包含违禁信息")
+}
+
+func moderateGroupName(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	return moderateParam(ctx, groupNameKey, moderation.SceneGroupName, "群名包含违禁信息")
+}
+
+func moderateGroupIntroduction(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	return moderateParam(ctx, groupIntroductionKey, moderation.SceneGroupIntroduction, "群介绍包含违禁信息")
+}
+
+// moderateParam 审核 ctx.Param[key] 中的文本，命中屏蔽词时替换为处理后的文本
+func moderateParam(ctx *controllers.ProcessContext, key, scene, reason string) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		text   = ctx.Param[key].(string)
+	)
+
+	verdict, err := moderation.Moderate(userId, scene, text)
+	if errors.Is(err, moderation.ErrorContentBlocked) {
+		retBuf, err = errorHandlerHook(ContentBlocked, reason)
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	ctx.Param[key] = verdict.Text
+	return
+}
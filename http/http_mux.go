@@ -1,7 +1,9 @@
 package http
 
 import (
+	"crypto/tls"
 	"github.com/gin-gonic/gin"
+	nethttp "net/http"
 )
 
 var httpServer *gin.Engine
@@ -20,6 +22,24 @@ const (
 	approveFriendApplicationRoute = userRouteHead + "/approveFriendApplication"
 	refuseFriendApplicationRoute  = userRouteHead + "/refuseFriendApplication"
 	deleteFriendRoute             = userRouteHead + "/deleteFriend"
+	getMentionsRoute              = userRouteHead + "/mentions"
+	getMentionCountsRoute         = userRouteHead + "/mentionCounts"
+	readMentionsRoute             = userRouteHead + "/readMentions"
+	deleteAccountRoute            = userRouteHead + "/deleteAccount"
+	blockUserRoute                = userRouteHead + "/block"
+	unblockUserRoute              = userRouteHead + "/unblock"
+	getBlocklistRoute             = userRouteHead + "/blocklist"
+	updateFriendSettingRoute      = userRouteHead + "/updateFriendSetting"
+	createContactGroupRoute       = userRouteHead + "/createContactGroup"
+	renameContactGroupRoute       = userRouteHead + "/renameContactGroup"
+	deleteContactGroupRoute       = userRouteHead + "/deleteContactGroup"
+	searchUserRoute               = userRouteHead + "/search"
+	updateDiscoverableByRoute     = userRouteHead + "/updateDiscoverableBy"
+	updateAddFriendPolicyRoute    = userRouteHead + "/updateAddFriendPolicy"
+	updateUserRetentionRoute      = userRouteHead + "/updateRetentionPolicy"
+	pinDirectMessageRoute         = userRouteHead + "/pinMessage"
+	unpinDirectMessageRoute       = userRouteHead + "/unpinMessage"
+	getDirectPinnedMessagesRoute  = userRouteHead + "/pinnedMessages"
 
 	groupRouteHead = "/groupInfo"
 
@@ -35,10 +55,107 @@ const (
 	addAdministratorRoute        = groupRouteHead + "/addAdministrator"
 	deleteAdministratorRoute     = groupRouteHead + "/deleteAdministrator"
 	quitOrDeleteMemberRoute      = groupRouteHead + "/quitOrDeleteMember"
+	getGroupRolesRoute           = groupRouteHead + "/roles"
+	saveGroupRoleRoute           = groupRouteHead + "/saveRole"
+	deleteGroupRoleRoute         = groupRouteHead + "/deleteRole"
+	setGroupMemberRoleRoute      = groupRouteHead + "/setMemberRole"
+	muteAllMembersRoute          = groupRouteHead + "/muteAll"
+	unmuteAllMembersRoute        = groupRouteHead + "/unmuteAll"
+	muteGroupMemberRoute         = groupRouteHead + "/muteMember"
+	unmuteGroupMemberRoute       = groupRouteHead + "/unmuteMember"
+	transferGroupOwnershipRoute  = groupRouteHead + "/transferOwnership"
+	updateSuccessionPolicyRoute  = groupRouteHead + "/updateSuccessionPolicy"
+	updateJoinPolicyRoute        = groupRouteHead + "/updateJoinPolicy"
+	updateGroupRetentionRoute    = groupRouteHead + "/updateRetentionPolicy"
+	inviteGroupMemberRoute       = groupRouteHead + "/inviteMember"
+	acceptGroupInvitationRoute   = groupRouteHead + "/acceptInvitation"
+	refuseGroupInvitationRoute   = groupRouteHead + "/refuseInvitation"
+	createInviteLinkRoute        = groupRouteHead + "/createInviteLink"
+	getInviteLinksRoute          = groupRouteHead + "/inviteLinks"
+	revokeInviteLinkRoute        = groupRouteHead + "/revokeInviteLink"
+	redeemInviteLinkRoute        = groupRouteHead + "/redeemInviteLink"
+	publishAnnouncementRoute     = groupRouteHead + "/publishAnnouncement"
+	acknowledgeAnnouncementRoute = groupRouteHead + "/acknowledgeAnnouncement"
+	pinMessageRoute              = groupRouteHead + "/pinMessage"
+	unpinMessageRoute            = groupRouteHead + "/unpinMessage"
+
+	messageRouteHead = "/message"
+
+	searchMessageRoute = messageRouteHead + "/search"
+
+	pushRouteHead = "/push"
+
+	registerPushDeviceRoute   = pushRouteHead + "/registerDevice"
+	unregisterPushDeviceRoute = pushRouteHead + "/unregisterDevice"
+	getPushSettingRoute       = pushRouteHead + "/setting"
+	updatePushSettingRoute    = pushRouteHead + "/updateSetting"
+	mutePushOfChatRoute       = pushRouteHead + "/muteChat"
+	unmutePushOfChatRoute     = pushRouteHead + "/unmuteChat"
+
+	webhookRouteHead = "/webhook"
+
+	createWebhookSubscriptionRoute = webhookRouteHead + "/createSubscription"
+	deleteWebhookSubscriptionRoute = webhookRouteHead + "/deleteSubscription"
+	getWebhookSubscriptionsRoute   = webhookRouteHead + "/subscriptions"
+	getWebhookDeliveriesRoute      = webhookRouteHead + "/deliveries"
+	replayWebhookDeliveryRoute     = webhookRouteHead + "/replayDelivery"
+
+	exportRouteHead = "/export"
+
+	createChatExportRoute       = exportRouteHead + "/chat"
+	createAccountExportRoute    = exportRouteHead + "/account"
+	getExportJobsRoute          = exportRouteHead + "/jobs"
+	getExportJobRoute           = exportRouteHead + "/job"
+	downloadExportRoute         = exportRouteHead + "/download"
+	operatorChatExportRoute     = exportRouteHead + "/operator/chat"
+	operatorGetExportJobRoute   = exportRouteHead + "/operator/job"
+	operatorDownloadExportRoute = exportRouteHead + "/operator/download"
+
+	keyRouteHead = "/keys"
+
+	uploadDeviceKeyRoute       = keyRouteHead + "/upload"
+	claimPreKeyBundleRoute     = keyRouteHead + "/bundle"
+	getOneTimePreKeyCountRoute = keyRouteHead + "/count"
+	removeDeviceKeyRoute       = keyRouteHead + "/removeDevice"
+
+	reportRouteHead = "/report"
+
+	submitReportRoute      = reportRouteHead + "/submit"
+	operatorReportsRoute   = reportRouteHead + "/operator/reports"
+	operatorReportRoute    = reportRouteHead + "/operator/report"
+	operatorReviewRoute    = reportRouteHead + "/operator/review"
+	operatorAuditLogsRoute = reportRouteHead + "/operator/auditLogs"
+
+	adminRouteHead = "/admin"
+
+	adminUserRoute          = adminRouteHead + "/user"
+	adminGroupRoute         = adminRouteHead + "/group"
+	adminConnectionsRoute   = adminRouteHead + "/connections"
+	adminForceLogoutRoute   = adminRouteHead + "/forceLogout"
+	adminBanRoute           = adminRouteHead + "/ban"
+	adminUnbanRoute         = adminRouteHead + "/unban"
+	adminDissolveGroupRoute = adminRouteHead + "/dissolveGroup"
+	adminAnnounceRoute      = adminRouteHead + "/announce"
+
+	botRouteHead = "/bot"
+
+	createBotRoute                   = botRouteHead + "/createBot"
+	resetBotApiKeyRoute              = botRouteHead + "/resetApiKey"
+	setBotWebhookRoute               = botRouteHead + "/setWebhook"
+	addBotToGroupRoute               = botRouteHead + "/addToGroup"
+	sendBotMessageRoute              = botRouteHead + "/sendMessage"
+	botApproveFriendApplicationRoute = botRouteHead + "/approveFriendApplication"
+	pollBotInboxRoute                = botRouteHead + "/poll"
 )
 
-func InitHttpServer(addresses []string) {
+// InitHttpServer 监听 addresses 中的每一个地址，tlsConfig 不为 nil 时全部使用 HTTPS
+func InitHttpServer(addresses, trustedProxies []string, tlsConfig *tls.Config) {
 	httpServer = gin.Default()
+	// gin 默认信任全部代理，客户端可以伪造 X-Forwarded-For 绕过按 IP 的限流
+	if err := httpServer.SetTrustedProxies(trustedProxies); err != nil {
+		panic(err)
+	}
+	httpServer.Use(originPolicyMiddleware, rateLimitMiddleware)
 	httpServer.GET(loginRoute, loginHandler)
 	httpServer.GET(registerRoute, registerHandler)
 	httpServer.GET(getUserInfoRoute, getUserInfoHandler)
@@ -49,6 +166,24 @@ func InitHttpServer(addresses []string) {
 	httpServer.GET(approveFriendApplicationRoute, approveFriendshipApplicationHandler)
 	httpServer.GET(refuseFriendApplicationRoute, refuseFriendshipApplicationHandler)
 	httpServer.GET(deleteFriendRoute, deleteFriendHandler)
+	httpServer.GET(getMentionsRoute, getMentionsHandler)
+	httpServer.GET(getMentionCountsRoute, getMentionCountsHandler)
+	httpServer.GET(readMentionsRoute, readMentionsHandler)
+	httpServer.GET(deleteAccountRoute, deleteAccountHandler)
+	httpServer.GET(blockUserRoute, blockUserHandler)
+	httpServer.GET(unblockUserRoute, unblockUserHandler)
+	httpServer.GET(getBlocklistRoute, getBlocklistHandler)
+	httpServer.POST(updateFriendSettingRoute, updateFriendSettingHandler)
+	httpServer.GET(createContactGroupRoute, createContactGroupHandler)
+	httpServer.GET(renameContactGroupRoute, renameContactGroupHandler)
+	httpServer.GET(deleteContactGroupRoute, deleteContactGroupHandler)
+	httpServer.GET(searchUserRoute, searchUserHandler)
+	httpServer.GET(updateDiscoverableByRoute, updateDiscoverableByHandler)
+	httpServer.GET(updateAddFriendPolicyRoute, updateAddFriendPolicyHandler)
+	httpServer.GET(updateUserRetentionRoute, updateUserRetentionPolicyHandler)
+	httpServer.GET(pinDirectMessageRoute, pinDirectMessageHandler)
+	httpServer.GET(unpinDirectMessageRoute, unpinDirectMessageHandler)
+	httpServer.GET(getDirectPinnedMessagesRoute, getDirectPinnedMessagesHandler)
 	httpServer.GET(getGroupInfoRoute, getGroupInfoHandler)
 	httpServer.POST(createGroupRoute, createGroupHandler)
 	httpServer.GET(deleteGroupRoute, deleteGroupHandler)
@@ -61,9 +196,92 @@ func InitHttpServer(addresses []string) {
 	httpServer.GET(addAdministratorRoute, addAdministratorHandler)
 	httpServer.GET(deleteAdministratorRoute, deleteAdministratorHandler)
 	httpServer.GET(quitOrDeleteMemberRoute, quitOrDeleteMemberHandler)
+	httpServer.GET(getGroupRolesRoute, getGroupRolesHandler)
+	httpServer.GET(saveGroupRoleRoute, saveGroupRoleHandler)
+	httpServer.GET(deleteGroupRoleRoute, deleteGroupRoleHandler)
+	httpServer.GET(setGroupMemberRoleRoute, setGroupMemberRoleHandler)
+	httpServer.GET(muteAllMembersRoute, muteAllMembersHandler)
+	httpServer.GET(unmuteAllMembersRoute, unmuteAllMembersHandler)
+	httpServer.GET(muteGroupMemberRoute, muteGroupMemberHandler)
+	httpServer.GET(unmuteGroupMemberRoute, unmuteGroupMemberHandler)
+	httpServer.GET(transferGroupOwnershipRoute, transferGroupOwnershipHandler)
+	httpServer.GET(updateSuccessionPolicyRoute, updateSuccessionPolicyHandler)
+	httpServer.GET(updateJoinPolicyRoute, updateJoinPolicyHandler)
+	httpServer.GET(updateGroupRetentionRoute, updateGroupRetentionPolicyHandler)
+	httpServer.GET(inviteGroupMemberRoute, inviteGroupMemberHandler)
+	httpServer.GET(acceptGroupInvitationRoute, acceptGroupInvitationHandler)
+	httpServer.GET(refuseGroupInvitationRoute, refuseGroupInvitationHandler)
+	httpServer.GET(createInviteLinkRoute, createInviteLinkHandler)
+	httpServer.GET(getInviteLinksRoute, getInviteLinksHandler)
+	httpServer.GET(revokeInviteLinkRoute, revokeInviteLinkHandler)
+	httpServer.GET(redeemInviteLinkRoute, redeemInviteLinkHandler)
+	httpServer.POST(publishAnnouncementRoute, publishAnnouncementHandler)
+	httpServer.GET(acknowledgeAnnouncementRoute, acknowledgeAnnouncementHandler)
+	httpServer.GET(pinMessageRoute, pinMessageHandler)
+	httpServer.GET(unpinMessageRoute, unpinMessageHandler)
+	httpServer.GET(searchMessageRoute, searchMessageHandler)
+	httpServer.GET(registerPushDeviceRoute, registerPushDeviceHandler)
+	httpServer.GET(unregisterPushDeviceRoute, unregisterPushDeviceHandler)
+	httpServer.GET(getPushSettingRoute, getPushSettingHandler)
+	httpServer.POST(updatePushSettingRoute, updatePushSettingHandler)
+	httpServer.GET(mutePushOfChatRoute, mutePushOfChatHandler)
+	httpServer.GET(unmutePushOfChatRoute, unmutePushOfChatHandler)
+	httpServer.POST(createWebhookSubscriptionRoute, createWebhookSubscriptionHandler)
+	httpServer.GET(deleteWebhookSubscriptionRoute, deleteWebhookSubscriptionHandler)
+	httpServer.GET(getWebhookSubscriptionsRoute, getWebhookSubscriptionsHandler)
+	httpServer.GET(getWebhookDeliveriesRoute, getWebhookDeliveriesHandler)
+	httpServer.GET(replayWebhookDeliveryRoute, replayWebhookDeliveryHandler)
+	httpServer.GET(createChatExportRoute, createChatExportHandler)
+	httpServer.GET(createAccountExportRoute, createAccountExportHandler)
+	httpServer.GET(getExportJobsRoute, getExportJobsHandler)
+	httpServer.GET(getExportJobRoute, getExportJobHandler)
+	httpServer.GET(downloadExportRoute, downloadExportHandler)
+	httpServer.GET(operatorChatExportRoute, operatorChatExportHandler)
+	httpServer.GET(operatorGetExportJobRoute, operatorGetExportJobHandler)
+	httpServer.GET(operatorDownloadExportRoute, operatorDownloadExportHandler)
+	httpServer.POST(uploadDeviceKeyRoute, uploadDeviceKeyHandler)
+	httpServer.GET(claimPreKeyBundleRoute, claimPreKeyBundleHandler)
+	httpServer.GET(getOneTimePreKeyCountRoute, getOneTimePreKeyCountHandler)
+	httpServer.GET(removeDeviceKeyRoute, removeDeviceKeyHandler)
+	httpServer.POST(submitReportRoute, submitReportHandler)
+	httpServer.GET(operatorReportsRoute, operatorReportsHandler)
+	httpServer.GET(operatorReportRoute, operatorReportHandler)
+	httpServer.POST(operatorReviewRoute, operatorReviewHandler)
+	httpServer.GET(operatorAuditLogsRoute, operatorAuditLogsHandler)
+	httpServer.GET(adminUserRoute, adminUserHandler)
+	httpServer.GET(adminGroupRoute, adminGroupHandler)
+	httpServer.GET(adminConnectionsRoute, adminConnectionsHandler)
+	httpServer.GET(adminForceLogoutRoute, adminForceLogoutHandler)
+	httpServer.GET(adminBanRoute, adminBanHandler)
+	httpServer.GET(adminUnbanRoute, adminUnbanHandler)
+	httpServer.GET(adminDissolveGroupRoute, adminDissolveGroupHandler)
+	httpServer.POST(adminAnnounceRoute, adminAnnounceHandler)
+	httpServer.GET(createBotRoute, createBotHandler)
+	httpServer.GET(resetBotApiKeyRoute, resetBotApiKeyHandler)
+	httpServer.GET(setBotWebhookRoute, setBotWebhookHandler)
+	httpServer.GET(addBotToGroupRoute, addBotToGroupHandler)
+	httpServer.POST(sendBotMessageRoute, sendBotMessageHandler)
+	httpServer.GET(botApproveFriendApplicationRoute, botApproveFriendApplicationHandler)
+	httpServer.GET(pollBotInboxRoute, pollBotInboxHandler)
 
-	err := httpServer.Run(addresses...)
-	if err != nil {
-		panic(err)
+	if len(addresses) == 0 {
+		if err := httpServer.Run(); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	// gin 的 Run 只接受一个地址，每个监听地址单独启动一个 Server，任一地址退出时整个服务退出
+	errs := make(chan error, len(addresses))
+	for _, address := range addresses {
+		server := &nethttp.Server{Addr: address, Handler: httpServer, TLSConfig: tlsConfig}
+		go func() {
+			if tlsConfig != nil {
+				errs <- server.ListenAndServeTLS("", "")
+			} else {
+				errs <- server.ListenAndServe()
+			}
+		}()
 	}
+	panic(<-errs)
 }
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"liveChat/controllers"
+)
+
+const (
+	operatorKeyHeaderParam = "x-operator-key"
+
+	operatorIdKey = "operatorId"
+)
+
+var (
+	operatorKeys [][]byte
+	// operatorIds 为各密钥的指纹，用于在审计记录中区分操作者而不暴露密钥
+	operatorIds []string
+)
+
+// InitOperatorKeys 设置运维接口可用的密钥，未设置时所有运维接口均拒绝访问
+func InitOperatorKeys(keys []string) {
+	operatorKeys = make([][]byte, 0, len(keys))
+	operatorIds = make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key != "" {
+			sum := sha256.Sum256([]byte(key))
+			operatorKeys = append(operatorKeys, []byte(key))
+			operatorIds = append(operatorIds, hex.EncodeToString(sum[:6]))
+		}
+	}
+}
+
+func validateOperatorKey(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	key := []byte(ctx.Ctx.(*gin.Context).GetHeader(operatorKeyHeaderParam))
+	if len(key) != 0 {
+		for i, operatorKey := range operatorKeys {
+			if subtle.ConstantTimeCompare(key, operatorKey) == 1 {
+				ctx.Param[operatorIdKey] = operatorIds[i]
+				return
+			}
+		}
+	}
+
+	retBuf, err = errorHandlerHook(OperatorKeyInvalid, "运维密钥无效")
+	return
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	greetingParam        = "message"
+	addFriendPolicyParam = "policy"
+
+	greetingKey        = "greeting"
+	addFriendPolicyKey = "addFriendPolicy"
+)
+
+const maxGreetingLength = 100
+
+// getGreetingFromUrl 读取好友申请或入群申请附带的验证消息，验证消息可以为空
+func getGreetingFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	greeting := strings.TrimSpace(ctx.Ctx.(*gin.Context).Query(greetingParam))
+	if utf8.RuneCountInString(greeting) > maxGreetingLength {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "验证消息过长")
+		return
+	}
+
+	ctx.Param[greetingKey] = greeting
+	return
+}
+
+func getAddFriendPolicyFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	policy, retBuf, err := getInt64ParamFromURL(ctx, addFriendPolicyParam, "缺少好友申请规则", LackOfParameter)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if policy < 0 || !entities.IsValidAddFriendPolicy(byte(policy)) {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "好友申请规则不合法")
+		return
+	}
+
+	ctx.Param[addFriendPolicyKey] = byte(policy)
+	return
+}
+
+func updateAddFriendPolicy(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		policy = ctx.Param[addFriendPolicyKey].(byte)
+	)
+
+	if err = db.UpdateUserAddFriendPolicy(nil, userId, policy); err == db.MysqlErrorUserNotExist {
+		retBuf, err = errorHandlerHook(UserNotFound, "用户不存在")
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
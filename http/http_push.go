@@ -0,0 +1,194 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"liveChat/push"
+)
+
+const (
+	pushPlatformParam = "platform"
+	pushProviderParam = "provider"
+	pushTokenParam    = "pushToken"
+
+	pushPlatformKey = "pushPlatform"
+	pushProviderKey = "pushProvider"
+	pushTokenKey    = "pushToken"
+	pushSettingKey  = "pushSetting"
+)
+
+const minutesOfDay = 24 * 60
+
+type PushSettingBody struct {
+	ResponseHeader
+	entities.PushSetting
+}
+
+type pushSettingForm struct {
+	IsDisabled     bool `json:"is_disabled"`
+	IsDndEnabled   bool `json:"is_dnd_enabled"`
+	DndStartMinute int  `json:"dnd_start_minute"`
+	DndEndMinute   int  `json:"dnd_end_minute"`
+	TimezoneOffset int  `json:"timezone_offset"`
+}
+
+func getPushDeviceFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	platform, retBuf, err := getOptionalInt64ParamFromURL(ctx, pushPlatformParam, 0)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	provider, retBuf, err := getParamFromURL(ctx, pushProviderParam, "缺少推送通道", LackOfParameter)
+	if len(retBuf) != 0 || err != nil {
+		return
+	} else if !push.HasProvider(provider) {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "推送通道不存在或未启用")
+		return
+	}
+
+	token, retBuf, err := getParamFromURL(ctx, pushTokenParam, "缺少推送设备令牌", LackOfParameter)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	ctx.Param[pushPlatformKey] = int(platform)
+	ctx.Param[pushProviderKey] = provider
+	ctx.Param[pushTokenKey] = token
+	return
+}
+
+func getPushTokenFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	token, retBuf, err := getParamFromURL(ctx, pushTokenParam, "缺少推送设备令牌", LackOfParameter)
+	if len(retBuf) == 0 && err == nil {
+		ctx.Param[pushTokenKey] = token
+	}
+	return
+}
+
+func getPushSettingPostInBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	ginCtx := ctx.Ctx.(*gin.Context)
+
+	form := &pushSettingForm{}
+	err = ginCtx.BindJSON(form)
+	if err != nil {
+		retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "Json 表单解析错误")
+		return
+	}
+
+	if form.DndStartMinute < 0 || form.DndStartMinute >= minutesOfDay || form.DndEndMinute < 0 || form.DndEndMinute >= minutesOfDay {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "免打扰时段超出范围")
+		return
+	} else if form.TimezoneOffset < -12*60 || form.TimezoneOffset > 14*60 {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "时区偏移超出范围")
+		return
+	}
+
+	ctx.Param[pushSettingKey] = form
+	return
+}
+
+func registerPushDevice(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		platform = ctx.Param[pushPlatformKey].(int)
+		provider = ctx.Param[pushProviderKey].(string)
+		token    = ctx.Param[pushTokenKey].(string)
+	)
+
+	if err = db.RegisterPushDevice(nil, entities.NewPushDevice(userId, platform, provider, token)); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
+
+func unregisterPushDevice(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		token  = ctx.Param[pushTokenKey].(string)
+	)
+
+	err = db.UnregisterPushDevice(nil, userId, token)
+	if errors.Is(err, db.MysqlErrorNoLine) {
+		retBuf, err = errorHandlerHook(IllegalRequest, "推送设备不存在")
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
+
+func updatePushSetting(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		form   = ctx.Param[pushSettingKey].(*pushSettingForm)
+	)
+
+	return modifyPushSetting(userId, func(setting *entities.PushSetting) {
+		setting.IsDisabled = form.IsDisabled
+		setting.IsDndEnabled = form.IsDndEnabled
+		setting.DndStartMinute = form.DndStartMinute
+		setting.DndEndMinute = form.DndEndMinute
+		setting.TimezoneOffset = form.TimezoneOffset
+	})
+}
+
+func mutePushOfChat(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		chatId = ctx.Param[chatIdKey].(int64)
+	)
+
+	return modifyPushSetting(userId, func(setting *entities.PushSetting) {
+		if !setting.IsChatMuted(chatId) {
+			setting.MutedChats = append(setting.MutedChats, chatId)
+		}
+	})
+}
+
+func unmutePushOfChat(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		chatId = ctx.Param[chatIdKey].(int64)
+	)
+
+	return modifyPushSetting(userId, func(setting *entities.PushSetting) {
+		chats := make([]int64, 0, len(setting.MutedChats))
+		for _, id := range setting.MutedChats {
+			if id != chatId {
+				chats = append(chats, id)
+			}
+		}
+		setting.MutedChats = chats
+	})
+}
+
+func returnPushSettingBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	userId := ctx.Param[userIdFromTokenKey].(int64)
+
+	setting, err := db.GetPushSetting(nil, userId)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&PushSettingBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		PushSetting:    *setting,
+	}).MarshalJSON()
+}
+
+func modifyPushSetting(userId int64, fn func(setting *entities.PushSetting)) (retBuf []byte, err error) {
+	setting, err := db.GetPushSetting(nil, userId)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	fn(setting)
+	if err = db.SavePushSetting(nil, setting); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
@@ -0,0 +1,267 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonA4888625DecodeLiveChatHttp(in *jlexer.Lexer, out *pushSettingForm) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "is_disabled":
+			out.IsDisabled = bool(in.Bool())
+		case "is_dnd_enabled":
+			out.IsDndEnabled = bool(in.Bool())
+		case "dnd_start_minute":
+			out.DndStartMinute = int(in.Int())
+		case "dnd_end_minute":
+			out.DndEndMinute = int(in.Int())
+		case "timezone_offset":
+			out.TimezoneOffset = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA4888625EncodeLiveChatHttp(out *jwriter.Writer, in pushSettingForm) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"is_disabled\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.IsDisabled))
+	}
+	{
+		const prefix string = ",\"is_dnd_enabled\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsDndEnabled))
+	}
+	{
+		const prefix string = ",\"dnd_start_minute\":"
+		out.RawString(prefix)
+		out.Int(int(in.DndStartMinute))
+	}
+	{
+		const prefix string = ",\"dnd_end_minute\":"
+		out.RawString(prefix)
+		out.Int(int(in.DndEndMinute))
+	}
+	{
+		const prefix string = ",\"timezone_offset\":"
+		out.RawString(prefix)
+		out.Int(int(in.TimezoneOffset))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v pushSettingForm) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA4888625EncodeLiveChatHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v pushSettingForm) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA4888625EncodeLiveChatHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *pushSettingForm) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA4888625DecodeLiveChatHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *pushSettingForm) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA4888625DecodeLiveChatHttp(l, v)
+}
+func easyjsonA4888625DecodeLiveChatHttp1(in *jlexer.Lexer, out *PushSettingBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "userId":
+			out.UserId = int64(in.Int64())
+		case "isDisabled":
+			out.IsDisabled = bool(in.Bool())
+		case "isDndEnabled":
+			out.IsDndEnabled = bool(in.Bool())
+		case "dndStartMinute":
+			out.DndStartMinute = int(in.Int())
+		case "dndEndMinute":
+			out.DndEndMinute = int(in.Int())
+		case "timezoneOffset":
+			out.TimezoneOffset = int(in.Int())
+		case "mutedChats":
+			if in.IsNull() {
+				in.Skip()
+				out.MutedChats = nil
+			} else {
+				in.Delim('[')
+				if out.MutedChats == nil {
+					if !in.IsDelim(']') {
+						out.MutedChats = make([]int64, 0, 8)
+					} else {
+						out.MutedChats = []int64{}
+					}
+				} else {
+					out.MutedChats = (out.MutedChats)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 int64
+					v1 = int64(in.Int64())
+					out.MutedChats = append(out.MutedChats, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA4888625EncodeLiveChatHttp1(out *jwriter.Writer, in PushSettingBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"userId\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.UserId))
+	}
+	{
+		const prefix string = ",\"isDisabled\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsDisabled))
+	}
+	{
+		const prefix string = ",\"isDndEnabled\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsDndEnabled))
+	}
+	{
+		const prefix string = ",\"dndStartMinute\":"
+		out.RawString(prefix)
+		out.Int(int(in.DndStartMinute))
+	}
+	{
+		const prefix string = ",\"dndEndMinute\":"
+		out.RawString(prefix)
+		out.Int(int(in.DndEndMinute))
+	}
+	{
+		const prefix string = ",\"timezoneOffset\":"
+		out.RawString(prefix)
+		out.Int(int(in.TimezoneOffset))
+	}
+	{
+		const prefix string = ",\"mutedChats\":"
+		out.RawString(prefix)
+		if in.MutedChats == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.MutedChats {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.Int64(int64(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PushSettingBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA4888625EncodeLiveChatHttp1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PushSettingBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA4888625EncodeLiveChatHttp1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PushSettingBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA4888625DecodeLiveChatHttp1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PushSettingBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA4888625DecodeLiveChatHttp1(l, v)
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"liveChat/controllers"
+	"liveChat/log"
+	"liveChat/ratelimit"
+	"math"
+	"strconv"
+)
+
+type RateLimitedBody struct {
+	ResponseHeader
+	Banned bool `json:"banned"`
+	// RetryAfter 为建议的重试等待秒数
+	RetryAfter int64 `json:"retryAfter"`
+}
+
+// rateLimitMiddleware 以客户端 IP 与路由限流，用户维度的限流在 validateToken 之后进行
+func rateLimitMiddleware(ctx *gin.Context) {
+	decision, err := ratelimit.AllowRoute(ratelimit.IPSubject(ctx.ClientIP()), ctx.FullPath())
+	if err != nil {
+		// 限流存储不可用时放行，避免影响正常请求
+		log.Error(fmt.Sprintf("限流检查失败: %s", err.Error()))
+	} else if !decision.Allowed {
+		retBuf, _ := rateLimitedHook(ctx, ctx.ClientIP(), decision)
+		ctx.Data(Success, contentTypeJson, retBuf)
+		ctx.Abort()
+		return
+	}
+	ctx.Next()
+}
+
+func limitUserRate(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		ginCtx = ctx.Ctx.(*gin.Context)
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+	)
+
+	decision, err := ratelimit.AllowRoute(ratelimit.UserSubject(userId), ginCtx.FullPath())
+	if err != nil {
+		log.Error(fmt.Sprintf("限流检查失败: %s", err.Error()))
+		err = nil
+	} else if !decision.Allowed {
+		retBuf, err = rateLimitedHook(ginCtx, "用户 "+strconv.FormatInt(userId, 10), decision)
+	}
+	return
+}
+
+func rateLimitedHook(ctx *gin.Context, subject string, decision ratelimit.Decision) (retBuf []byte, err error) {
+	retryAfter := int64(math.Ceil(decision.RetryAfter.Seconds()))
+	reason := "请求过于频繁，请稍后再试"
+	if decision.Banned {
+		reason = "请求频率持续超限，已被暂时封禁"
+	}
+
+	log.Error(fmt.Sprintf("%s 访问 %s 被限流: %s", subject, ctx.FullPath(), reason))
+	ctx.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+	return (&RateLimitedBody{
+		ResponseHeader: ResponseHeader{TooManyRequests, reason},
+		Banned:         decision.Banned,
+		RetryAfter:     retryAfter,
+	}).MarshalJSON()
+}
@@ -0,0 +1,435 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	reportIdParam     = "reportId"
+	reportStatusParam = "status"
+
+	reportFormKey   = "reportForm"
+	reportIdKey     = "reportId"
+	reportStatusKey = "reportStatus"
+	reportKey       = "report"
+	reviewFormKey   = "reviewForm"
+)
+
+const maxReviewNoteLength = 512
+
+type ReportBody struct {
+	ResponseHeader
+	Report entities.Report `json:"report"`
+}
+
+type ReportListBody struct {
+	ResponseHeader
+	Reports    []entities.Report `json:"reports"`
+	NextCursor int64             `json:"nextCursor"`
+}
+
+type AuditLogListBody struct {
+	ResponseHeader
+	Logs       []entities.AuditLog `json:"logs"`
+	NextCursor int64               `json:"nextCursor"`
+}
+
+type reportForm struct {
+	TargetType byte   `json:"targetType"`
+	TargetId   int64  `json:"targetId"`
+	Seq        uint64 `json:"seq"`
+	Reason     string `json:"reason"`
+}
+
+// reviewForm 中 DurationSeconds 为禁言或封禁的时长，封禁时为 0 表示永久封禁
+type reviewForm struct {
+	ReportId        int64  `json:"reportId"`
+	Action          string `json:"action"`
+	DurationSeconds int64  `json:"durationSeconds"`
+	Note            string `json:"note"`
+}
+
+func getReportPostInBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	ginCtx := ctx.Ctx.(*gin.Context)
+
+	form := &reportForm{}
+	err = ginCtx.BindJSON(form)
+	if err != nil {
+		retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "Json 表单解析错误")
+		return
+	}
+
+	form.Reason = strings.TrimSpace(form.Reason)
+	if !entities.IsValidReportTarget(form.TargetType) || form.TargetId == 0 {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "举报对象无效")
+		return
+	} else if form.Reason == "" {
+		retBuf, err = errorHandlerHook(LackOfParameter, "缺少举报理由")
+		return
+	} else if utf8.RuneCountInString(form.Reason) > entities.MaxReportReasonLength {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "举报理由过长")
+		return
+	}
+
+	ctx.Param[reportFormKey] = form
+	return
+}
+
+func getReviewPostInBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	ginCtx := ctx.Ctx.(*gin.Context)
+
+	form := &reviewForm{}
+	err = ginCtx.BindJSON(form)
+	if err != nil {
+		retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "Json 表单解析错误")
+		return
+	}
+
+	if form.ReportId == 0 {
+		retBuf, err = errorHandlerHook(LackOfParameter, "缺少举报 id")
+		return
+	} else if form.Action == entities.ReviewMute && (form.DurationSeconds <= 0 || form.DurationSeconds > maxMuteDurationInSecond) {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "禁言时长超出范围")
+		return
+	} else if form.Action == entities.ReviewBan && form.DurationSeconds < 0 {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "封禁时长超出范围")
+		return
+	} else if utf8.RuneCountInString(form.Note) > maxReviewNoteLength {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "备注过长")
+		return
+	}
+
+	ctx.Param[reviewFormKey] = form
+	return
+}
+
+func getReportIdFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	id, retBuf, err := getInt64ParamFromURL(ctx, reportIdParam, "缺少举报 id", LackOfParameter)
+	if len(retBuf) == 0 && err == nil {
+		ctx.Param[reportIdKey] = id
+	}
+	return
+}
+
+// getOptionalReportIdFromUrl 查询审计记录时举报 id 可选，为 0 时返回全部记录
+func getOptionalReportIdFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	id, retBuf, err := getOptionalInt64ParamFromURL(ctx, reportIdParam, 0)
+	if len(retBuf) == 0 && err == nil {
+		ctx.Param[reportIdKey] = id
+	}
+	return
+}
+
+// getReportStatusFromUrl 未指定状态时返回待处理的举报
+func getReportStatusFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	status, retBuf, err := getOptionalInt64ParamFromURL(ctx, reportStatusParam, int64(entities.ReportPending))
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if status < int64(entities.ReportPending) || status > int64(entities.ReportActioned) {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "举报状态无效")
+		return
+	}
+
+	ctx.Param[reportStatusKey] = byte(status)
+	return
+}
+
+// checkReportTarget 检查举报对象是否存在，举报消息时保存消息的发送者与内容快照。
+// 用户只能举报自己所在群组或自己收到的私聊消息
+func checkReportTarget(userId int64, report *entities.Report) (retBuf []byte, err error) {
+	switch report.TargetType {
+	case entities.ReportMessage:
+		if report.TargetId < 0 {
+			var isIn bool
+			if isIn, err = controllers.CheckIsUserInGroup(userId, report.TargetId, false); err != nil {
+				return errorHandlerHook(InternalError, err.Error())
+			} else if !isIn {
+				return errorHandlerHook(IllegalRequest, "用户不在该会话中")
+			}
+		} else if report.TargetId != userId {
+			return errorHandlerHook(IllegalRequest, "只能举报自己收到的私聊消息")
+		}
+
+		message, err := db.GetMessageInSeq(context.Background(), report.TargetId, report.Seq)
+		if err == mongo.ErrNoDocuments {
+			return errorHandlerHook(IllegalRequest, "消息不存在")
+		} else if err != nil {
+			return errorHandlerHook(InternalError, err.Error())
+		} else if message.Sender == userId {
+			return errorHandlerHook(IllegalRequest, "不能举报自己发送的消息")
+		}
+
+		snapshot, err := message.MarshalJSON()
+		if err != nil {
+			return errorHandlerHook(InternalError, err.Error())
+		}
+		report.MessageSender, report.MessageSnapshot = message.Sender, string(snapshot)
+
+	case entities.ReportUser:
+		if report.TargetId < 0 || report.TargetId == userId {
+			return errorHandlerHook(UserParamTypeIllegal, "举报对象无效")
+		}
+		if info, err := db.SearchUserInfo(nil, report.TargetId, false); err == db.MysqlErrorUserNotExist || (err == nil && info.IsDeleted) {
+			return errorHandlerHook(UserNotFound, "用户不存在")
+		} else if err != nil {
+			return errorHandlerHook(InternalError, err.Error())
+		}
+
+	case entities.ReportGroup:
+		if report.TargetId > 0 {
+			return errorHandlerHook(UserParamTypeIllegal, "举报对象无效")
+		}
+		if info, err := db.SearchGroupInfo(nil, report.TargetId, false); err == db.MysqlErrorGroupNotExist || (err == nil && info.IsDeleted) {
+			return errorHandlerHook(GroupNotFound, "群组不存在")
+		} else if err != nil {
+			return errorHandlerHook(InternalError, err.Error())
+		}
+	}
+	return
+}
+
+func submitReport(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		form   = ctx.Param[reportFormKey].(*reportForm)
+	)
+
+	if form.TargetType != entities.ReportMessage {
+		form.Seq = 0
+	}
+	report := entities.NewReport(userId, form.TargetType, form.TargetId, form.Seq, form.Reason)
+	if retBuf, err = checkReportTarget(userId, report); len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if err = db.AddReport(nil, report); errors.Is(err, db.MysqlErrorReportDuplicated) {
+		retBuf, err = errorHandlerHook(IllegalRequest, err.Error())
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&ReportBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Report:         *report,
+	}).MarshalJSON()
+}
+
+func loadReport(ctx *controllers.ProcessContext, reportId int64) (retBuf []byte, err error) {
+	report, err := db.GetReport(nil, reportId)
+	if errors.Is(err, db.MysqlErrorReportNotExist) {
+		retBuf, err = errorHandlerHook(IllegalRequest, err.Error())
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	ctx.Param[reportKey] = report
+	return
+}
+
+func getReport(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	return loadReport(ctx, ctx.Param[reportIdKey].(int64))
+}
+
+func getReviewReport(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	return loadReport(ctx, ctx.Param[reviewFormKey].(*reviewForm).ReportId)
+}
+
+func returnReportBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	return (&ReportBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Report:         *ctx.Param[reportKey].(*entities.Report),
+	}).MarshalJSON()
+}
+
+func returnReportListBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		status = ctx.Param[reportStatusKey].(byte)
+		cursor = ctx.Param[cursorKey].(int64)
+		limit  = ctx.Param[limitKey].(int64)
+	)
+
+	reports, err := db.SelectReports(nil, status, cursor, limit)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	nextCursor := int64(0)
+	if int64(len(reports)) == limit {
+		nextCursor = reports[len(reports)-1].Id
+	}
+
+	return (&ReportListBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Reports:        reports,
+		NextCursor:     nextCursor,
+	}).MarshalJSON()
+}
+
+func returnAuditLogListBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		reportId = ctx.Param[reportIdKey].(int64)
+		cursor   = ctx.Param[cursorKey].(int64)
+		limit    = ctx.Param[limitKey].(int64)
+	)
+
+	logs, err := db.SelectAuditLogs(nil, reportId, cursor, limit)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	nextCursor := int64(0)
+	if int64(len(logs)) == limit {
+		nextCursor = logs[len(logs)-1].Id
+	}
+
+	return (&AuditLogListBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Logs:           logs,
+		NextCursor:     nextCursor,
+	}).MarshalJSON()
+}
+
+// reviewReport 执行复核操作后将举报标记为已处理，并记录审计日志。
+// 操作已经执行时即使举报被其他运维人员并发处理，也会记录本次操作
+func reviewReport(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		operatorId = ctx.Param[operatorIdKey].(string)
+		form       = ctx.Param[reviewFormKey].(*reviewForm)
+		report     = ctx.Param[reportKey].(*entities.Report)
+	)
+
+	if report.Status != entities.ReportPending {
+		retBuf, err = errorHandlerHook(IllegalRequest, db.MysqlErrorReportReviewed.Error())
+		return
+	} else if !report.AllowsAction(form.Action) {
+		retBuf, err = errorHandlerHook(IllegalRequest, "该举报不支持此操作")
+		return
+	}
+
+	detail, retBuf, err := executeReviewAction(report, form)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+	if form.Note != "" {
+		detail = strings.TrimSpace(detail + " " + form.Note)
+	}
+
+	status := entities.ReportActioned
+	if form.Action == entities.ReviewDismiss {
+		status = entities.ReportDismissed
+	}
+	reviewedAt := time.Now().UnixMilli()
+	reviewErr := db.ReviewReport(nil, report.Id, status, form.Action, reviewedAt)
+	if reviewErr != nil && !errors.Is(reviewErr, db.MysqlErrorReportReviewed) {
+		retBuf, err = errorHandlerHook(InternalError, reviewErr.Error())
+		return
+	}
+
+	if err = db.AddAuditLog(nil, entities.NewAuditLog(operatorId, form.Action, report, detail)); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	} else if reviewErr != nil {
+		retBuf, err = errorHandlerHook(IllegalRequest, reviewErr.Error())
+		return
+	}
+
+	report.Status, report.ReviewAction, report.ReviewedAt = status, form.Action, reviewedAt
+	return (&ReportBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Report:         *report,
+	}).MarshalJSON()
+}
+
+// executeReviewAction 执行复核操作，返回写入审计日志的操作详情
+func executeReviewAction(report *entities.Report, form *reviewForm) (detail string, retBuf []byte, err error) {
+	var (
+		userId   = report.ReportedUserId()
+		groupId  = report.ReportedGroupId()
+		duration = time.Duration(form.DurationSeconds) * time.Second
+	)
+
+	switch form.Action {
+	case entities.ReviewDeleteMessage:
+		err = db.DeleteMessage(context.Background(), report.TargetId, report.Seq)
+		if err == mongo.ErrNoDocuments {
+			detail, err = "消息已不存在", nil
+		} else if err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return
+		}
+		if err = db.DeleteMessageCacheInRange(report.TargetId, report.Seq, report.Seq); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+		}
+
+	case entities.ReviewMute:
+		detail = fmt.Sprintf("禁言 %d 秒", form.DurationSeconds)
+		if report.TargetType == entities.ReportGroup {
+			detail = "全体禁言"
+			retBuf, err = muteReportedGroup(groupId, 0, 0)
+		} else if groupId != 0 {
+			retBuf, err = muteReportedGroup(groupId, userId, time.Now().Add(duration).UnixMilli())
+		} else if err = db.SetUserMute(userId, duration); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+		}
+
+	case entities.ReviewBan:
+		detail, retBuf, err = banAccount(userId, form.DurationSeconds)
+
+	case entities.ReviewDissolveGroup:
+		retBuf, err = dissolveGroup(groupId)
+	}
+	return
+}
+
+// muteReportedGroup memberId 为 0 时禁言全体成员，否则在群内禁言该成员至 mutedUntil
+func muteReportedGroup(groupId, memberId, mutedUntil int64) (retBuf []byte, err error) {
+	var noti *entities.Notification
+	if db.StartDbTransaction(func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) error {
+		if memberId == 0 {
+			err = db.UpdateGroupMuteAll(mysqlTx, groupId, true)
+			noti = entities.NewNotification(groupId, groupId, entities.Mute, entities.Group, true, true)
+		} else {
+			err = db.UpdateGroupMemberMute(mysqlTx, memberId, groupId, mutedUntil)
+			noti = entities.NewNotification(memberId, groupId, entities.Mute, entities.Group, true, true)
+		}
+		if err == db.MysqlErrorGroupNotExist {
+			retBuf, err = errorHandlerHook(GroupNotFound, "群组不存在")
+			return errorAbortTransaction
+		} else if err == db.MysqlErrorUserNotExist {
+			retBuf, err = errorHandlerHook(UserNotFound, "用户不在群组中")
+			return errorAbortTransaction
+		} else if err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return errorAbortTransaction
+		}
+
+		if _, err = db.AddAndReturnNotification(mongoTx, noti); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return errorAbortTransaction
+		}
+		return nil
+	}) != nil {
+		return
+	}
+
+	return sendMuteNotification(groupId, noti)
+}
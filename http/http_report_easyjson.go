@@ -0,0 +1,729 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	entities "liveChat/entities"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson96abddebDecodeLiveChatHttp(in *jlexer.Lexer, out *reviewForm) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reportId":
+			out.ReportId = int64(in.Int64())
+		case "action":
+			out.Action = string(in.String())
+		case "durationSeconds":
+			out.DurationSeconds = int64(in.Int64())
+		case "note":
+			out.Note = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson96abddebEncodeLiveChatHttp(out *jwriter.Writer, in reviewForm) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"reportId\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ReportId))
+	}
+	{
+		const prefix string = ",\"action\":"
+		out.RawString(prefix)
+		out.String(string(in.Action))
+	}
+	{
+		const prefix string = ",\"durationSeconds\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.DurationSeconds))
+	}
+	{
+		const prefix string = ",\"note\":"
+		out.RawString(prefix)
+		out.String(string(in.Note))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v reviewForm) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson96abddebEncodeLiveChatHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v reviewForm) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson96abddebEncodeLiveChatHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *reviewForm) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson96abddebDecodeLiveChatHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *reviewForm) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson96abddebDecodeLiveChatHttp(l, v)
+}
+func easyjson96abddebDecodeLiveChatHttp1(in *jlexer.Lexer, out *reportForm) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "targetType":
+			out.TargetType = uint8(in.Uint8())
+		case "targetId":
+			out.TargetId = int64(in.Int64())
+		case "seq":
+			out.Seq = uint64(in.Uint64())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson96abddebEncodeLiveChatHttp1(out *jwriter.Writer, in reportForm) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"targetType\":"
+		out.RawString(prefix[1:])
+		out.Uint8(uint8(in.TargetType))
+	}
+	{
+		const prefix string = ",\"targetId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.TargetId))
+	}
+	{
+		const prefix string = ",\"seq\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Seq))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v reportForm) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson96abddebEncodeLiveChatHttp1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v reportForm) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson96abddebEncodeLiveChatHttp1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *reportForm) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson96abddebDecodeLiveChatHttp1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *reportForm) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson96abddebDecodeLiveChatHttp1(l, v)
+}
+func easyjson96abddebDecodeLiveChatHttp2(in *jlexer.Lexer, out *ReportListBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reports":
+			if in.IsNull() {
+				in.Skip()
+				out.Reports = nil
+			} else {
+				in.Delim('[')
+				if out.Reports == nil {
+					if !in.IsDelim(']') {
+						out.Reports = make([]entities.Report, 0, 0)
+					} else {
+						out.Reports = []entities.Report{}
+					}
+				} else {
+					out.Reports = (out.Reports)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 entities.Report
+					easyjson96abddebDecodeLiveChatEntities(in, &v1)
+					out.Reports = append(out.Reports, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "nextCursor":
+			out.NextCursor = int64(in.Int64())
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson96abddebEncodeLiveChatHttp2(out *jwriter.Writer, in ReportListBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"reports\":"
+		out.RawString(prefix[1:])
+		if in.Reports == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Reports {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				easyjson96abddebEncodeLiveChatEntities(out, v3)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"nextCursor\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.NextCursor))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReportListBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson96abddebEncodeLiveChatHttp2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReportListBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson96abddebEncodeLiveChatHttp2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReportListBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson96abddebDecodeLiveChatHttp2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReportListBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson96abddebDecodeLiveChatHttp2(l, v)
+}
+func easyjson96abddebDecodeLiveChatEntities(in *jlexer.Lexer, out *entities.Report) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.Id = int64(in.Int64())
+		case "reporterId":
+			out.ReporterId = int64(in.Int64())
+		case "targetType":
+			out.TargetType = uint8(in.Uint8())
+		case "targetId":
+			out.TargetId = int64(in.Int64())
+		case "seq":
+			out.Seq = uint64(in.Uint64())
+		case "messageSender":
+			out.MessageSender = int64(in.Int64())
+		case "messageSnapshot":
+			out.MessageSnapshot = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		case "status":
+			out.Status = uint8(in.Uint8())
+		case "reviewAction":
+			out.ReviewAction = string(in.String())
+		case "createdAt":
+			out.CreatedAt = int64(in.Int64())
+		case "reviewedAt":
+			out.ReviewedAt = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson96abddebEncodeLiveChatEntities(out *jwriter.Writer, in entities.Report) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Id))
+	}
+	{
+		const prefix string = ",\"reporterId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ReporterId))
+	}
+	{
+		const prefix string = ",\"targetType\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.TargetType))
+	}
+	{
+		const prefix string = ",\"targetId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.TargetId))
+	}
+	{
+		const prefix string = ",\"seq\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Seq))
+	}
+	{
+		const prefix string = ",\"messageSender\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MessageSender))
+	}
+	{
+		const prefix string = ",\"messageSnapshot\":"
+		out.RawString(prefix)
+		out.String(string(in.MessageSnapshot))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.Status))
+	}
+	{
+		const prefix string = ",\"reviewAction\":"
+		out.RawString(prefix)
+		out.String(string(in.ReviewAction))
+	}
+	{
+		const prefix string = ",\"createdAt\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CreatedAt))
+	}
+	{
+		const prefix string = ",\"reviewedAt\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ReviewedAt))
+	}
+	out.RawByte('}')
+}
+func easyjson96abddebDecodeLiveChatHttp3(in *jlexer.Lexer, out *ReportBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "report":
+			easyjson96abddebDecodeLiveChatEntities(in, &out.Report)
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson96abddebEncodeLiveChatHttp3(out *jwriter.Writer, in ReportBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"report\":"
+		out.RawString(prefix[1:])
+		easyjson96abddebEncodeLiveChatEntities(out, in.Report)
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReportBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson96abddebEncodeLiveChatHttp3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReportBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson96abddebEncodeLiveChatHttp3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReportBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson96abddebDecodeLiveChatHttp3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReportBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson96abddebDecodeLiveChatHttp3(l, v)
+}
+func easyjson96abddebDecodeLiveChatHttp4(in *jlexer.Lexer, out *AuditLogListBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "logs":
+			if in.IsNull() {
+				in.Skip()
+				out.Logs = nil
+			} else {
+				in.Delim('[')
+				if out.Logs == nil {
+					if !in.IsDelim(']') {
+						out.Logs = make([]entities.AuditLog, 0, 0)
+					} else {
+						out.Logs = []entities.AuditLog{}
+					}
+				} else {
+					out.Logs = (out.Logs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 entities.AuditLog
+					easyjson96abddebDecodeLiveChatEntities1(in, &v4)
+					out.Logs = append(out.Logs, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "nextCursor":
+			out.NextCursor = int64(in.Int64())
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson96abddebEncodeLiveChatHttp4(out *jwriter.Writer, in AuditLogListBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"logs\":"
+		out.RawString(prefix[1:])
+		if in.Logs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Logs {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				easyjson96abddebEncodeLiveChatEntities1(out, v6)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"nextCursor\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.NextCursor))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuditLogListBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson96abddebEncodeLiveChatHttp4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuditLogListBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson96abddebEncodeLiveChatHttp4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuditLogListBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson96abddebDecodeLiveChatHttp4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuditLogListBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson96abddebDecodeLiveChatHttp4(l, v)
+}
+func easyjson96abddebDecodeLiveChatEntities1(in *jlexer.Lexer, out *entities.AuditLog) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.Id = int64(in.Int64())
+		case "operator":
+			out.Operator = string(in.String())
+		case "action":
+			out.Action = string(in.String())
+		case "reportId":
+			out.ReportId = int64(in.Int64())
+		case "targetType":
+			out.TargetType = uint8(in.Uint8())
+		case "targetId":
+			out.TargetId = int64(in.Int64())
+		case "seq":
+			out.Seq = uint64(in.Uint64())
+		case "detail":
+			out.Detail = string(in.String())
+		case "createdAt":
+			out.CreatedAt = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson96abddebEncodeLiveChatEntities1(out *jwriter.Writer, in entities.AuditLog) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Id))
+	}
+	{
+		const prefix string = ",\"operator\":"
+		out.RawString(prefix)
+		out.String(string(in.Operator))
+	}
+	{
+		const prefix string = ",\"action\":"
+		out.RawString(prefix)
+		out.String(string(in.Action))
+	}
+	{
+		const prefix string = ",\"reportId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ReportId))
+	}
+	{
+		const prefix string = ",\"targetType\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.TargetType))
+	}
+	{
+		const prefix string = ",\"targetId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.TargetId))
+	}
+	{
+		const prefix string = ",\"seq\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Seq))
+	}
+	{
+		const prefix string = ",\"detail\":"
+		out.RawString(prefix)
+		out.String(string(in.Detail))
+	}
+	{
+		const prefix string = ",\"createdAt\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CreatedAt))
+	}
+	out.RawByte('}')
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+)
+
+const (
+	keepDaysParam   = "keepDays"
+	keepLastParam   = "keepLast"
+	useDefaultParam = "useDefault"
+
+	retentionPolicyKey = "retentionPolicy"
+)
+
+// getRetentionPolicyFromUrl 读取消息保留策略，useDefault 为真时恢复为全局默认策略
+func getRetentionPolicyFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	useDefault, retBuf, err := getOptionalBoolParamFromURL(ctx, useDefaultParam, false)
+	if len(retBuf) != 0 || err != nil {
+		return
+	} else if useDefault {
+		ctx.Param[retentionPolicyKey] = (*entities.RetentionPolicy)(nil)
+		return
+	}
+
+	keepDays, retBuf, err := getOptionalInt64ParamFromURL(ctx, keepDaysParam, 0)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	keepLast, retBuf, err := getOptionalInt64ParamFromURL(ctx, keepLastParam, 0)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	policy := &entities.RetentionPolicy{KeepDays: keepDays, KeepLast: keepLast}
+	if !policy.IsValid() {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "保留策略不合法")
+		return
+	}
+
+	ctx.Param[retentionPolicyKey] = policy
+	return
+}
+
+func updateGroupRetentionPolicy(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		groupId = ctx.Param[groupIdKey].(int64)
+		policy  = ctx.Param[retentionPolicyKey].(*entities.RetentionPolicy)
+		isOwner = ctx.Param[groupMemberIsOwnerKey].(bool)
+	)
+
+	if !isOwner {
+		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+		return
+	}
+
+	if err = db.UpdateGroupRetentionPolicy(nil, groupId, policy); err == db.MysqlErrorGroupNotExist {
+		retBuf, err = errorHandlerHook(GroupNotFound, "群组不存在")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	if err = controllers.RefreshGroupInfoCache(groupId); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
+
+func updateUserRetentionPolicy(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		policy = ctx.Param[retentionPolicyKey].(*entities.RetentionPolicy)
+	)
+
+	if err = db.UpdateUserRetentionPolicy(nil, userId, policy); err == db.MysqlErrorUserNotExist {
+		retBuf, err = errorHandlerHook(UserNotFound, "用户不存在")
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"context"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"liveChat/search"
+	"strings"
+)
+
+const (
+	keywordParam  = "keyword"
+	senderParam   = "sender"
+	fromTimeParam = "from"
+	toTimeParam   = "to"
+
+	keywordKey  = "keyword"
+	senderKey   = "sender"
+	fromTimeKey = "from"
+	toTimeKey   = "to"
+)
+
+type SearchHit struct {
+	ChatId    int64                `json:"chatId"`
+	Seq       uint64               `json:"seq"`
+	Sender    int64                `json:"sender"`
+	Timestamp uint64               `json:"timestamp"`
+	Type      entities.ContentType `json:"type"`
+	Content   string               `json:"content"`
+	Highlight string               `json:"highlight"`
+}
+
+type SearchResultBody struct {
+	ResponseHeader
+	Hits       []SearchHit `json:"hits"`
+	NextCursor int64       `json:"nextCursor"`
+}
+
+func getKeywordFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	keyword, retBuf, err := getParamFromURL(ctx, keywordParam, "缺少检索关键词", LackOfParameter)
+	if len(retBuf) == 0 && err == nil {
+		if keyword = strings.TrimSpace(keyword); keyword == "" {
+			retBuf, err = errorHandlerHook(LackOfParameter, "缺少检索关键词")
+			return
+		}
+		ctx.Param[keywordKey] = keyword
+	}
+	return
+}
+
+func getSearchFilterFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	for _, entry := range []struct{ param, key string }{
+		{senderParam, senderKey},
+		{fromTimeParam, fromTimeKey},
+		{toTimeParam, toTimeKey},
+	} {
+		var value int64
+		value, retBuf, err = getOptionalInt64ParamFromURL(ctx, entry.param, 0)
+		if len(retBuf) != 0 || err != nil {
+			return
+		}
+		ctx.Param[entry.key] = value
+	}
+	return
+}
+
+func returnSearchResultBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId = ctx.Param[userIdFromTokenKey].(int64)
+		chatId = ctx.Param[chatIdKey].(int64)
+		query  = &search.Query{
+			MessageFilter: db.MessageFilter{
+				Keyword: ctx.Param[keywordKey].(string),
+				UserId:  userId,
+				ChatId:  chatId,
+				Sender:  ctx.Param[senderKey].(int64),
+				From:    uint64(ctx.Param[fromTimeKey].(int64)),
+				To:      uint64(ctx.Param[toTimeKey].(int64)),
+			},
+			Cursor: ctx.Param[cursorKey].(int64),
+			Limit:  ctx.Param[limitKey].(int64),
+		}
+	)
+
+	if chatId < 0 {
+		var flag bool
+		if flag, err = controllers.CheckIsUserInGroup(userId, chatId, false); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return
+		} else if !flag {
+			retBuf, err = errorHandlerHook(IllegalRequest, "用户不在群组中")
+			return
+		}
+	} else if chatId == 0 {
+		var groups []entities.GroupMember
+		if groups, err = db.SelectGroupInfoForUser(nil, userId); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return
+		}
+		for _, group := range groups {
+			query.GroupIds = append(query.GroupIds, group.GroupId)
+		}
+	}
+
+	hits, nextCursor, err := search.SearchMessages(context.Background(), query)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	body := &SearchResultBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Hits:           make([]SearchHit, len(hits), len(hits)),
+		NextCursor:     nextCursor,
+	}
+	for i, hit := range hits {
+		body.Hits[i] = SearchHit{
+			ChatId:    hit.Message.Receiver,
+			Seq:       hit.Message.Id,
+			Sender:    hit.Message.Sender,
+			Timestamp: hit.Message.Timestamp,
+			Type:      hit.Message.Type,
+			Content:   hit.Message.Content,
+			Highlight: hit.Highlight,
+		}
+	}
+	return body.MarshalJSON()
+}
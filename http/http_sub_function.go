@@ -10,6 +10,7 @@ import (
 	"liveChat/db"
 	"liveChat/entities"
 	"liveChat/log"
+	"liveChat/webhook"
 	"strconv"
 	"time"
 )
@@ -31,6 +32,11 @@ const (
 
 	notificationSeqParam = "seq"
 
+	chatIdParam = "chatId"
+	seqParam    = "seq"
+	cursorParam = "cursor"
+	limitParam  = "limit"
+
 	tokenHeaderParam = "x-custom-token"
 )
 
@@ -50,11 +56,15 @@ const (
 	groupIntroductionKey = "groupIntroduction"
 	groupAvatarKey       = "groupAvatar"
 
-	groupMemberIsOwnerKey = "groupMemberIsOwner"
-	groupMemberIsAuthKey  = "groupMemberIsAuth"
-	groupMemberIsInKey    = "groupMemberIsIn"
+	groupInfoKey             = "groupInfo"
+	groupMemberIsOwnerKey    = "groupMemberIsOwner"
+	groupMemberPermissionKey = "groupMemberPermission"
+	groupMemberIsInKey       = "groupMemberIsIn"
 
 	chatIdKey = "chatId"
+	seqKey    = "seq"
+	cursorKey = "cursor"
+	limitKey  = "limit"
 
 	notificationSeqKey      = "notificationSeq"
 	notificationReceiverKey = "notificationReceiver"
@@ -67,6 +77,11 @@ const (
 	contentTypeJson = "application/json"
 )
 
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+)
+
 const (
 	Success = 200
 
@@ -79,6 +94,10 @@ const (
 	GroupNotFound                = 425
 	GroupOpNoAuth                = 426
 	IllegalRequest               = 427
+	OperatorKeyInvalid           = 428
+	TooManyRequests              = 429
+	ContentBlocked               = 430
+	AccountBanned                = 431
 	InternalError                = 500
 )
 
@@ -109,6 +128,9 @@ type UserInfoBody struct {
 type GroupInfoBody struct {
 	ResponseHeader
 	entities.GroupInfo
+	Announcement               *entities.GroupAnnouncement `json:"announcement"`
+	IsAnnouncementAcknowledged bool                        `json:"isAnnouncementAcknowledged"`
+	PinnedMessages             []entities.PinnedMessage    `json:"pinnedMessages"`
 }
 
 type FriendshipBody struct {
@@ -229,9 +251,9 @@ func getGroupAvatarFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err
 }
 
 func getNotificationSeqFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
-	seq, retBuf, err := getParamFromURL(ctx, notificationSeqParam, "缺少需要确认的通知序号", LackOfParameter)
+	seq, retBuf, err := getInt64ParamFromURL(ctx, notificationSeqParam, "缺少需要确认的通知序号", LackOfParameter)
 	if len(retBuf) == 0 && err == nil {
-		ctx.Param[notificationSeqKey] = seq
+		ctx.Param[notificationSeqKey] = uint64(seq)
 	}
 	return
 }
@@ -307,6 +329,14 @@ func getUserIdByAccountAndPassword(ctx *controllers.ProcessContext) (retBuf []by
 		return
 	}
 
+	bannedUntil, err := db.GetUserBannedUntil(nil, userId)
+	if err != nil {
+		return
+	} else if bannedUntil > time.Now().UnixMilli() {
+		retBuf, err = errorHandlerHook(AccountBanned, "账号已被封禁")
+		return
+	}
+
 	ctx.Param[userIdKey] = userId
 	return
 }
@@ -333,7 +363,7 @@ func validateToken(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
 	}
 
 	ctx.Param[userIdFromTokenKey] = tokenUserId
-	return
+	return limitUserRate(ctx)
 }
 
 func validateRegisterInfo(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
@@ -385,14 +415,33 @@ func rejectRequestFromOneSelf(ctx *controllers.ProcessContext) (retBuf []byte, e
 }
 
 func sendAddFriendNotificationToOther(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
-	noti := entities.NewNotification(ctx.Param[userIdFromTokenKey].(int64),
-		ctx.Param[friendIdKey].(int64),
-		entities.Add,
-		entities.Friend,
-		false,
-		false)
-
-	noti, err = db.AddAndReturnNotification(context.Background(), noti)
+	var (
+		userId   = ctx.Param[userIdFromTokenKey].(int64)
+		friendId = ctx.Param[friendIdKey].(int64)
+		greeting = ctx.Param[greetingKey].(string)
+	)
+
+	info, err := db.SearchUserInfo(nil, friendId, false)
+	if err == db.MysqlErrorUserNotExist || (err == nil && info.IsDeleted) {
+		retBuf, err = errorHandlerHook(UserNotFound, "目标用户不存在")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	switch info.AddFriendPolicy {
+	case entities.AddFriendNobody:
+		retBuf, err = errorHandlerHook(IllegalRequest, "对方不允许添加好友")
+		return
+	case entities.AddFriendDirectly:
+		return addFriendWithoutApproval(userId, friendId, greeting)
+	}
+
+	noti := entities.NewNotification(userId, friendId, entities.Add, entities.Friend, false, false)
+	noti.Message = greeting
+
+	noti, err = db.AddOrRefreshPendingNotification(context.Background(), noti)
 	if err != nil {
 		retBuf, err = errorHandlerHook(InternalError, err.Error())
 		return
@@ -402,6 +451,43 @@ func sendAddFriendNotificationToOther(ctx *controllers.ProcessContext) (retBuf [
 	return
 }
 
+// addFriendWithoutApproval 对方允许直接添加好友时建立好友关系，并向双方下发已处理的申请与同意通知
+func addFriendWithoutApproval(userId, friendId int64, greeting string) (retBuf []byte, err error) {
+	var notifications []*entities.Notification
+	if db.StartDbTransaction(func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) error {
+		if _, err = db.AgreeFriendShip(mysqlTx, userId, friendId); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return errorAbortTransaction
+		}
+
+		addNoti := entities.NewNotification(userId, friendId, entities.Add, entities.Friend, true, true)
+		addNoti.HandleUserId = friendId
+		addNoti.Message = greeting
+		approveNoti := entities.NewNotification(friendId, userId, entities.Approve, entities.Friend, true, true)
+		notifications = []*entities.Notification{addNoti, approveNoti}
+
+		for _, noti := range notifications {
+			if _, err = db.AddAndReturnNotification(mongoTx, noti); err != nil {
+				retBuf, err = errorHandlerHook(InternalError, err.Error())
+				return errorAbortTransaction
+			}
+		}
+		return nil
+	}) != nil {
+		return
+	}
+
+	if _, err = controllers.CheckAreUsersFriend(userId, friendId, true); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	for _, noti := range notifications {
+		SendNotification(noti)
+	}
+	return
+}
+
 func sendDeleteFriendNotificationToOther(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
 	var (
 		selfId   = ctx.Param[userIdFromTokenKey].(int64)
@@ -457,6 +543,9 @@ func approveFriendRequest(ctx *controllers.ProcessContext) (retBuf []byte, err e
 		if err == db.MongoErrorNoNotification {
 			retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "无相关通知")
 			return err
+		} else if err == db.MongoErrorNotificationExpired {
+			retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "申请已过期")
+			return err
 		} else if err != nil {
 			retBuf, err = errorHandlerHook(InternalError, err.Error())
 			return err
@@ -500,6 +589,9 @@ func refuseFriendRequest(ctx *controllers.ProcessContext) (retBuf []byte, err er
 		if err == db.MongoErrorNoNotification {
 			retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "无相关通知")
 			return err
+		} else if err == db.MongoErrorNotificationExpired {
+			retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "申请已过期")
+			return err
 		} else if err != nil {
 			retBuf, err = errorHandlerHook(InternalError, err.Error())
 			return err
@@ -573,13 +665,35 @@ func getGroupInfoBody(ctx *controllers.ProcessContext) (retBuf []byte, err error
 	if err == db.MysqlErrorGroupNotExist {
 		retBuf, err = errorHandlerHook(GroupNotFound, "群组不存在")
 		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
 	}
 
-	err = (&GroupInfoBody{
+	body := &GroupInfoBody{
 		ResponseHeader: ResponseHeader{Success, ""},
 		GroupInfo:      *info,
-	}).UnmarshalJSON(retBuf)
-	return
+	}
+
+	// 公告与置顶消息只对群组成员可见
+	if flag {
+		if body.Announcement, err = db.GetLatestGroupAnnouncement(nil, groupId); err == nil {
+			body.IsAnnouncementAcknowledged, err = db.IsGroupAnnouncementAcknowledged(nil, body.Announcement.Id, userId)
+		} else if err == db.MysqlErrorNoLine {
+			err = nil
+		}
+		if err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return
+		}
+
+		if body.PinnedMessages, err = db.GetPinnedMessages(context.Background(), groupId); err != nil {
+			retBuf, err = errorHandlerHook(InternalError, err.Error())
+			return
+		}
+	}
+
+	return body.MarshalJSON()
 }
 
 func createGroup(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
@@ -597,6 +711,7 @@ func createGroup(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
 	}
 
 	ctx.Param[groupIdKey] = groupId
+	webhook.Publish(webhook.EventGroupCreated, groupId, &webhook.GroupData{GroupId: groupId, OperatorId: userId})
 	return
 }
 
@@ -609,6 +724,7 @@ func checkGroupAuth(ctx *controllers.ProcessContext) (retBuf []byte, err error)
 	info, err := db.SearchGroupInfo(nil, groupId, true)
 	if err == db.MysqlErrorGroupNotExist {
 		retBuf, err = errorHandlerHook(GroupNotFound, "群组不存在")
+		return
 	} else if err != nil {
 		retBuf, err = errorHandlerHook(InternalError, err.Error())
 		return
@@ -619,21 +735,19 @@ func checkGroupAuth(ctx *controllers.ProcessContext) (retBuf []byte, err error)
 		return
 	}
 
+	permissions, isIn := info.PermissionsOf(userId)
+	ctx.Param[groupInfoKey] = info
 	ctx.Param[groupMemberIsOwnerKey] = info.Owner == userId
-	ctx.Param[groupMemberIsInKey] = false
-
-	for _, member := range info.Members {
-		if member.MemberId == userId {
-			ctx.Param[groupMemberIsAuthKey] = member.IsAdministrator
-			ctx.Param[groupMemberIsInKey] = true
-			return
-		}
-	}
-
-	ctx.Param[groupMemberIsAuthKey] = false
+	ctx.Param[groupMemberIsInKey] = isIn
+	ctx.Param[groupMemberPermissionKey] = permissions
 	return
 }
 
+// hasGroupPermission 需在 checkGroupAuth 之后调用
+func hasGroupPermission(ctx *controllers.ProcessContext, permission entities.GroupPermission) bool {
+	return ctx.Param[groupMemberPermissionKey].(entities.GroupPermission).Has(permission)
+}
+
 func deleteGroup(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
 	var (
 		userId  = ctx.Param[userIdFromTokenKey].(int64)
@@ -643,35 +757,67 @@ func deleteGroup(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
 
 	if !isOwner {
 		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
+		return
 	}
 
-	db.StartDbTransaction(func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) error {
-		err = db.DeleteGroupInfo(mysqlTx, groupId)
-		if err != nil {
-			retBuf, err = errorHandlerHook(InternalError, err.Error())
-			return err
-		}
-
-		_, err = controllers.CheckIsUserInGroup(userId, groupId, true)
-		if err != nil {
+	var notifications []*entities.Notification
+	if db.StartDbTransaction(func(mysqlTx *gorm.DB, mongoTx mongo.SessionContext) error {
+		if notifications, err = dissolveGroupInTx(mysqlTx, mongoTx, groupId, userId); err != nil {
 			retBuf, err = errorHandlerHook(InternalError, err.Error())
-			return err
+			return errorAbortTransaction
 		}
-
 		return nil
-	})
+	}) != nil {
+		return
+	}
 
+	if _, err = controllers.CheckIsUserInGroup(userId, groupId, true); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	for _, noti := range notifications {
+		SendNotification(noti)
+	}
+	webhook.Publish(webhook.EventGroupDeleted, groupId, &webhook.GroupData{GroupId: groupId, OperatorId: userId})
 	return
 }
 
+// dissolveGroupInTx 在事务中解散群组，并为解散前的每个成员写入由群组发出的解散通知，事务提交后由调用方下发。
+// 解散后群组已没有成员，因此不能使用下发给全体成员的群组通知
+func dissolveGroupInTx(mysqlTx *gorm.DB, mongoTx mongo.SessionContext, groupId, operatorId int64) ([]*entities.Notification, error) {
+	info, err := db.SearchGroupInfo(mysqlTx, groupId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.DeleteGroupInfo(mysqlTx, groupId); err != nil {
+		return nil, err
+	}
+
+	notifications := make([]*entities.Notification, 0, len(info.Members))
+	for _, member := range info.Members {
+		if member.IsDeleted {
+			continue
+		}
+
+		noti := entities.NewNotification(groupId, member.MemberId, entities.Delete, entities.Group, true, true)
+		noti.HandleUserId = operatorId
+		if noti, err = db.AddAndReturnNotification(mongoTx, noti); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, noti)
+	}
+	return notifications, nil
+}
+
 func updateGroupName(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
 	var (
 		groupId   = ctx.Param[groupIdKey].(int64)
 		groupName = ctx.Param[groupNameKey].(string)
-		isAuth    = ctx.Param[groupMemberIsAuthKey].(bool)
 	)
 
-	if !isAuth {
+	if !hasGroupPermission(ctx, entities.PermissionEditGroupInfo) {
 		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
 		return
 	}
@@ -687,10 +833,9 @@ func updateGroupIntroduction(ctx *controllers.ProcessContext) (retBuf []byte, er
 	var (
 		groupId           = ctx.Param[groupIdKey].(int64)
 		groupIntroduction = ctx.Param[groupIntroductionKey].(string)
-		isAuth            = ctx.Param[groupMemberIsAuthKey].(bool)
 	)
 
-	if !isAuth {
+	if !hasGroupPermission(ctx, entities.PermissionEditGroupInfo) {
 		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
 		return
 	}
@@ -706,10 +851,9 @@ func updateGroupAvatar(ctx *controllers.ProcessContext) (retBuf []byte, err erro
 	var (
 		groupId     = ctx.Param[groupIdKey].(int64)
 		groupAvatar = ctx.Param[groupAvatarKey].(string)
-		isAuth      = ctx.Param[groupMemberIsAuthKey].(bool)
 	)
 
-	if !isAuth {
+	if !hasGroupPermission(ctx, entities.PermissionEditGroupInfo) {
 		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
 		return
 	}
@@ -726,6 +870,7 @@ func sendJoinGroupNotification(ctx *controllers.ProcessContext) (retBuf []byte,
 		isIn    = ctx.Param[groupMemberIsInKey].(bool)
 		userId  = ctx.Param[userIdFromTokenKey].(int64)
 		groupId = ctx.Param[groupIdKey].(int64)
+		info    = ctx.Param[groupInfoKey].(*entities.GroupInfo)
 	)
 
 	if isIn {
@@ -733,15 +878,7 @@ func sendJoinGroupNotification(ctx *controllers.ProcessContext) (retBuf []byte,
 		return
 	}
 
-	noti := entities.NewNotification(userId, groupId, entities.Add, entities.Group, false, false)
-	noti, err = db.AddAndReturnNotification(context.Background(), noti)
-	if err != nil {
-		retBuf, err = errorHandlerHook(InternalError, err.Error())
-		return
-	}
-
-	SendNotification(noti)
-	return
+	return joinGroupByPolicy(info.JoinActionFor(0), userId, groupId, userId, ctx.Param[greetingKey].(string), nil)
 }
 
 func approveJoinGroupRequest(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
@@ -749,11 +886,10 @@ func approveJoinGroupRequest(ctx *controllers.ProcessContext) (retBuf []byte, er
 		userId                         = ctx.Param[userIdFromTokenKey].(int64)
 		groupId                        = ctx.Param[notificationReceiverKey].(int64)
 		seq                            = ctx.Param[notificationSeqKey].(uint64)
-		isAuth                         = ctx.Param[groupMemberIsAuthKey].(bool)
 		noti    *entities.Notification = nil
 	)
 
-	if !isAuth {
+	if !hasGroupPermission(ctx, entities.PermissionApproveJoin) {
 		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
 		return
 	}
@@ -763,6 +899,9 @@ func approveJoinGroupRequest(ctx *controllers.ProcessContext) (retBuf []byte, er
 		if err == db.MongoErrorNoNotification {
 			retBuf, err = errorHandlerHook(IllegalRequest, "无相关通知")
 			return err
+		} else if err == db.MongoErrorNotificationExpired {
+			retBuf, err = errorHandlerHook(IllegalRequest, "申请已过期")
+			return err
 		} else if err != nil {
 			retBuf, err = errorHandlerHook(InternalError, err.Error())
 			return err
@@ -798,10 +937,9 @@ func refuseJoinGroupRequest(ctx *controllers.ProcessContext) (retBuf []byte, err
 		userId  = ctx.Param[userIdFromTokenKey].(int64)
 		groupId = ctx.Param[notificationReceiverKey].(int64)
 		seq     = ctx.Param[notificationSeqKey].(uint64)
-		isAuth  = ctx.Param[groupMemberIsAuthKey].(bool)
 	)
 
-	if !isAuth {
+	if !hasGroupPermission(ctx, entities.PermissionApproveJoin) {
 		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
 		return
 	}
@@ -812,6 +950,9 @@ func refuseJoinGroupRequest(ctx *controllers.ProcessContext) (retBuf []byte, err
 		if err == db.MongoErrorNoNotification {
 			retBuf, err = errorHandlerHook(IllegalRequest, "无相关通知")
 			return err
+		} else if err == db.MongoErrorNotificationExpired {
+			retBuf, err = errorHandlerHook(IllegalRequest, "申请已过期")
+			return err
 		} else if err != nil {
 			retBuf, err = errorHandlerHook(InternalError, err.Error())
 			return err
@@ -845,11 +986,10 @@ func quitOrDeleteMemberFromGroup(ctx *controllers.ProcessContext) (retBuf []byte
 		userId   = ctx.Param[userIdFromTokenKey].(int64)
 		groupId  = ctx.Param[groupIdKey].(int64)
 		friendId = ctx.Param[friendIdKey].(int64)
-		isAuth   = ctx.Param[groupMemberIsAuthKey].(bool)
 		isSame   = ctx.Param[isSameUserKey].(bool)
 	)
 
-	if !isAuth && !isSame {
+	if !isSame && !hasGroupPermission(ctx, entities.PermissionRemoveMember) {
 		retBuf, err = errorHandlerHook(IllegalRequest, "非法操作")
 		return
 	}
@@ -896,10 +1036,9 @@ func addOrDeleteAdministrator(ctx *controllers.ProcessContext, isAdd bool) (retB
 		userId   = ctx.Param[userIdFromTokenKey].(int64)
 		groupId  = ctx.Param[groupIdKey].(int64)
 		friendId = ctx.Param[friendIdKey].(int64)
-		isOwner  = ctx.Param[groupMemberIsOwnerKey].(bool)
 	)
 
-	if !isOwner {
+	if !hasGroupPermission(ctx, entities.PermissionManageRoles) {
 		retBuf, err = errorHandlerHook(GroupOpNoAuth, "无权限")
 		return
 	}
@@ -959,8 +1098,7 @@ func addOrDeleteAdministrator(ctx *controllers.ProcessContext, isAdd bool) (retB
 }
 
 func returnSuccessBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
-	err = (&SuccessBody{ResponseHeader{Success, ""}}).UnmarshalJSON(retBuf)
-	return
+	return (&SuccessBody{ResponseHeader{Success, ""}}).MarshalJSON()
 }
 
 func returnUserInfoBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
@@ -977,11 +1115,10 @@ func returnUserInfoBody(ctx *controllers.ProcessContext) (retBuf []byte, err err
 		return
 	}
 
-	err = (&UserInfoBody{
+	return (&UserInfoBody{
 		ResponseHeader: ResponseHeader{Success, ""},
 		UserInfo:       *info,
-	}).UnmarshalJSON(retBuf)
-	return
+	}).MarshalJSON()
 }
 
 func returnFriendshipBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
@@ -991,7 +1128,7 @@ func returnFriendshipBody(ctx *controllers.ProcessContext) (retBuf []byte, err e
 		chatId   = ctx.Param[chatIdKey].(int64)
 	)
 
-	err = (&FriendshipBody{
+	return (&FriendshipBody{
 		ResponseHeader: ResponseHeader{Success, ""},
 		Friendship: entities.Friendship{
 			GormModel: gorm.Model{},
@@ -1000,8 +1137,7 @@ func returnFriendshipBody(ctx *controllers.ProcessContext) (retBuf []byte, err e
 			IsDeleted: false,
 			ChatId:    chatId,
 		},
-	}).UnmarshalJSON(retBuf)
-	return
+	}).MarshalJSON()
 }
 
 func returnGroupInfoBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
@@ -1013,7 +1149,7 @@ func returnGroupInfoBody(ctx *controllers.ProcessContext) (retBuf []byte, err er
 		userId            = ctx.Param[userIdFromTokenKey].(int64)
 	)
 
-	err = (&GroupInfoBody{
+	return (&GroupInfoBody{
 		ResponseHeader: ResponseHeader{Success, ""},
 		GroupInfo: entities.GroupInfo{
 			Id:           groupId,
@@ -1029,11 +1165,11 @@ func returnGroupInfoBody(ctx *controllers.ProcessContext) (retBuf []byte, err er
 				GroupId:         groupId,
 				MemberId:        userId,
 				IsAdministrator: true,
+				Role:            entities.RoleOwner,
 				IsDeleted:       false,
 			}},
 		},
-	}).UnmarshalJSON(retBuf)
-	return
+	}).MarshalJSON()
 }
 
 func returnRegisterOrLoginBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
@@ -1050,6 +1186,50 @@ func returnRegisterOrLoginBody(ctx *controllers.ProcessContext) (retBuf []byte,
 	return
 }
 
+func getChatIdFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	chatId, retBuf, err := getInt64ParamFromURL(ctx, chatIdParam, "缺少目标会话 id", LackOfParameter)
+	if len(retBuf) == 0 && err == nil {
+		ctx.Param[chatIdKey] = chatId
+	}
+	return
+}
+
+func getOptionalChatIdFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	chatId, retBuf, err := getOptionalInt64ParamFromURL(ctx, chatIdParam, 0)
+	if len(retBuf) == 0 && err == nil {
+		ctx.Param[chatIdKey] = chatId
+	}
+	return
+}
+
+func getSeqFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	seq, retBuf, err := getInt64ParamFromURL(ctx, seqParam, "缺少消息序号", LackOfParameter)
+	if len(retBuf) == 0 && err == nil {
+		ctx.Param[seqKey] = uint64(seq)
+	}
+	return
+}
+
+func getPageFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	cursor, retBuf, err := getOptionalInt64ParamFromURL(ctx, cursorParam, 0)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	limit, retBuf, err := getOptionalInt64ParamFromURL(ctx, limitParam, defaultPageLimit)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if limit <= 0 || limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	ctx.Param[cursorKey] = cursor
+	ctx.Param[limitKey] = limit
+	return
+}
+
 func getParamFromURL(ctx *controllers.ProcessContext, queryName, errorInfo string, errorStatus int32) (param string, retBuf []byte, err error) {
 	ginCtx := ctx.Ctx.(*gin.Context)
 	param = ginCtx.Query(queryName)
@@ -1063,7 +1243,7 @@ func getParamFromURL(ctx *controllers.ProcessContext, queryName, errorInfo strin
 func getInt64ParamFromURL(ctx *controllers.ProcessContext, queryName, errorInfo string, errorStatus int32) (param int64, retBuf []byte, err error) {
 	tmp := ""
 	tmp, retBuf, err = getParamFromURL(ctx, queryName, errorInfo, errorStatus)
-	if err != nil {
+	if len(retBuf) != 0 || err != nil {
 		return
 	}
 
@@ -1074,15 +1254,41 @@ func getInt64ParamFromURL(ctx *controllers.ProcessContext, queryName, errorInfo
 	return
 }
 
+func getOptionalInt64ParamFromURL(ctx *controllers.ProcessContext, queryName string, defaultValue int64) (param int64, retBuf []byte, err error) {
+	tmp := ctx.Ctx.(*gin.Context).Query(queryName)
+	if tmp == "" {
+		return defaultValue, nil, nil
+	}
+
+	param, err = strconv.ParseInt(tmp, 10, 64)
+	if err != nil {
+		retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "整数转换无效")
+	}
+	return
+}
+
+func getOptionalBoolParamFromURL(ctx *controllers.ProcessContext, queryName string, defaultValue bool) (param bool, retBuf []byte, err error) {
+	tmp := ctx.Ctx.(*gin.Context).Query(queryName)
+	if tmp == "" {
+		return defaultValue, nil, nil
+	}
+
+	param, err = strconv.ParseBool(tmp)
+	if err != nil {
+		retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "布尔值转换无效")
+	}
+	return
+}
+
 func errorHandlerHook(statusCode int32, reason string) (retBuf []byte, err error) {
 	log.Error(fmt.Sprintf("处理客户端请求错误。状态码: %d, 错误原因: %s", statusCode, reason))
 	if statusCode == InternalError {
 		reason = "服务器内部错误"
 	}
 
-	err = (&FailBody{
+	retBuf, err = (&FailBody{
 		ResponseHeader: ResponseHeader{statusCode, reason},
-	}).UnmarshalJSON(retBuf)
+	}).MarshalJSON()
 	if err != nil {
 		log.Error(fmt.Sprintf("序列化错误消息发绳错误: %s", err.Error()))
 	}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"unicode/utf8"
+)
+
+const (
+	discoverableByParam = "discoverableBy"
+
+	discoverableByKey = "discoverableBy"
+)
+
+const (
+	minUserSearchPrefixLength = 2
+	maxUserSearchLimit        = 20
+)
+
+type UserSearchHit struct {
+	Id           int64  `json:"id"`
+	Username     string `json:"username"`
+	Avatar       string `json:"avatar"`
+	Introduction string `json:"introduction"`
+	IsBot        bool   `json:"isBot"`
+}
+
+type UserSearchBody struct {
+	ResponseHeader
+	Users      []UserSearchHit `json:"users"`
+	NextCursor int64           `json:"nextCursor"`
+}
+
+func getDiscoverableByFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	discoverableBy, retBuf, err := getInt64ParamFromURL(ctx, discoverableByParam, "缺少可被搜索的范围", LackOfParameter)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	if discoverableBy < 0 || !entities.IsValidDiscoverableBy(byte(discoverableBy)) {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "可被搜索的范围不合法")
+		return
+	}
+
+	ctx.Param[discoverableByKey] = byte(discoverableBy)
+	return
+}
+
+func updateDiscoverableBy(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		userId         = ctx.Param[userIdFromTokenKey].(int64)
+		discoverableBy = ctx.Param[discoverableByKey].(byte)
+	)
+
+	if err = db.UpdateUserDiscoverableBy(nil, userId, discoverableBy); err == db.MysqlErrorUserNotExist {
+		retBuf, err = errorHandlerHook(UserNotFound, "用户不存在")
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+	}
+	return
+}
+
+func returnUserSearchBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		keyword = ctx.Param[keywordKey].(string)
+		cursor  = ctx.Param[cursorKey].(int64)
+		limit   = ctx.Param[limitKey].(int64)
+	)
+
+	if utf8.RuneCountInString(keyword) < minUserSearchPrefixLength {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "检索关键词过短")
+		return
+	} else if limit > maxUserSearchLimit {
+		limit = maxUserSearchLimit
+	}
+
+	users, err := db.SearchUsers(nil, keyword, cursor, limit)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	hits := make([]UserSearchHit, 0, len(users))
+	for _, user := range users {
+		hits = append(hits, UserSearchHit{
+			Id:           user.Id,
+			Username:     user.Username,
+			Avatar:       user.UserAvatar,
+			Introduction: user.UserIntroduction,
+			IsBot:        user.IsBot,
+		})
+	}
+
+	var nextCursor int64
+	if int64(len(hits)) == limit {
+		nextCursor = hits[len(hits)-1].Id
+	}
+
+	return (&UserSearchBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Users:          hits,
+		NextCursor:     nextCursor,
+	}).MarshalJSON()
+}
@@ -0,0 +1,218 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"liveChat/controllers"
+	"liveChat/db"
+	"liveChat/entities"
+	"liveChat/webhook"
+	"net/url"
+)
+
+const (
+	subscriptionIdParam = "subscriptionId"
+	deliveryIdParam     = "deliveryId"
+	deliveryStatusParam = "status"
+
+	subscriptionIdKey = "subscriptionId"
+	subscriptionKey   = "subscription"
+	deliveryIdKey     = "deliveryId"
+	deliveryStatusKey = "deliveryStatus"
+)
+
+type WebhookSubscriptionBody struct {
+	ResponseHeader
+	entities.WebhookSubscription
+}
+
+type WebhookSubscriptionListBody struct {
+	ResponseHeader
+	Subscriptions []entities.WebhookSubscription `json:"subscriptions"`
+}
+
+type WebhookDeliveryBody struct {
+	ResponseHeader
+	Delivery entities.WebhookDelivery `json:"delivery"`
+}
+
+type WebhookDeliveryListBody struct {
+	ResponseHeader
+	Deliveries []entities.WebhookDelivery `json:"deliveries"`
+	NextCursor int64                      `json:"nextCursor"`
+}
+
+type webhookSubscriptionForm struct {
+	Url     string   `json:"url"`
+	Secret  string   `json:"secret"`
+	Events  []string `json:"events"`
+	ChatIds []int64  `json:"chat_ids"`
+}
+
+func getWebhookSubscriptionPostInBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	ginCtx := ctx.Ctx.(*gin.Context)
+
+	form := &webhookSubscriptionForm{}
+	err = ginCtx.BindJSON(form)
+	if err != nil {
+		retBuf, err = errorHandlerHook(IllegalRequestFromMismatched, "Json 表单解析错误")
+		return
+	}
+
+	if u, e := url.Parse(form.Url); e != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "回调地址无效")
+		return
+	} else if form.Secret == "" {
+		retBuf, err = errorHandlerHook(LackOfParameter, "缺少签名密钥")
+		return
+	}
+
+	for _, event := range form.Events {
+		if !webhook.IsValidEventType(event) {
+			retBuf, err = errorHandlerHook(UserParamTypeIllegal, "未知的事件类型: "+event)
+			return
+		}
+	}
+
+	ctx.Param[subscriptionKey] = entities.NewWebhookSubscription(form.Url, form.Secret, form.Events, form.ChatIds)
+	return
+}
+
+func getSubscriptionIdFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	id, retBuf, err := getInt64ParamFromURL(ctx, subscriptionIdParam, "缺少订阅 id", LackOfParameter)
+	if len(retBuf) == 0 && err == nil {
+		ctx.Param[subscriptionIdKey] = id
+	}
+	return
+}
+
+func getDeliveryIdFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	id, retBuf, err := getParamFromURL(ctx, deliveryIdParam, "缺少投递记录 id", LackOfParameter)
+	if len(retBuf) == 0 && err == nil {
+		ctx.Param[deliveryIdKey] = id
+	}
+	return
+}
+
+func getDeliveryFilterFromUrl(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	subscriptionId, retBuf, err := getOptionalInt64ParamFromURL(ctx, subscriptionIdParam, 0)
+	if len(retBuf) != 0 || err != nil {
+		return
+	}
+
+	status := ctx.Ctx.(*gin.Context).Query(deliveryStatusParam)
+	switch status {
+	case "", entities.WebhookDeliveryPending, entities.WebhookDeliverySucceeded, entities.WebhookDeliveryDead:
+	default:
+		retBuf, err = errorHandlerHook(UserParamTypeIllegal, "投递状态无效")
+		return
+	}
+
+	ctx.Param[subscriptionIdKey] = subscriptionId
+	ctx.Param[deliveryStatusKey] = status
+	return
+}
+
+func createWebhookSubscription(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	subscription := ctx.Param[subscriptionKey].(*entities.WebhookSubscription)
+
+	if err = db.AddWebhookSubscription(nil, subscription); err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+	invalidateWebhookSubscriptions()
+
+	return (&WebhookSubscriptionBody{
+		ResponseHeader:      ResponseHeader{Success, ""},
+		WebhookSubscription: *subscription,
+	}).MarshalJSON()
+}
+
+func deleteWebhookSubscription(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	id := ctx.Param[subscriptionIdKey].(int64)
+
+	err = db.DeleteWebhookSubscription(nil, id)
+	if errors.Is(err, db.MysqlErrorNoLine) {
+		retBuf, err = errorHandlerHook(IllegalRequest, "订阅不存在")
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	invalidateWebhookSubscriptions()
+	return
+}
+
+func replayWebhookDelivery(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	id := ctx.Param[deliveryIdKey].(string)
+
+	dispatcher := webhook.GetDispatcher()
+	if dispatcher == nil {
+		retBuf, err = errorHandlerHook(InternalError, "webhook 未初始化")
+		return
+	}
+
+	delivery, err := dispatcher.Replay(id)
+	if errors.Is(err, db.MongoErrorNoDelivery) {
+		retBuf, err = errorHandlerHook(IllegalRequest, "投递记录不存在")
+		return
+	} else if errors.Is(err, webhook.ErrorDeliveryPending) {
+		retBuf, err = errorHandlerHook(IllegalRequest, err.Error())
+		return
+	} else if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&WebhookDeliveryBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Delivery:       *delivery,
+	}).MarshalJSON()
+}
+
+func returnWebhookSubscriptionListBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	subscriptions, err := db.SelectWebhookSubscriptions(nil)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	return (&WebhookSubscriptionListBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Subscriptions:  subscriptions,
+	}).MarshalJSON()
+}
+
+func returnWebhookDeliveryListBody(ctx *controllers.ProcessContext) (retBuf []byte, err error) {
+	var (
+		subscriptionId = ctx.Param[subscriptionIdKey].(int64)
+		status         = ctx.Param[deliveryStatusKey].(string)
+		cursor         = ctx.Param[cursorKey].(int64)
+		limit          = ctx.Param[limitKey].(int64)
+	)
+
+	deliveries, err := db.GetWebhookDeliveries(context.Background(), status, subscriptionId, cursor, limit)
+	if err != nil {
+		retBuf, err = errorHandlerHook(InternalError, err.Error())
+		return
+	}
+
+	nextCursor := int64(0)
+	if int64(len(deliveries)) == limit {
+		nextCursor = cursor + limit
+	}
+
+	return (&WebhookDeliveryListBody{
+		ResponseHeader: ResponseHeader{Success, ""},
+		Deliveries:     deliveries,
+		NextCursor:     nextCursor,
+	}).MarshalJSON()
+}
+
+func invalidateWebhookSubscriptions() {
+	if dispatcher := webhook.GetDispatcher(); dispatcher != nil {
+		dispatcher.InvalidateSubscriptions()
+	}
+}
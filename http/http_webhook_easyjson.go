@@ -0,0 +1,893 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	entities "liveChat/entities"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonBab2b7f2DecodeLiveChatHttp(in *jlexer.Lexer, out *webhookSubscriptionForm) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "url":
+			out.Url = string(in.String())
+		case "secret":
+			out.Secret = string(in.String())
+		case "events":
+			if in.IsNull() {
+				in.Skip()
+				out.Events = nil
+			} else {
+				in.Delim('[')
+				if out.Events == nil {
+					if !in.IsDelim(']') {
+						out.Events = make([]string, 0, 4)
+					} else {
+						out.Events = []string{}
+					}
+				} else {
+					out.Events = (out.Events)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.Events = append(out.Events, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "chat_ids":
+			if in.IsNull() {
+				in.Skip()
+				out.ChatIds = nil
+			} else {
+				in.Delim('[')
+				if out.ChatIds == nil {
+					if !in.IsDelim(']') {
+						out.ChatIds = make([]int64, 0, 8)
+					} else {
+						out.ChatIds = []int64{}
+					}
+				} else {
+					out.ChatIds = (out.ChatIds)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 int64
+					v2 = int64(in.Int64())
+					out.ChatIds = append(out.ChatIds, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonBab2b7f2EncodeLiveChatHttp(out *jwriter.Writer, in webhookSubscriptionForm) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Url))
+	}
+	{
+		const prefix string = ",\"secret\":"
+		out.RawString(prefix)
+		out.String(string(in.Secret))
+	}
+	{
+		const prefix string = ",\"events\":"
+		out.RawString(prefix)
+		if in.Events == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v3, v4 := range in.Events {
+				if v3 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v4))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"chat_ids\":"
+		out.RawString(prefix)
+		if in.ChatIds == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.ChatIds {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.Int64(int64(v6))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v webhookSubscriptionForm) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonBab2b7f2EncodeLiveChatHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v webhookSubscriptionForm) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonBab2b7f2EncodeLiveChatHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *webhookSubscriptionForm) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonBab2b7f2DecodeLiveChatHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *webhookSubscriptionForm) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonBab2b7f2DecodeLiveChatHttp(l, v)
+}
+func easyjsonBab2b7f2DecodeLiveChatHttp1(in *jlexer.Lexer, out *WebhookSubscriptionListBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "subscriptions":
+			if in.IsNull() {
+				in.Skip()
+				out.Subscriptions = nil
+			} else {
+				in.Delim('[')
+				if out.Subscriptions == nil {
+					if !in.IsDelim(']') {
+						out.Subscriptions = make([]entities.WebhookSubscription, 0, 0)
+					} else {
+						out.Subscriptions = []entities.WebhookSubscription{}
+					}
+				} else {
+					out.Subscriptions = (out.Subscriptions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 entities.WebhookSubscription
+					easyjsonBab2b7f2DecodeLiveChatEntities(in, &v7)
+					out.Subscriptions = append(out.Subscriptions, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonBab2b7f2EncodeLiveChatHttp1(out *jwriter.Writer, in WebhookSubscriptionListBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"subscriptions\":"
+		out.RawString(prefix[1:])
+		if in.Subscriptions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Subscriptions {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonBab2b7f2EncodeLiveChatEntities(out, v9)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v WebhookSubscriptionListBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonBab2b7f2EncodeLiveChatHttp1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v WebhookSubscriptionListBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonBab2b7f2EncodeLiveChatHttp1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *WebhookSubscriptionListBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonBab2b7f2DecodeLiveChatHttp1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *WebhookSubscriptionListBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonBab2b7f2DecodeLiveChatHttp1(l, v)
+}
+func easyjsonBab2b7f2DecodeLiveChatEntities(in *jlexer.Lexer, out *entities.WebhookSubscription) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.Id = int64(in.Int64())
+		case "url":
+			out.Url = string(in.String())
+		case "events":
+			if in.IsNull() {
+				in.Skip()
+				out.Events = nil
+			} else {
+				in.Delim('[')
+				if out.Events == nil {
+					if !in.IsDelim(']') {
+						out.Events = make([]string, 0, 4)
+					} else {
+						out.Events = []string{}
+					}
+				} else {
+					out.Events = (out.Events)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 string
+					v10 = string(in.String())
+					out.Events = append(out.Events, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "chatIds":
+			if in.IsNull() {
+				in.Skip()
+				out.ChatIds = nil
+			} else {
+				in.Delim('[')
+				if out.ChatIds == nil {
+					if !in.IsDelim(']') {
+						out.ChatIds = make([]int64, 0, 8)
+					} else {
+						out.ChatIds = []int64{}
+					}
+				} else {
+					out.ChatIds = (out.ChatIds)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v11 int64
+					v11 = int64(in.Int64())
+					out.ChatIds = append(out.ChatIds, v11)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "isDisabled":
+			out.IsDisabled = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonBab2b7f2EncodeLiveChatEntities(out *jwriter.Writer, in entities.WebhookSubscription) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Id))
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.Url))
+	}
+	{
+		const prefix string = ",\"events\":"
+		out.RawString(prefix)
+		if in.Events == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v12, v13 := range in.Events {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v13))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"chatIds\":"
+		out.RawString(prefix)
+		if in.ChatIds == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v14, v15 := range in.ChatIds {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				out.Int64(int64(v15))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"isDisabled\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsDisabled))
+	}
+	out.RawByte('}')
+}
+func easyjsonBab2b7f2DecodeLiveChatHttp2(in *jlexer.Lexer, out *WebhookSubscriptionBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.Id = int64(in.Int64())
+		case "url":
+			out.Url = string(in.String())
+		case "events":
+			if in.IsNull() {
+				in.Skip()
+				out.Events = nil
+			} else {
+				in.Delim('[')
+				if out.Events == nil {
+					if !in.IsDelim(']') {
+						out.Events = make([]string, 0, 4)
+					} else {
+						out.Events = []string{}
+					}
+				} else {
+					out.Events = (out.Events)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v16 string
+					v16 = string(in.String())
+					out.Events = append(out.Events, v16)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "chatIds":
+			if in.IsNull() {
+				in.Skip()
+				out.ChatIds = nil
+			} else {
+				in.Delim('[')
+				if out.ChatIds == nil {
+					if !in.IsDelim(']') {
+						out.ChatIds = make([]int64, 0, 8)
+					} else {
+						out.ChatIds = []int64{}
+					}
+				} else {
+					out.ChatIds = (out.ChatIds)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v17 int64
+					v17 = int64(in.Int64())
+					out.ChatIds = append(out.ChatIds, v17)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "isDisabled":
+			out.IsDisabled = bool(in.Bool())
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonBab2b7f2EncodeLiveChatHttp2(out *jwriter.Writer, in WebhookSubscriptionBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Id))
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.Url))
+	}
+	{
+		const prefix string = ",\"events\":"
+		out.RawString(prefix)
+		if in.Events == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v18, v19 := range in.Events {
+				if v18 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v19))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"chatIds\":"
+		out.RawString(prefix)
+		if in.ChatIds == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v20, v21 := range in.ChatIds {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				out.Int64(int64(v21))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"isDisabled\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsDisabled))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v WebhookSubscriptionBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonBab2b7f2EncodeLiveChatHttp2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v WebhookSubscriptionBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonBab2b7f2EncodeLiveChatHttp2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *WebhookSubscriptionBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonBab2b7f2DecodeLiveChatHttp2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *WebhookSubscriptionBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonBab2b7f2DecodeLiveChatHttp2(l, v)
+}
+func easyjsonBab2b7f2DecodeLiveChatHttp3(in *jlexer.Lexer, out *WebhookDeliveryListBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "deliveries":
+			if in.IsNull() {
+				in.Skip()
+				out.Deliveries = nil
+			} else {
+				in.Delim('[')
+				if out.Deliveries == nil {
+					if !in.IsDelim(']') {
+						out.Deliveries = make([]entities.WebhookDelivery, 0, 0)
+					} else {
+						out.Deliveries = []entities.WebhookDelivery{}
+					}
+				} else {
+					out.Deliveries = (out.Deliveries)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v22 entities.WebhookDelivery
+					easyjsonBab2b7f2DecodeLiveChatEntities1(in, &v22)
+					out.Deliveries = append(out.Deliveries, v22)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "nextCursor":
+			out.NextCursor = int64(in.Int64())
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonBab2b7f2EncodeLiveChatHttp3(out *jwriter.Writer, in WebhookDeliveryListBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"deliveries\":"
+		out.RawString(prefix[1:])
+		if in.Deliveries == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v23, v24 := range in.Deliveries {
+				if v23 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonBab2b7f2EncodeLiveChatEntities1(out, v24)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"nextCursor\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.NextCursor))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v WebhookDeliveryListBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonBab2b7f2EncodeLiveChatHttp3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v WebhookDeliveryListBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonBab2b7f2EncodeLiveChatHttp3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *WebhookDeliveryListBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonBab2b7f2DecodeLiveChatHttp3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *WebhookDeliveryListBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonBab2b7f2DecodeLiveChatHttp3(l, v)
+}
+func easyjsonBab2b7f2DecodeLiveChatEntities1(in *jlexer.Lexer, out *entities.WebhookDelivery) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.Id = string(in.String())
+		case "subscriptionId":
+			out.SubscriptionId = int64(in.Int64())
+		case "botId":
+			out.BotId = int64(in.Int64())
+		case "eventType":
+			out.EventType = string(in.String())
+		case "payload":
+			out.Payload = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "attempts":
+			out.Attempts = int(in.Int())
+		case "lastError":
+			out.LastError = string(in.String())
+		case "createdAt":
+			out.CreatedAt = int64(in.Int64())
+		case "updatedAt":
+			out.UpdatedAt = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonBab2b7f2EncodeLiveChatEntities1(out *jwriter.Writer, in entities.WebhookDelivery) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Id))
+	}
+	{
+		const prefix string = ",\"subscriptionId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.SubscriptionId))
+	}
+	if in.BotId != 0 {
+		const prefix string = ",\"botId\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BotId))
+	}
+	{
+		const prefix string = ",\"eventType\":"
+		out.RawString(prefix)
+		out.String(string(in.EventType))
+	}
+	{
+		const prefix string = ",\"payload\":"
+		out.RawString(prefix)
+		out.String(string(in.Payload))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"attempts\":"
+		out.RawString(prefix)
+		out.Int(int(in.Attempts))
+	}
+	{
+		const prefix string = ",\"lastError\":"
+		out.RawString(prefix)
+		out.String(string(in.LastError))
+	}
+	{
+		const prefix string = ",\"createdAt\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CreatedAt))
+	}
+	{
+		const prefix string = ",\"updatedAt\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.UpdatedAt))
+	}
+	out.RawByte('}')
+}
+func easyjsonBab2b7f2DecodeLiveChatHttp4(in *jlexer.Lexer, out *WebhookDeliveryBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "delivery":
+			easyjsonBab2b7f2DecodeLiveChatEntities1(in, &out.Delivery)
+		case "status":
+			out.Status = int32(in.Int32())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonBab2b7f2EncodeLiveChatHttp4(out *jwriter.Writer, in WebhookDeliveryBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"delivery\":"
+		out.RawString(prefix[1:])
+		easyjsonBab2b7f2EncodeLiveChatEntities1(out, in.Delivery)
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v WebhookDeliveryBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonBab2b7f2EncodeLiveChatHttp4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v WebhookDeliveryBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonBab2b7f2EncodeLiveChatHttp4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *WebhookDeliveryBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonBab2b7f2DecodeLiveChatHttp4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *WebhookDeliveryBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonBab2b7f2DecodeLiveChatHttp4(l, v)
+}
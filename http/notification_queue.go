@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"github.com/Shopify/sarama"
 	"github.com/golang/protobuf/proto"
+	"liveChat/bot"
 	"liveChat/controllers"
 	"liveChat/db"
 	"liveChat/entities"
 	"liveChat/log"
+	"liveChat/push"
 	"liveChat/rpc"
+	"liveChat/webhook"
 	"time"
 )
 
@@ -43,6 +46,7 @@ func SendNotification(notification *entities.Notification) {
 		ReceiveType:     rpc.NotificationRequest_ReceiveType(notification.ReceiveType),
 		IsHandledByAuth: notification.IsHandled,
 		IsAgree:         notification.IsAgree,
+		Message:         notification.Message,
 	}
 
 	data, _ := proto.Marshal(&protoNot)
@@ -57,14 +61,31 @@ func consumeNotificationFunc(m *sarama.ConsumerMessage) {
 		return
 	}
 
+	webhook.PublishNotification(noti)
+
+	delivered := make(map[int64]struct{})
 	clients := controllers.GetAllServerClients()
 	for _, client := range clients {
 		ctx, cfn := context.WithTimeout(context.Background(), time.Second*3)
-		_, err := client.BroadcastNotification(ctx, noti, nil)
+		resp, err := client.BroadcastNotification(ctx, noti, nil)
 		cfn()
 		if err != nil {
 			log.Error(err.Error())
 			continue
 		}
+
+		for _, userId := range resp.DeliveredUsers {
+			delivered[userId] = struct{}{}
+		}
+	}
+
+	recipients := []int64{noti.Receiver}
+	if noti.Receiver < 0 {
+		if recipients, err = controllers.GetGroupNotificationReceivers(noti.Receiver, byte(noti.Op)); err != nil {
+			log.Error(fmt.Sprintf("获取群组 %d 通知接收者失败: %s", noti.Receiver, err.Error()))
+			return
+		}
 	}
+	bot.DeliverNotification(noti, recipients)
+	push.DispatchNotification(noti, push.UndeliveredUsers(recipients, delivered))
 }
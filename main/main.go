@@ -1,14 +1,24 @@
 package main
 
 import (
+	"crypto/tls"
 	"flag"
 	"fmt"
+	"liveChat/bot"
 	"liveChat/config"
 	"liveChat/controllers"
 	"liveChat/db"
+	"liveChat/export"
 	"liveChat/http"
+	"liveChat/metrics"
+	"liveChat/moderation"
+	"liveChat/push"
+	"liveChat/ratelimit"
 	"liveChat/rpc/rpc_implementation"
+	"liveChat/search"
+	"liveChat/security"
 	"liveChat/tcp"
+	"liveChat/webhook"
 	"os"
 	"time"
 )
@@ -39,16 +49,32 @@ func main() {
 	parseENV()
 	generalConfig := config.NewGeneralConfig(*path)
 
-	go http.InitHttpServer(generalConfig.HttpListenAddresses)
-	go tcp.InitiateTcpServer(generalConfig.TcpListenAddress)
-	go rpc_implementation.InitRpcServer(generalConfig.GrpcListenAddress)
+	tlsConfigs := initTLS(generalConfig)
+
+	originPolicy := security.NewOriginPolicy(generalConfig.CorsConfig)
+	http.InitOriginPolicy(originPolicy)
+	tcp.InitOriginPolicy(originPolicy)
+	http.InitOperatorKeys(generalConfig.OperatorKeys)
+	ratelimit.InitRateLimit(generalConfig.RateLimitConfig)
+	moderation.InitModeration(generalConfig.ModerationConfig)
+	go metrics.InitMetricsServer(generalConfig.MetricsConfig.ListenAddress)
+	go http.InitHttpServer(generalConfig.HttpListenAddresses, generalConfig.TrustedProxies, tlsConfigs.http)
+	go tcp.InitiateTcpServer(generalConfig.TcpListenAddress, tlsConfigs.webSocket, generalConfig.TLSConfig.WebSocketInternalAddress)
+	go rpc_implementation.InitRpcServer(generalConfig.GrpcListenAddress, tlsConfigs.grpcServer)
 
 	initMessageQueue(generalConfig)
 	initNotificationQueue(generalConfig)
 	initMysql(generalConfig)
 	initRedis(generalConfig)
 	initMongoDb(generalConfig)
-	initEtcd(generalConfig)
+	initEtcd(generalConfig, tlsConfigs.grpcClient)
+	search.InitMessageIndex(generalConfig.SearchEngine)
+	push.InitPush(generalConfig.PushConfig)
+	webhook.InitWebhook(generalConfig.WebhookConfig)
+	bot.InitBot()
+	controllers.InitNotificationExpiry(generalConfig.NotificationConfig)
+	controllers.InitMessageRetention(generalConfig.RetentionConfig)
+	export.InitExport(generalConfig.ExportConfig)
 
 	ticker := time.NewTicker(time.Second * 3)
 	for {
@@ -116,12 +142,39 @@ func initMysql(cfg *config.GeneralConfig) {
 	db.InitMysqlConnection(url)
 }
 
-func initEtcd(cfg *config.GeneralConfig) {
+type tlsConfigs struct {
+	http       *tls.Config
+	webSocket  *tls.Config
+	grpcServer *tls.Config
+	grpcClient *tls.Config
+}
+
+func initTLS(cfg *config.GeneralConfig) (ret tlsConfigs) {
+	var (
+		err      error
+		interval = time.Duration(cfg.TLSConfig.ReloadIntervalSeconds) * time.Second
+	)
+
+	if ret.http, err = security.NewServerTLSConfig(cfg.TLSConfig.Http, interval); err != nil {
+		panic(err)
+	}
+	if ret.webSocket, err = security.NewServerTLSConfig(cfg.TLSConfig.WebSocket, interval); err != nil {
+		panic(err)
+	} else if ret.webSocket != nil && cfg.TLSConfig.WebSocketInternalAddress == "" {
+		panic("WebSocket 启用 TLS 时需要配置 websocket_internal_address")
+	}
+	if ret.grpcServer, ret.grpcClient, err = security.NewClusterTLSConfig(cfg.TLSConfig.Grpc, interval); err != nil {
+		panic(err)
+	}
+	return
+}
+
+func initEtcd(cfg *config.GeneralConfig, tlsConfig *tls.Config) {
 	var url []string
 	if etcdAddressVar != "" {
 		url = []string{etcdAddressVar}
 	} else {
 		url = cfg.EtcdUrls
 	}
-	controllers.InitServerInterconnection(url, cfg.GrpcServeAddress)
+	controllers.InitServerInterconnection(url, cfg.GrpcServeAddress, tlsConfig)
 }
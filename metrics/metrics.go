@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"time"
+)
+
+// DefaultBuckets 为耗时类直方图的默认分桶，单位秒
+var DefaultBuckets = prometheus.DefBuckets
+
+type Counter struct {
+	prometheus.Counter
+}
+
+// Add delta 为负数时忽略，client_golang 对负数会 panic
+func (c *Counter) Add(delta float64) {
+	if delta > 0 {
+		c.Counter.Add(delta)
+	}
+}
+
+type Gauge struct {
+	prometheus.Gauge
+}
+
+type Histogram struct {
+	prometheus.Observer
+}
+
+// ObserveSince 记录从 start 至今经过的秒数
+func (h *Histogram) ObserveSince(start time.Time) {
+	h.Observe(time.Since(start).Seconds())
+}
+
+type CounterVec struct {
+	vec *prometheus.CounterVec
+}
+
+// With 标签值的个数必须与注册时的标签名一致
+func (v *CounterVec) With(values ...string) *Counter {
+	return &Counter{v.vec.WithLabelValues(values...)}
+}
+
+type GaugeVec struct {
+	vec *prometheus.GaugeVec
+}
+
+func (v *GaugeVec) With(values ...string) *Gauge {
+	return &Gauge{v.vec.WithLabelValues(values...)}
+}
+
+type HistogramVec struct {
+	vec *prometheus.HistogramVec
+}
+
+func (v *HistogramVec) With(values ...string) *Histogram {
+	return &Histogram{v.vec.WithLabelValues(values...)}
+}
+
+// NewCounterVec 与 NewGaugeVec、NewHistogramVec 一样通过 promauto 注册到默认 Registry，同名指标重复注册时 panic
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{promauto.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels)}
+}
+
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{promauto.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labels)}
+}
+
+// NewHistogramVec buckets 为各分桶的上界，需要升序排列，为 nil 时使用 DefaultBuckets
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	return &HistogramVec{promauto.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labels)}
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"strings"
+	"testing"
+)
+
+func TestMetricsWrite(t *testing.T) {
+	counter := NewCounterVec("test_requests_total", "请求数", "route")
+	gauge := NewGaugeVec("test_connections", "连接数")
+	histogram := NewHistogramVec("test_latency_seconds", "耗时", []float64{0.1, 1}, "op")
+
+	counter.With("/b").Inc()
+	counter.With("/a").Add(2)
+	counter.With("/a").Add(-1)
+	gauge.With().Inc()
+	gauge.With().Inc()
+	gauge.With().Dec()
+	histogram.With("query").Observe(0.05)
+	histogram.With("query").Observe(0.1)
+	histogram.With("query").Observe(3)
+
+	want := `# HELP test_connections 连接数
+# TYPE test_connections gauge
+test_connections 1
+# HELP test_latency_seconds 耗时
+# TYPE test_latency_seconds histogram
+test_latency_seconds_bucket{op="query",le="0.1"} 2
+test_latency_seconds_bucket{op="query",le="1"} 2
+test_latency_seconds_bucket{op="query",le="+Inf"} 3
+test_latency_seconds_sum{op="query"} 3.15
+test_latency_seconds_count{op="query"} 3
+# HELP test_requests_total 请求数
+# TYPE test_requests_total counter
+test_requests_total{route="/a"} 2
+test_requests_total{route="/b"} 1
+`
+	err := testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(want),
+		"test_connections", "test_latency_seconds", "test_requests_total")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMetricsPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"duplicate name", func() {
+			NewCounterVec("test_duplicate_total", "")
+			NewGaugeVec("test_duplicate_total", "")
+		}},
+		{"label count mismatch", func() {
+			NewCounterVec("test_mismatch_total", "", "a", "b").With("a")
+		}},
+	}
+
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected panic", test.name)
+				}
+			}()
+			test.fn()
+		}()
+	}
+}
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+)
+
+const metricsPath = "/metrics"
+
+// Handler 按 Prometheus 的格式输出默认 Registry 中的全部指标
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// InitMetricsServer 在单独的端口上提供 /metrics，不经过业务接口的跨域与限流检查。listenAddress 为空时不启动
+func InitMetricsServer(listenAddress string) {
+	if listenAddress == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, Handler())
+	if err := http.ListenAndServe(listenAddress, mux); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,130 @@
+package moderation
+
+import "unicode"
+
+// keyword 为词典中的一个词，length 为其 rune 数量
+type keyword struct {
+	word   string
+	length int
+	action Action
+}
+
+type acNode struct {
+	children map[rune]int32
+	fail     int32
+	// outputs 包含以该节点结尾的全部词，构建时已合并失配链上的输出
+	outputs []int32
+}
+
+// automaton 为 Aho-Corasick 自动机，构建后只读，可并发使用。匹配时忽略大小写
+type automaton struct {
+	nodes    []acNode
+	keywords []keyword
+}
+
+// match 中 start 与 end 为 rune 下标，区间左闭右开
+type match struct {
+	keyword *keyword
+	start   int
+	end     int
+}
+
+// newAutomaton 同一个词出现多次时使用最严格的处理方式
+func newAutomaton(words map[string]Action) *automaton {
+	a := &automaton{nodes: []acNode{{}}}
+	for word, action := range words {
+		runes := normalize([]rune(word))
+		if len(runes) == 0 {
+			continue
+		}
+
+		cur := int32(0)
+		for _, r := range runes {
+			next, ok := a.nodes[cur].children[r]
+			if !ok {
+				next = int32(len(a.nodes))
+				a.nodes = append(a.nodes, acNode{})
+				if a.nodes[cur].children == nil {
+					a.nodes[cur].children = make(map[rune]int32)
+				}
+				a.nodes[cur].children[r] = next
+			}
+			cur = next
+		}
+
+		if len(a.nodes[cur].outputs) != 0 {
+			kw := &a.keywords[a.nodes[cur].outputs[0]]
+			if action > kw.action {
+				kw.action = action
+			}
+			continue
+		}
+		a.keywords = append(a.keywords, keyword{word: word, length: len(runes), action: action})
+		a.nodes[cur].outputs = []int32{int32(len(a.keywords) - 1)}
+	}
+
+	a.buildFailLinks()
+	return a
+}
+
+// buildFailLinks 按广度优先顺序计算失配指针
+func (a *automaton) buildFailLinks() {
+	queue := make([]int32, 0, len(a.nodes))
+	for _, child := range a.nodes[0].children {
+		queue = append(queue, child)
+	}
+
+	for len(queue) != 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for r, child := range a.nodes[cur].children {
+			fail := a.nodes[cur].fail
+			for {
+				if next, ok := a.nodes[fail].children[r]; ok {
+					a.nodes[child].fail = next
+					break
+				} else if fail == 0 {
+					break
+				}
+				fail = a.nodes[fail].fail
+			}
+
+			a.nodes[child].outputs = append(a.nodes[child].outputs, a.nodes[a.nodes[child].fail].outputs...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+func (a *automaton) find(runes []rune) []match {
+	var (
+		matches []match
+		cur     = int32(0)
+	)
+
+	for i, r := range normalize(runes) {
+		for {
+			if next, ok := a.nodes[cur].children[r]; ok {
+				cur = next
+				break
+			} else if cur == 0 {
+				break
+			}
+			cur = a.nodes[cur].fail
+		}
+
+		for _, output := range a.nodes[cur].outputs {
+			kw := &a.keywords[output]
+			matches = append(matches, match{keyword: kw, start: i + 1 - kw.length, end: i + 1})
+		}
+	}
+	return matches
+}
+
+func normalize(runes []rune) []rune {
+	ret := make([]rune, len(runes))
+	for i, r := range runes {
+		ret[i] = unicode.ToLower(r)
+	}
+	return ret
+}
@@ -0,0 +1,155 @@
+package moderation
+
+import (
+	"bufio"
+	"fmt"
+	"liveChat/config"
+	"liveChat/log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	keywordClassifierName = "keyword"
+	maskRune              = '*'
+	defaultReloadInterval = time.Minute
+)
+
+// KeywordClassifier 使用 Aho-Corasick 自动机匹配词典中的词，词典文件修改时间变化时重新加载，
+// 加载失败时继续使用旧的词典
+type KeywordClassifier struct {
+	dictionaries []config.ModerationDictionary
+	actions      []Action
+
+	lock      sync.RWMutex
+	automaton *automaton
+	modTimes  map[string]time.Time
+}
+
+func NewKeywordClassifier(dictionaries []config.ModerationDictionary) (*KeywordClassifier, error) {
+	c := &KeywordClassifier{dictionaries: dictionaries, modTimes: make(map[string]time.Time)}
+	for _, dictionary := range dictionaries {
+		action, err := ParseAction(dictionary.Action)
+		if err != nil {
+			return nil, fmt.Errorf("词典 %s: %w", dictionary.Path, err)
+		}
+		c.actions = append(c.actions, action)
+	}
+
+	if _, err := c.reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *KeywordClassifier) Name() string {
+	return keywordClassifierName
+}
+
+// reload 在任一词典的修改时间变化时重新加载全部词典，返回是否发生了重新加载
+func (c *KeywordClassifier) reload() (bool, error) {
+	modTimes := make(map[string]time.Time, len(c.dictionaries))
+	changed := false
+	for _, dictionary := range c.dictionaries {
+		info, err := os.Stat(dictionary.Path)
+		if err != nil {
+			return false, err
+		}
+		modTimes[dictionary.Path] = info.ModTime()
+		if t, ok := c.modTimes[dictionary.Path]; !ok || !t.Equal(info.ModTime()) {
+			changed = true
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+
+	words := make(map[string]Action)
+	for i, dictionary := range c.dictionaries {
+		if err := readDictionary(dictionary.Path, c.actions[i], words); err != nil {
+			return false, err
+		}
+	}
+
+	a := newAutomaton(words)
+	c.lock.Lock()
+	c.automaton, c.modTimes = a, modTimes
+	c.lock.Unlock()
+	return true, nil
+}
+
+// readDictionary 词典每行一个词，忽略空行与 # 开头的注释
+func readDictionary(path string, action Action, words map[string]Action) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		if old, ok := words[word]; !ok || action > old {
+			words[word] = action
+		}
+	}
+	return scanner.Err()
+}
+
+func (c *KeywordClassifier) watch(interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultReloadInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := c.reload(); err != nil {
+				log.Error(fmt.Sprintf("重新加载审核词典失败，继续使用旧词典: %s", err.Error()))
+			}
+		}
+	}()
+}
+
+// Classify 命中多个词时取最严格的处理方式，结果为 Mask 时只替换处理方式为 Mask 的词
+func (c *KeywordClassifier) Classify(content *Content) (Verdict, error) {
+	c.lock.RLock()
+	a := c.automaton
+	c.lock.RUnlock()
+
+	runes := []rune(content.Text)
+	verdict := Verdict{Action: Pass, Text: content.Text}
+	matches := a.find(runes)
+	if len(matches) == 0 {
+		return verdict, nil
+	}
+
+	seen := make(map[string]struct{}, len(matches))
+	masked := false
+	for _, m := range matches {
+		if m.keyword.action > verdict.Action {
+			verdict.Action = m.keyword.action
+		}
+		if _, ok := seen[m.keyword.word]; !ok {
+			seen[m.keyword.word] = struct{}{}
+			verdict.Labels = append(verdict.Labels, m.keyword.word)
+		}
+		if m.keyword.action == Mask {
+			for i := m.start; i < m.end; i++ {
+				runes[i] = maskRune
+			}
+			masked = true
+		}
+	}
+
+	if verdict.Action == Mask && masked {
+		verdict.Text = string(runes)
+	}
+	return verdict, nil
+}
@@ -0,0 +1,186 @@
+package moderation
+
+import (
+	"errors"
+	"fmt"
+	"liveChat/config"
+	"liveChat/log"
+	"time"
+)
+
+// Action 为审核结果的处理方式，数值越大越严格
+type Action int
+
+const (
+	Pass Action = iota
+	// Flag 允许保存，但记录下来供人工复核
+	Flag
+	// Mask 将命中的内容替换为 * 后保存
+	Mask
+	// Block 拒绝保存
+	Block
+)
+
+const (
+	SceneMessage           = "message"
+	SceneUsername          = "username"
+	SceneUserIntroduction  = "userIntroduction"
+	SceneGroupName         = "groupName"
+	SceneGroupIntroduction = "groupIntroduction"
+)
+
+var (
+	ErrorContentBlocked = errors.New("内容包含违禁信息")
+	ErrorUnknownAction  = errors.New("未知的审核处理方式")
+)
+
+func ParseAction(action string) (Action, error) {
+	switch action {
+	case "block":
+		return Block, nil
+	case "mask":
+		return Mask, nil
+	case "flag":
+		return Flag, nil
+	}
+	return Pass, ErrorUnknownAction
+}
+
+func (a Action) String() string {
+	switch a {
+	case Block:
+		return "block"
+	case Mask:
+		return "mask"
+	case Flag:
+		return "flag"
+	}
+	return "pass"
+}
+
+// Content 为待审核的文本，Scene 为文本出现的位置，UserId 为提交者
+type Content struct {
+	UserId int64
+	Scene  string
+	Text   string
+}
+
+// Verdict 为审核结果，Text 为处理后的文本，Labels 为命中的词或外部分类器给出的标签
+type Verdict struct {
+	Action Action
+	Text   string
+	Labels []string
+}
+
+// Classifier 为审核的扩展点，外部分类器实现该接口后通过 RegisterClassifier 加入审核流程。
+// 返回 Mask 时需要同时返回处理后的文本
+type Classifier interface {
+	Name() string
+	Classify(content *Content) (Verdict, error)
+}
+
+// FlagHook 在内容被标记或拦截时调用
+type FlagHook func(content *Content, verdict Verdict)
+
+// Pipeline 依次调用各个分类器，后一个分类器审核前一个处理后的文本，任一分类器拦截时立即返回
+type Pipeline struct {
+	classifiers  []Classifier
+	hooks        []FlagHook
+	blockOnError bool
+}
+
+func NewPipeline(blockOnError bool, classifiers ...Classifier) *Pipeline {
+	return &Pipeline{classifiers: classifiers, blockOnError: blockOnError}
+}
+
+func (p *Pipeline) AddClassifier(c Classifier) {
+	p.classifiers = append(p.classifiers, c)
+}
+
+func (p *Pipeline) AddFlagHook(hook FlagHook) {
+	p.hooks = append(p.hooks, hook)
+}
+
+// Moderate 返回的 Verdict.Action 为 Block 时同时返回 ErrorContentBlocked。
+// 分类器出错时按 blockOnError 拦截或跳过该分类器
+func (p *Pipeline) Moderate(content *Content) (Verdict, error) {
+	result := Verdict{Action: Pass, Text: content.Text}
+	if content.Text == "" {
+		return result, nil
+	}
+
+	current := *content
+	for _, c := range p.classifiers {
+		verdict, err := c.Classify(&current)
+		if err != nil {
+			log.Error(fmt.Sprintf("审核分类器 %s 出错: %s", c.Name(), err.Error()))
+			if !p.blockOnError {
+				continue
+			}
+			verdict = Verdict{Action: Block, Labels: []string{c.Name() + " 不可用"}}
+		}
+
+		result.Labels = append(result.Labels, verdict.Labels...)
+		if verdict.Action > result.Action {
+			result.Action = verdict.Action
+		}
+		if verdict.Action == Mask {
+			current.Text = verdict.Text
+			result.Text = verdict.Text
+		}
+		if verdict.Action == Block {
+			break
+		}
+	}
+
+	if result.Action == Pass {
+		return result, nil
+	}
+	for _, hook := range p.hooks {
+		hook(content, result)
+	}
+	if result.Action == Block {
+		return result, ErrorContentBlocked
+	}
+	return result, nil
+}
+
+func logFlaggedContent(content *Content, verdict Verdict) {
+	log.Error(fmt.Sprintf("用户 %d 提交的 %s 内容被审核处理为 %s，命中 %v: %s",
+		content.UserId, content.Scene, verdict.Action, verdict.Labels, content.Text))
+}
+
+var pipeline *Pipeline
+
+// InitModeration 未配置词典时审核流程为空，仍可通过 RegisterClassifier 接入外部分类器
+func InitModeration(cfg config.ModerationConfig) {
+	pipeline = NewPipeline(cfg.BlockOnError)
+	pipeline.AddFlagHook(logFlaggedContent)
+	if len(cfg.Dictionaries) == 0 {
+		return
+	}
+
+	classifier, err := NewKeywordClassifier(cfg.Dictionaries)
+	if err != nil {
+		panic(err)
+	}
+	classifier.watch(time.Duration(cfg.ReloadIntervalSeconds) * time.Second)
+	pipeline.AddClassifier(classifier)
+}
+
+// RegisterClassifier 需要在 InitModeration 之后、开始处理请求之前调用
+func RegisterClassifier(c Classifier) {
+	pipeline.AddClassifier(c)
+}
+
+func RegisterFlagHook(hook FlagHook) {
+	pipeline.AddFlagHook(hook)
+}
+
+// Moderate 未初始化时直接放行
+func Moderate(userId int64, scene, text string) (Verdict, error) {
+	if pipeline == nil {
+		return Verdict{Action: Pass, Text: text}, nil
+	}
+	return pipeline.Moderate(&Content{UserId: userId, Scene: scene, Text: text})
+}
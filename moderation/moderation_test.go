@@ -0,0 +1,183 @@
+package moderation
+
+import (
+	"errors"
+	"liveChat/config"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestAutomatonFind(t *testing.T) {
+	a := newAutomaton(map[string]Action{"he": Flag, "she": Flag, "his": Flag, "hers": Flag, "违禁": Block})
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"ushers", []string{"she", "he", "hers"}},
+		{"USHERS", []string{"she", "he", "hers"}},
+		{"this", []string{"his"}},
+		{"这是违禁词", []string{"违禁"}},
+		{"nothing", nil},
+		{"", nil},
+	}
+
+	for _, test := range tests {
+		var got []string
+		for _, m := range a.find([]rune(test.text)) {
+			got = append(got, m.keyword.word)
+			if string([]rune(test.text)[m.start:m.end]) == "" {
+				t.Errorf("%q: empty match range for %s", test.text, m.keyword.word)
+			}
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: got %v, want %v", test.text, got, test.want)
+		}
+	}
+}
+
+func writeDictionary(t *testing.T, path, content string, modTime time.Time) {
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestClassifier(t *testing.T) (*KeywordClassifier, []config.ModerationDictionary) {
+	var (
+		dir          = t.TempDir()
+		modTime      = time.Now().Add(-time.Minute)
+		dictionaries = []config.ModerationDictionary{
+			{Path: filepath.Join(dir, "block.txt"), Action: "block"},
+			{Path: filepath.Join(dir, "mask.txt"), Action: "mask"},
+			{Path: filepath.Join(dir, "flag.txt"), Action: "flag"},
+		}
+	)
+	writeDictionary(t, dictionaries[0].Path, "# 拦截\n赌博\n", modTime)
+	writeDictionary(t, dictionaries[1].Path, "傻瓜\nBadWord\n\n", modTime)
+	writeDictionary(t, dictionaries[2].Path, "投诉\n傻瓜\n", modTime)
+
+	c, err := NewKeywordClassifier(dictionaries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, dictionaries
+}
+
+func TestKeywordClassifier(t *testing.T) {
+	c, _ := newTestClassifier(t)
+
+	tests := []struct {
+		text       string
+		wantAction Action
+		wantText   string
+		wantLabels []string
+	}{
+		{"你好", Pass, "你好", nil},
+		{"我要投诉", Flag, "我要投诉", []string{"投诉"}},
+		{"你这个傻瓜", Mask, "你这个**", []string{"傻瓜"}},
+		{"what a badword!", Mask, "what a *******!", []string{"BadWord"}},
+		{"投诉这个傻瓜", Mask, "投诉这个**", []string{"投诉", "傻瓜"}},
+		{"来赌博吧傻瓜", Block, "来赌博吧傻瓜", []string{"傻瓜", "赌博"}},
+	}
+
+	for _, test := range tests {
+		verdict, err := c.Classify(&Content{Text: test.text})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Strings(verdict.Labels)
+		sort.Strings(test.wantLabels)
+		if verdict.Action != test.wantAction || verdict.Text != test.wantText || !reflect.DeepEqual(verdict.Labels, test.wantLabels) {
+			t.Errorf("%q: got %v %q %v, want %v %q %v", test.text,
+				verdict.Action, verdict.Text, verdict.Labels, test.wantAction, test.wantText, test.wantLabels)
+		}
+	}
+}
+
+func TestKeywordClassifierReload(t *testing.T) {
+	c, dictionaries := newTestClassifier(t)
+
+	if reloaded, err := c.reload(); err != nil || reloaded {
+		t.Fatalf("unchanged dictionaries: reloaded %v, err %v", reloaded, err)
+	}
+
+	writeDictionary(t, dictionaries[0].Path, "赌博\n诈骗\n", time.Now())
+	if reloaded, err := c.reload(); err != nil || !reloaded {
+		t.Fatalf("changed dictionaries: reloaded %v, err %v", reloaded, err)
+	}
+	if verdict, _ := c.Classify(&Content{Text: "诈骗电话"}); verdict.Action != Block {
+		t.Errorf("new word not loaded, got %v", verdict.Action)
+	}
+
+	if err := os.Remove(dictionaries[1].Path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.reload(); err == nil {
+		t.Error("missing dictionary should fail to load")
+	}
+	if verdict, _ := c.Classify(&Content{Text: "傻瓜"}); verdict.Action != Mask {
+		t.Error("previous dictionaries should be kept when reload fails")
+	}
+}
+
+type stubClassifier struct {
+	verdict Verdict
+	err     error
+	got     string
+}
+
+func (s *stubClassifier) Name() string {
+	return "stub"
+}
+
+func (s *stubClassifier) Classify(content *Content) (Verdict, error) {
+	s.got = content.Text
+	return s.verdict, s.err
+}
+
+func TestPipeline(t *testing.T) {
+	c, _ := newTestClassifier(t)
+
+	external := &stubClassifier{verdict: Verdict{Action: Flag, Labels: []string{"spam"}}}
+	p := NewPipeline(false, c, external)
+	var flagged []Verdict
+	p.AddFlagHook(func(content *Content, verdict Verdict) {
+		flagged = append(flagged, verdict)
+	})
+
+	verdict, err := p.Moderate(&Content{Text: "你这个傻瓜"})
+	if err != nil || verdict.Action != Mask || verdict.Text != "你这个**" {
+		t.Fatalf("got %+v, %v", verdict, err)
+	}
+	if external.got != "你这个**" {
+		t.Errorf("external classifier got %q, want masked text", external.got)
+	}
+	if !reflect.DeepEqual(verdict.Labels, []string{"傻瓜", "spam"}) || len(flagged) != 1 {
+		t.Errorf("labels %v, flagged %d times", verdict.Labels, len(flagged))
+	}
+
+	external.got = ""
+	if _, err = p.Moderate(&Content{Text: "赌博"}); !errors.Is(err, ErrorContentBlocked) {
+		t.Errorf("got %v, want %v", err, ErrorContentBlocked)
+	}
+	if external.got != "" {
+		t.Error("pipeline should stop after block")
+	}
+
+	external.verdict, external.err = Verdict{}, errors.New("timeout")
+	if verdict, err = p.Moderate(&Content{Text: "你好"}); err != nil || verdict.Action != Pass {
+		t.Errorf("failing classifier should be skipped, got %+v, %v", verdict, err)
+	}
+
+	strict := NewPipeline(true, external)
+	if _, err = strict.Moderate(&Content{Text: "你好"}); !errors.Is(err, ErrorContentBlocked) {
+		t.Errorf("got %v, want blocked when classifier fails", err)
+	}
+}
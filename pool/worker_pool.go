@@ -2,20 +2,39 @@ package pool
 
 import (
 	"github.com/panjf2000/ants/v2"
+	"liveChat/metrics"
+)
+
+var (
+	queuedTasks = metrics.NewGaugeVec("livechat_worker_pool_queued",
+		"已提交但尚未开始执行的任务数", "pool")
+	runningTasks = metrics.NewGaugeVec("livechat_worker_pool_running",
+		"正在执行的任务数", "pool")
 )
 
 type WorkerPool struct {
 	*ants.PoolWithFunc
+	queued *metrics.Gauge
 }
 
-func NewWorkerPool(fn func(interface{})) (*WorkerPool, error) {
-	tmp, err := ants.NewPoolWithFunc(-1, fn)
+// NewWorkerPool name 用于区分监控指标中的各个协程池
+func NewWorkerPool(name string, fn func(interface{})) (*WorkerPool, error) {
+	queued, running := queuedTasks.With(name), runningTasks.With(name)
+	tmp, err := ants.NewPoolWithFunc(-1, func(arg interface{}) {
+		queued.Dec()
+		running.Inc()
+		defer running.Dec()
+		fn(arg)
+	})
 	if err != nil {
 		return nil, err
 	}
-	return &WorkerPool{tmp}, nil
+	return &WorkerPool{tmp, queued}, nil
 }
 
 func (p *WorkerPool) PushTask(arg interface{}) {
-	p.Invoke(arg)
+	p.queued.Inc()
+	if err := p.Invoke(arg); err != nil {
+		p.queued.Dec()
+	}
 }
@@ -0,0 +1,268 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"liveChat/config"
+	"liveChat/db"
+	"liveChat/entities"
+	"liveChat/log"
+	"liveChat/rpc"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRateLimitPerMinute = 30
+	defaultWorkerNumber       = 4
+	defaultTaskQueueSize      = 10000
+	defaultPushTimeout        = time.Second * 5
+
+	bodyPreviewLength = 64
+)
+
+const WebhookProviderName = "webhook"
+
+// Store 为分发器读取推送设置与设备列表的来源
+type Store interface {
+	GetSetting(userId int64) (*entities.PushSetting, error)
+	GetDevices(userId int64) ([]entities.PushDevice, error)
+}
+
+type mysqlStore struct{}
+
+func (mysqlStore) GetSetting(userId int64) (*entities.PushSetting, error) {
+	return db.GetPushSetting(nil, userId)
+}
+
+func (mysqlStore) GetDevices(userId int64) ([]entities.PushDevice, error) {
+	return db.SelectPushDevices(nil, userId)
+}
+
+type Dispatcher struct {
+	store     Store
+	providers map[string]Provider
+	limiter   *rateLimiter
+	tasks     chan *Payload
+	now       func() time.Time
+}
+
+func NewDispatcher(store Store, limitPerMinute, workers int, providers ...Provider) *Dispatcher {
+	if limitPerMinute <= 0 {
+		limitPerMinute = defaultRateLimitPerMinute
+	}
+	if workers <= 0 {
+		workers = defaultWorkerNumber
+	}
+
+	d := &Dispatcher{
+		store:     store,
+		providers: make(map[string]Provider, len(providers)),
+		limiter:   newRateLimiter(limitPerMinute, time.Minute),
+		tasks:     make(chan *Payload, defaultTaskQueueSize),
+		now:       time.Now,
+	}
+	for _, provider := range providers {
+		d.providers[provider.Name()] = provider
+	}
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for payload := range d.tasks {
+				if err := d.dispatch(payload); err != nil {
+					log.Error(fmt.Sprintf("离线推送失败: %s", err.Error()))
+				}
+			}
+		}()
+	}
+	return d
+}
+
+// Dispatch 将推送放入队列异步处理，队列已满时丢弃
+func (d *Dispatcher) Dispatch(payload *Payload) {
+	select {
+	case d.tasks <- payload:
+	default:
+		log.Error(fmt.Sprintf("离线推送队列已满，丢弃用户 %d 的推送", payload.UserId))
+	}
+}
+
+func (d *Dispatcher) dispatch(payload *Payload) error {
+	setting, err := d.store.GetSetting(payload.UserId)
+	if err != nil {
+		return err
+	}
+
+	now := d.now().UTC()
+	if setting.IsDisabled || setting.IsChatMuted(payload.ChatId) || setting.IsInDnd(now.Hour()*60+now.Minute()) {
+		return nil
+	}
+
+	if !d.limiter.allow(payload.UserId, now) {
+		return nil
+	}
+
+	devices, err := d.store.GetDevices(payload.UserId)
+	if err != nil {
+		return err
+	}
+
+	for i := range devices {
+		provider, ok := d.providers[devices[i].Provider]
+		if !ok {
+			continue
+		}
+
+		ctx, cfn := context.WithTimeout(context.Background(), defaultPushTimeout)
+		err = provider.Push(ctx, &devices[i], payload)
+		cfn()
+		if err != nil {
+			log.Error(fmt.Sprintf("通过 %s 推送至用户 %d 失败: %s", provider.Name(), payload.UserId, err.Error()))
+		}
+	}
+	return nil
+}
+
+var dispatcher *Dispatcher
+
+func InitPush(cfg config.PushConfig) {
+	providers := make([]Provider, 0)
+	if cfg.WebhookUrl != "" {
+		providers = append(providers, NewWebhookProvider(WebhookProviderName, cfg.WebhookUrl, defaultPushTimeout))
+	}
+	dispatcher = NewDispatcher(mysqlStore{}, cfg.RateLimitPerMinute, cfg.Workers, providers...)
+}
+
+// HasProvider 返回推送通道是否已启用，注册设备时只接受已启用的通道
+func HasProvider(name string) bool {
+	if dispatcher == nil {
+		return false
+	}
+	_, ok := dispatcher.providers[name]
+	return ok
+}
+
+// UndeliveredUsers 返回 recipients 中未出现在 delivered 里的用户
+func UndeliveredUsers(recipients []int64, delivered map[int64]struct{}) []int64 {
+	ret := make([]int64, 0, len(recipients))
+	for _, userId := range recipients {
+		if _, ok := delivered[userId]; !ok {
+			ret = append(ret, userId)
+		}
+	}
+	return ret
+}
+
+// DispatchMessage 为未能在线投递的用户发送离线推送，消息发送者会被忽略
+func DispatchMessage(m *rpc.Message, userIds []int64) {
+	if dispatcher == nil {
+		return
+	}
+
+	body := strings.Join(m.Contents, "")
+	if m.Type != rpc.Message_Text {
+		body = "[非文本消息]"
+	} else if runes := []rune(body); len(runes) > bodyPreviewLength {
+		body = string(runes[:bodyPreviewLength]) + "..."
+	}
+
+	for _, userId := range userIds {
+		if userId == m.Sender {
+			continue
+		}
+		dispatcher.Dispatch(newMessagePayload(m, userId, body))
+	}
+}
+
+// newMessagePayload 中私聊的 ChatId 为对方的 id，与客户端免打扰设置中的会话 id 一致
+func newMessagePayload(m *rpc.Message, userId int64, body string) *Payload {
+	chatId := m.Receiver
+	if chatId > 0 {
+		chatId = m.Sender
+	}
+
+	return &Payload{
+		UserId:    userId,
+		Kind:      KindMessage,
+		ChatId:    chatId,
+		Seq:       m.Id,
+		SenderId:  m.Sender,
+		Title:     "新消息",
+		Body:      body,
+		Timestamp: int64(m.Timestamp),
+	}
+}
+
+func DispatchNotification(n *rpc.NotificationRequest, userIds []int64) {
+	if dispatcher == nil {
+		return
+	}
+
+	body := n.Op.String()
+	if n.Message != "" {
+		body = n.Message
+	}
+
+	for _, userId := range userIds {
+		dispatcher.Dispatch(&Payload{
+			UserId:    userId,
+			Kind:      KindNotification,
+			ChatId:    n.Receiver,
+			Seq:       n.Id,
+			SenderId:  n.Sender,
+			Title:     "新通知",
+			Body:      body,
+			Timestamp: int64(n.Timestamp),
+		})
+	}
+}
+
+// rateLimiter 以固定窗口限制每个用户在窗口内收到的推送数量
+type rateLimiter struct {
+	lock    sync.Mutex
+	limit   int
+	window  time.Duration
+	windows map[int64]*limiterWindow
+}
+
+type limiterWindow struct {
+	start time.Time
+	count int
+}
+
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:   limit,
+		window:  window,
+		windows: make(map[int64]*limiterWindow),
+	}
+}
+
+func (l *rateLimiter) allow(userId int64, now time.Time) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	w, ok := l.windows[userId]
+	if !ok || now.Sub(w.start) >= l.window {
+		if len(l.windows) > defaultTaskQueueSize {
+			l.evict(now)
+		}
+		l.windows[userId] = &limiterWindow{start: now, count: 1}
+		return true
+	}
+
+	if w.count >= l.limit {
+		return false
+	}
+	w.count++
+	return true
+}
+
+func (l *rateLimiter) evict(now time.Time) {
+	for userId, w := range l.windows {
+		if now.Sub(w.start) >= l.window {
+			delete(l.windows, userId)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package push
+
+import (
+	"liveChat/entities"
+	"liveChat/rpc"
+	"testing"
+	"time"
+)
+
+type memoryStore struct {
+	settings map[int64]*entities.PushSetting
+	devices  map[int64][]entities.PushDevice
+}
+
+func (s *memoryStore) GetSetting(userId int64) (*entities.PushSetting, error) {
+	if setting, ok := s.settings[userId]; ok {
+		return setting, nil
+	}
+	return entities.NewDefaultPushSetting(userId), nil
+}
+
+func (s *memoryStore) GetDevices(userId int64) ([]entities.PushDevice, error) {
+	return s.devices[userId], nil
+}
+
+func newTestDispatcher(store Store, limit int, providers ...Provider) *Dispatcher {
+	d := NewDispatcher(store, limit, 1, providers...)
+	d.now = func() time.Time { return time.Date(2022, 10, 1, 23, 30, 0, 0, time.UTC) }
+	return d
+}
+
+func TestDispatcherRespectsSettings(t *testing.T) {
+	fake := NewFakeProvider("fake")
+	store := &memoryStore{
+		settings: map[int64]*entities.PushSetting{
+			2: {UserId: 2, IsDisabled: true},
+			3: {UserId: 3, IsDndEnabled: true, DndStartMinute: 22 * 60, DndEndMinute: 8 * 60},
+			4: {UserId: 4, MutedChats: []int64{-100}},
+			// UTC 23:30 在 UTC+8 时区为次日 07:30，处于 0:00-7:00 之外
+			5: {UserId: 5, IsDndEnabled: true, DndStartMinute: 0, DndEndMinute: 7 * 60, TimezoneOffset: 8 * 60},
+		},
+		devices: map[int64][]entities.PushDevice{
+			1: {*entities.NewPushDevice(1, 1, "fake", "t1"), *entities.NewPushDevice(1, 0, "unknown", "t1-web")},
+			2: {*entities.NewPushDevice(2, 1, "fake", "t2")},
+			3: {*entities.NewPushDevice(3, 1, "fake", "t3")},
+			4: {*entities.NewPushDevice(4, 1, "fake", "t4")},
+			5: {*entities.NewPushDevice(5, 1, "fake", "t5")},
+		},
+	}
+	d := newTestDispatcher(store, 10, fake)
+
+	for userId := int64(1); userId <= 5; userId++ {
+		if err := d.dispatch(&Payload{UserId: userId, ChatId: -100}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pushes := fake.Pushes()
+	if len(pushes) != 2 {
+		t.Fatalf("expected 2 pushes, got %d: %+v", len(pushes), pushes)
+	}
+	if pushes[0].Device.Token != "t1" || pushes[1].Device.Token != "t5" {
+		t.Errorf("unexpected pushes: %+v", pushes)
+	}
+}
+
+func TestDispatcherRateLimit(t *testing.T) {
+	fake := NewFakeProvider("fake")
+	store := &memoryStore{devices: map[int64][]entities.PushDevice{1: {*entities.NewPushDevice(1, 1, "fake", "t1")}}}
+	d := newTestDispatcher(store, 3, fake)
+
+	for i := 0; i < 5; i++ {
+		if err := d.dispatch(&Payload{UserId: 1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if count := len(fake.Pushes()); count != 3 {
+		t.Errorf("expected 3 pushes within the window, got %d", count)
+	}
+
+	d.now = func() time.Time { return time.Date(2022, 10, 1, 23, 31, 0, 0, time.UTC) }
+	if err := d.dispatch(&Payload{UserId: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if count := len(fake.Pushes()); count != 4 {
+		t.Errorf("expected the window to reset, got %d pushes", count)
+	}
+}
+
+func TestMessagePayloadChatId(t *testing.T) {
+	cases := []struct {
+		message *rpc.Message
+		userId  int64
+		chatId  int64
+	}{
+		// 私聊以对方的 id 作为会话 id
+		{&rpc.Message{Sender: 1, Receiver: 2}, 2, 1},
+		{&rpc.Message{Sender: 1, Receiver: -100}, 2, -100},
+	}
+
+	for _, c := range cases {
+		if payload := newMessagePayload(c.message, c.userId, ""); payload.ChatId != c.chatId {
+			t.Errorf("message %d -> %d: expected chatId %d, got %d", c.message.Sender, c.message.Receiver, c.chatId, payload.ChatId)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"liveChat/entities"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	KindMessage      = "message"
+	KindNotification = "notification"
+)
+
+type Payload struct {
+	UserId    int64  `json:"userId"`
+	Kind      string `json:"kind"`
+	ChatId    int64  `json:"chatId"`
+	Seq       uint64 `json:"seq"`
+	SenderId  int64  `json:"senderId"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// Provider 为推送渠道的抽象，设备注册时携带的 provider 字段与 Name 的返回值对应
+type Provider interface {
+	Name() string
+	Push(ctx context.Context, device *entities.PushDevice, payload *Payload) error
+}
+
+// WebhookProvider 将推送以 JSON 形式 POST 到配置的地址，由外部服务负责对接具体的厂商通道
+type WebhookProvider struct {
+	name   string
+	url    string
+	client *http.Client
+}
+
+type webhookRequest struct {
+	Token    string   `json:"token"`
+	Platform int      `json:"platform"`
+	Payload  *Payload `json:"payload"`
+}
+
+func NewWebhookProvider(name, url string, timeout time.Duration) *WebhookProvider {
+	return &WebhookProvider{
+		name:   name,
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *WebhookProvider) Name() string {
+	return p.name
+}
+
+func (p *WebhookProvider) Push(ctx context.Context, device *entities.PushDevice, payload *Payload) error {
+	data, err := json.Marshal(&webhookRequest{Token: device.Token, Platform: device.Platform, Payload: payload})
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("推送 webhook 返回异常状态码: %d", response.StatusCode)
+	}
+	return nil
+}
+
+type FakePush struct {
+	Device  entities.PushDevice
+	Payload Payload
+}
+
+// FakeProvider 将推送记录在内存中，供测试与本地调试使用
+type FakeProvider struct {
+	name   string
+	lock   sync.Mutex
+	pushes []FakePush
+}
+
+func NewFakeProvider(name string) *FakeProvider {
+	return &FakeProvider{name: name}
+}
+
+func (p *FakeProvider) Name() string {
+	return p.name
+}
+
+func (p *FakeProvider) Push(ctx context.Context, device *entities.PushDevice, payload *Payload) error {
+	p.lock.Lock()
+	p.pushes = append(p.pushes, FakePush{*device, *payload})
+	p.lock.Unlock()
+	return nil
+}
+
+func (p *FakeProvider) Pushes() []FakePush {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	ret := make([]FakePush, len(p.pushes))
+	copy(ret, p.pushes)
+	return ret
+}
@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"errors"
+	"liveChat/config"
+	"strconv"
+	"time"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+
+	defaultBanWindow   = time.Minute * 10
+	defaultBanDuration = time.Minute * 30
+)
+
+var ErrorUnknownBackend = errors.New("未知的限流存储")
+
+// Decision 为一次限流检查的结果，未通过时 RetryAfter 为建议的重试等待时间
+type Decision struct {
+	Allowed    bool
+	Banned     bool
+	RetryAfter time.Duration
+}
+
+var allowed = Decision{Allowed: true}
+
+// Limiter 以 对象 + 路由或请求类型 为键使用令牌桶限流，对象为 IP 或用户。
+// 同一对象累计超限达到阈值后会被封禁，封禁期间该对象的全部请求都会被拒绝
+type Limiter struct {
+	store  Store
+	routes map[string]config.RateLimitRule
+	loads  map[string]config.RateLimitRule
+
+	banThreshold int64
+	banWindow    time.Duration
+	banDuration  time.Duration
+}
+
+func NewLimiter(store Store, cfg config.RateLimitConfig) *Limiter {
+	l := &Limiter{
+		store:        store,
+		routes:       cfg.Routes,
+		loads:        cfg.Loads,
+		banThreshold: cfg.BanThreshold,
+		banWindow:    time.Duration(cfg.BanWindowSeconds) * time.Second,
+		banDuration:  time.Duration(cfg.BanSeconds) * time.Second,
+	}
+	if l.banWindow <= 0 {
+		l.banWindow = defaultBanWindow
+	}
+	if l.banDuration <= 0 {
+		l.banDuration = defaultBanDuration
+	}
+	return l
+}
+
+func IPSubject(ip string) string {
+	return "ip_" + ip
+}
+
+func UserSubject(userId int64) string {
+	return "user_" + strconv.FormatInt(userId, 10)
+}
+
+// AllowRoute 检查 subject 对 HTTP 路由 route 的请求，未配置规则的路由只检查封禁
+func (l *Limiter) AllowRoute(subject, route string) (Decision, error) {
+	return l.allow(subject, "route_"+route, l.routes[route])
+}
+
+// AllowLoad 检查 subject 发送的 WebSocket 请求，load 为请求类型的名称
+func (l *Limiter) AllowLoad(subject, load string) (Decision, error) {
+	return l.allow(subject, "load_"+load, l.loads[load])
+}
+
+func (l *Limiter) allow(subject, scope string, rule config.RateLimitRule) (Decision, error) {
+	if l.banThreshold > 0 {
+		remaining, err := l.store.BanRemaining(subject)
+		if err != nil {
+			return allowed, err
+		} else if remaining > 0 {
+			return Decision{Banned: true, RetryAfter: remaining}, nil
+		}
+	}
+
+	if rule.Rate <= 0 {
+		return allowed, nil
+	}
+	if rule.Burst < 1 {
+		rule.Burst = 1
+	}
+
+	wait, err := l.store.Take(subject+"_"+scope, rule)
+	if err != nil || wait <= 0 {
+		return allowed, err
+	}
+
+	if l.banThreshold > 0 {
+		count, err := l.store.CountViolation(subject, l.banWindow)
+		if err != nil {
+			return Decision{RetryAfter: wait}, err
+		} else if count >= l.banThreshold {
+			if err = l.store.Ban(subject, l.banDuration); err != nil {
+				return Decision{RetryAfter: wait}, err
+			}
+			return Decision{Banned: true, RetryAfter: l.banDuration}, nil
+		}
+	}
+	return Decision{RetryAfter: wait}, nil
+}
+
+var limiter *Limiter
+
+// InitRateLimit 未配置任何规则时不启用限流
+func InitRateLimit(cfg config.RateLimitConfig) {
+	if len(cfg.Routes) == 0 && len(cfg.Loads) == 0 {
+		return
+	}
+
+	switch cfg.Backend {
+	case "", BackendMemory:
+		limiter = NewLimiter(newMemoryStore(time.Now), cfg)
+	case BackendRedis:
+		limiter = NewLimiter(redisStore{}, cfg)
+	default:
+		panic(ErrorUnknownBackend)
+	}
+}
+
+// AllowRoute 未启用限流时总是允许
+func AllowRoute(subject, route string) (Decision, error) {
+	if limiter == nil {
+		return allowed, nil
+	}
+	return limiter.AllowRoute(subject, route)
+}
+
+func AllowLoad(subject, load string) (Decision, error) {
+	if limiter == nil {
+		return allowed, nil
+	}
+	return limiter.AllowLoad(subject, load)
+}
@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"liveChat/config"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestLimiter(cfg config.RateLimitConfig) (*Limiter, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	return NewLimiter(newMemoryStore(clock.Now), cfg), clock
+}
+
+func TestTokenBucket(t *testing.T) {
+	limiter, clock := newTestLimiter(config.RateLimitConfig{
+		Routes: map[string]config.RateLimitRule{"/login": {Rate: 1, Burst: 3}},
+	})
+
+	subject := IPSubject("10.0.0.1")
+	for i := 0; i < 3; i++ {
+		if d, _ := limiter.AllowRoute(subject, "/login"); !d.Allowed {
+			t.Fatalf("request %d within burst rejected", i)
+		}
+	}
+
+	d, _ := limiter.AllowRoute(subject, "/login")
+	if d.Allowed || d.Banned || d.RetryAfter != time.Second {
+		t.Fatalf("got %+v, want rejected with one second retry", d)
+	}
+
+	if d, _ = limiter.AllowRoute(IPSubject("10.0.0.2"), "/login"); !d.Allowed {
+		t.Error("buckets of different subjects should be independent")
+	}
+	if d, _ = limiter.AllowRoute(subject, "/register"); !d.Allowed {
+		t.Error("route without rule should not be limited")
+	}
+
+	clock.now = clock.now.Add(time.Millisecond * 1500)
+	if d, _ = limiter.AllowRoute(subject, "/login"); !d.Allowed {
+		t.Error("token should be refilled")
+	}
+	if d, _ = limiter.AllowRoute(subject, "/login"); d.Allowed || d.RetryAfter != time.Millisecond*500 {
+		t.Errorf("got %+v, want rejected with half second retry", d)
+	}
+
+	clock.now = clock.now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		if d, _ = limiter.AllowRoute(subject, "/login"); !d.Allowed {
+			t.Fatalf("refilled bucket should not exceed burst, request %d rejected", i)
+		}
+	}
+	if d, _ = limiter.AllowRoute(subject, "/login"); d.Allowed {
+		t.Error("refilled bucket should not exceed burst")
+	}
+}
+
+func TestBanPersistentOffender(t *testing.T) {
+	limiter, clock := newTestLimiter(config.RateLimitConfig{
+		Loads:            map[string]config.RateLimitRule{"message": {Rate: 1, Burst: 1}},
+		Routes:           map[string]config.RateLimitRule{"/userInfo/addFriend": {Rate: 1, Burst: 1}},
+		BanThreshold:     3,
+		BanWindowSeconds: 60,
+		BanSeconds:       600,
+	})
+
+	subject := UserSubject(1)
+	limiter.AllowLoad(subject, "message")
+	for i := 0; i < 2; i++ {
+		if d, _ := limiter.AllowLoad(subject, "message"); d.Allowed || d.Banned {
+			t.Fatalf("violation %d: got %+v, want rejected without ban", i, d)
+		}
+	}
+
+	d, _ := limiter.AllowLoad(subject, "message")
+	if !d.Banned || d.RetryAfter != time.Minute*10 {
+		t.Fatalf("got %+v, want banned for ten minutes", d)
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+	if d, _ = limiter.AllowRoute(subject, "/userInfo/search"); !d.Banned || d.RetryAfter != time.Minute*9 {
+		t.Errorf("got %+v, want ban applied to other routes", d)
+	}
+	if d, _ = limiter.AllowLoad(UserSubject(2), "message"); !d.Allowed {
+		t.Error("other users should not be banned")
+	}
+
+	clock.now = clock.now.Add(time.Minute * 10)
+	if d, _ = limiter.AllowLoad(subject, "message"); !d.Allowed {
+		t.Errorf("got %+v, want ban lifted", d)
+	}
+}
+
+func TestViolationWindowExpires(t *testing.T) {
+	limiter, clock := newTestLimiter(config.RateLimitConfig{
+		Loads:            map[string]config.RateLimitRule{"message": {Rate: 0.01, Burst: 1}},
+		BanThreshold:     2,
+		BanWindowSeconds: 10,
+	})
+
+	subject := UserSubject(1)
+	limiter.AllowLoad(subject, "message")
+	if d, _ := limiter.AllowLoad(subject, "message"); d.Allowed || d.Banned {
+		t.Fatalf("got %+v, want rejected without ban", d)
+	}
+
+	clock.now = clock.now.Add(time.Second * 11)
+	if d, _ := limiter.AllowLoad(subject, "message"); d.Allowed || d.Banned {
+		t.Errorf("got %+v, violations of expired window should not count", d)
+	}
+}
@@ -0,0 +1,159 @@
+package ratelimit
+
+import (
+	"liveChat/config"
+	"liveChat/db"
+	"math"
+	"sync"
+	"time"
+)
+
+// Store 保存令牌桶、超限次数与封禁状态
+type Store interface {
+	// Take 从 key 对应的令牌桶中取出一个令牌，令牌不足时返回需要等待的时间
+	Take(key string, rule config.RateLimitRule) (time.Duration, error)
+	// CountViolation 将 key 在 window 内的超限次数加一并返回
+	CountViolation(key string, window time.Duration) (int64, error)
+	Ban(key string, duration time.Duration) error
+	// BanRemaining 返回 key 剩余的封禁时间，未被封禁时返回 0
+	BanRemaining(key string) (time.Duration, error)
+}
+
+// redisStore 在各节点间共享计数
+type redisStore struct{}
+
+func (redisStore) Take(key string, rule config.RateLimitRule) (time.Duration, error) {
+	return db.TakeBucketToken(key, rule.Rate, rule.Burst)
+}
+
+func (redisStore) CountViolation(key string, window time.Duration) (int64, error) {
+	return db.CountInFixedWindow(key, window)
+}
+
+func (redisStore) Ban(key string, duration time.Duration) error {
+	return db.SetRateLimitBan(key, duration)
+}
+
+func (redisStore) BanRemaining(key string) (time.Duration, error) {
+	return db.GetRateLimitBan(key)
+}
+
+const maxMemoryEntries = 1 << 16
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	// full 为令牌补满的时间，此后可以丢弃该桶
+	full time.Time
+}
+
+type violationWindow struct {
+	start time.Time
+	count int64
+}
+
+// memoryStore 只在当前节点内计数，条目数量超过上限时清理已补满的桶与过期的窗口
+type memoryStore struct {
+	lock       sync.Mutex
+	now        func() time.Time
+	buckets    map[string]*bucket
+	violations map[string]*violationWindow
+	bans       map[string]time.Time
+}
+
+func newMemoryStore(now func() time.Time) *memoryStore {
+	return &memoryStore{
+		now:        now,
+		buckets:    make(map[string]*bucket),
+		violations: make(map[string]*violationWindow),
+		bans:       make(map[string]time.Time),
+	}
+}
+
+func (s *memoryStore) Take(key string, rule config.RateLimitRule) (time.Duration, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	b, ok := s.buckets[key]
+	if !ok {
+		if len(s.buckets) >= maxMemoryEntries {
+			s.evict(now)
+		}
+		b = &bucket{tokens: float64(rule.Burst), last: now}
+		s.buckets[key] = b
+	} else if now.After(b.last) {
+		b.tokens = math.Min(float64(rule.Burst), b.tokens+now.Sub(b.last).Seconds()*rule.Rate)
+		b.last = now
+	}
+
+	var wait time.Duration
+	if b.tokens >= 1 {
+		b.tokens--
+	} else {
+		wait = time.Duration(math.Ceil((1 - b.tokens) / rule.Rate * float64(time.Second)))
+	}
+	b.full = now.Add(time.Duration((float64(rule.Burst) - b.tokens) / rule.Rate * float64(time.Second)))
+	return wait, nil
+}
+
+func (s *memoryStore) CountViolation(key string, window time.Duration) (int64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	w, ok := s.violations[key]
+	if !ok || now.Sub(w.start) >= window {
+		if len(s.violations) >= maxMemoryEntries {
+			s.evict(now)
+		}
+		w = &violationWindow{start: now}
+		s.violations[key] = w
+	}
+	w.count++
+	return w.count, nil
+}
+
+func (s *memoryStore) Ban(key string, duration time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.bans[key] = s.now().Add(duration)
+	return nil
+}
+
+func (s *memoryStore) BanRemaining(key string) (time.Duration, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	until, ok := s.bans[key]
+	if !ok {
+		return 0, nil
+	}
+
+	remaining := until.Sub(s.now())
+	if remaining <= 0 {
+		delete(s.bans, key)
+		return 0, nil
+	}
+	return remaining, nil
+}
+
+// evict 违规窗口的长度不在这里保存，超过一天未更新的窗口才会被清理
+func (s *memoryStore) evict(now time.Time) {
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+	for key, w := range s.violations {
+		if now.Sub(w.start) >= time.Hour*24 {
+			delete(s.violations, key)
+		}
+	}
+	for key, until := range s.bans {
+		if !now.Before(until) {
+			delete(s.bans, key)
+		}
+	}
+}
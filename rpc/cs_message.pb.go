@@ -20,12 +20,62 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ErrorResponseErrorCode int32
+
+const (
+	ErrorResponse_Unknown     ErrorResponseErrorCode = 0
+	ErrorResponse_RateLimited ErrorResponseErrorCode = 1
+	ErrorResponse_Banned      ErrorResponseErrorCode = 2
+)
+
+// Enum value maps for ErrorResponseErrorCode.
+var (
+	ErrorResponseErrorCode_name = map[int32]string{
+		0: "Unknown",
+		1: "RateLimited",
+		2: "Banned",
+	}
+	ErrorResponseErrorCode_value = map[string]int32{
+		"Unknown":     0,
+		"RateLimited": 1,
+		"Banned":      2,
+	}
+)
+
+func (x ErrorResponseErrorCode) Enum() *ErrorResponseErrorCode {
+	p := new(ErrorResponseErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorResponseErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorResponseErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_cs_message_proto_enumTypes[0].Descriptor()
+}
+
+func (ErrorResponseErrorCode) Type() protoreflect.EnumType {
+	return &file_cs_message_proto_enumTypes[0]
+}
+
+func (x ErrorResponseErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorResponseErrorCode.Descriptor instead.
+func (ErrorResponseErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_cs_message_proto_rawDescGZIP(), []int{0, 0}
+}
+
 type MessageContentType int32
 
 const (
-	Message_Text  MessageContentType = 0
-	Message_Image MessageContentType = 1
-	Message_Emoji MessageContentType = 2
+	Message_Text      MessageContentType = 0
+	Message_Image     MessageContentType = 1
+	Message_Emoji     MessageContentType = 2
+	Message_Encrypted MessageContentType = 3
 )
 
 // Enum value maps for MessageContentType.
@@ -34,11 +84,13 @@ var (
 		0: "Text",
 		1: "Image",
 		2: "Emoji",
+		3: "Encrypted",
 	}
 	MessageContentType_value = map[string]int32{
-		"Text":  0,
-		"Image": 1,
-		"Emoji": 2,
+		"Text":      0,
+		"Image":     1,
+		"Emoji":     2,
+		"Encrypted": 3,
 	}
 )
 
@@ -53,11 +105,11 @@ func (x MessageContentType) String() string {
 }
 
 func (MessageContentType) Descriptor() protoreflect.EnumDescriptor {
-	return file_cs_message_proto_enumTypes[0].Descriptor()
+	return file_cs_message_proto_enumTypes[1].Descriptor()
 }
 
 func (MessageContentType) Type() protoreflect.EnumType {
-	return &file_cs_message_proto_enumTypes[0]
+	return &file_cs_message_proto_enumTypes[1]
 }
 
 func (x MessageContentType) Number() protoreflect.EnumNumber {
@@ -99,11 +151,11 @@ func (x RequestEstablishConnectionPlatformType) String() string {
 }
 
 func (RequestEstablishConnectionPlatformType) Descriptor() protoreflect.EnumDescriptor {
-	return file_cs_message_proto_enumTypes[1].Descriptor()
+	return file_cs_message_proto_enumTypes[2].Descriptor()
 }
 
 func (RequestEstablishConnectionPlatformType) Type() protoreflect.EnumType {
-	return &file_cs_message_proto_enumTypes[1]
+	return &file_cs_message_proto_enumTypes[2]
 }
 
 func (x RequestEstablishConnectionPlatformType) Number() protoreflect.EnumNumber {
@@ -112,7 +164,7 @@ func (x RequestEstablishConnectionPlatformType) Number() protoreflect.EnumNumber
 
 // Deprecated: Use RequestEstablishConnectionPlatformType.Descriptor instead.
 func (RequestEstablishConnectionPlatformType) EnumDescriptor() ([]byte, []int) {
-	return file_cs_message_proto_rawDescGZIP(), []int{5, 0}
+	return file_cs_message_proto_rawDescGZIP(), []int{6, 0}
 }
 
 type ErrorResponse struct {
@@ -120,7 +172,10 @@ type ErrorResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Reason string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Code   ErrorResponseErrorCode `protobuf:"varint,2,opt,name=code,proto3,enum=ErrorResponseErrorCode" json:"code,omitempty"`
+	// 被限流时建议的重试等待毫秒数
+	RetryAfterMs int64 `protobuf:"varint,3,opt,name=retryAfterMs,proto3" json:"retryAfterMs,omitempty"`
 }
 
 func (x *ErrorResponse) Reset() {
@@ -162,17 +217,33 @@ func (x *ErrorResponse) GetReason() string {
 	return ""
 }
 
+func (x *ErrorResponse) GetCode() ErrorResponseErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ErrorResponse_Unknown
+}
+
+func (x *ErrorResponse) GetRetryAfterMs() int64 {
+	if x != nil {
+		return x.RetryAfterMs
+	}
+	return 0
+}
+
 type Message struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id        uint64             `protobuf:"fixed64,1,opt,name=id,proto3" json:"id,omitempty"`
-	Sender    int64              `protobuf:"fixed64,2,opt,name=sender,proto3" json:"sender,omitempty"`
-	Receiver  int64              `protobuf:"fixed64,3,opt,name=receiver,proto3" json:"receiver,omitempty"`
-	Timestamp uint64             `protobuf:"fixed64,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Type      MessageContentType `protobuf:"varint,5,opt,name=type,proto3,enum=MessageContentType" json:"type,omitempty"`
-	Contents  []string           `protobuf:"bytes,6,rep,name=contents,proto3" json:"contents,omitempty"`
+	Id         uint64             `protobuf:"fixed64,1,opt,name=id,proto3" json:"id,omitempty"`
+	Sender     int64              `protobuf:"fixed64,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	Receiver   int64              `protobuf:"fixed64,3,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	Timestamp  uint64             `protobuf:"fixed64,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Type       MessageContentType `protobuf:"varint,5,opt,name=type,proto3,enum=MessageContentType" json:"type,omitempty"`
+	Contents   []string           `protobuf:"bytes,6,rep,name=contents,proto3" json:"contents,omitempty"`
+	Mentions   []int64            `protobuf:"fixed64,7,rep,packed,name=mentions,proto3" json:"mentions,omitempty"`
+	MentionAll bool               `protobuf:"varint,8,opt,name=mentionAll,proto3" json:"mentionAll,omitempty"`
 }
 
 func (x *Message) Reset() {
@@ -249,6 +320,20 @@ func (x *Message) GetContents() []string {
 	return nil
 }
 
+func (x *Message) GetMentions() []int64 {
+	if x != nil {
+		return x.Mentions
+	}
+	return nil
+}
+
+func (x *Message) GetMentionAll() bool {
+	if x != nil {
+		return x.MentionAll
+	}
+	return false
+}
+
 type RequestMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -304,18 +389,75 @@ func (x *RequestMessage) GetReceiver() int64 {
 	return 0
 }
 
+// 范围内已按保留策略清理的消息以 gap 表示
+type MessageGap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BottomId uint64 `protobuf:"fixed64,1,opt,name=bottomId,proto3" json:"bottomId,omitempty"`
+	TopId    uint64 `protobuf:"fixed64,2,opt,name=topId,proto3" json:"topId,omitempty"`
+}
+
+func (x *MessageGap) Reset() {
+	*x = MessageGap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cs_message_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageGap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageGap) ProtoMessage() {}
+
+func (x *MessageGap) ProtoReflect() protoreflect.Message {
+	mi := &file_cs_message_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageGap.ProtoReflect.Descriptor instead.
+func (*MessageGap) Descriptor() ([]byte, []int) {
+	return file_cs_message_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MessageGap) GetBottomId() uint64 {
+	if x != nil {
+		return x.BottomId
+	}
+	return 0
+}
+
+func (x *MessageGap) GetTopId() uint64 {
+	if x != nil {
+		return x.TopId
+	}
+	return 0
+}
+
 type MultiMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Messages []*Message `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	Messages []*Message  `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	Gap      *MessageGap `protobuf:"bytes,2,opt,name=gap,proto3" json:"gap,omitempty"`
 }
 
 func (x *MultiMessage) Reset() {
 	*x = MultiMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cs_message_proto_msgTypes[3]
+		mi := &file_cs_message_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -328,7 +470,7 @@ func (x *MultiMessage) String() string {
 func (*MultiMessage) ProtoMessage() {}
 
 func (x *MultiMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_cs_message_proto_msgTypes[3]
+	mi := &file_cs_message_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -341,7 +483,7 @@ func (x *MultiMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MultiMessage.ProtoReflect.Descriptor instead.
 func (*MultiMessage) Descriptor() ([]byte, []int) {
-	return file_cs_message_proto_rawDescGZIP(), []int{3}
+	return file_cs_message_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *MultiMessage) GetMessages() []*Message {
@@ -351,6 +493,13 @@ func (x *MultiMessage) GetMessages() []*Message {
 	return nil
 }
 
+func (x *MultiMessage) GetGap() *MessageGap {
+	if x != nil {
+		return x.Gap
+	}
+	return nil
+}
+
 type RequestMultiMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -364,7 +513,7 @@ type RequestMultiMessage struct {
 func (x *RequestMultiMessage) Reset() {
 	*x = RequestMultiMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cs_message_proto_msgTypes[4]
+		mi := &file_cs_message_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -377,7 +526,7 @@ func (x *RequestMultiMessage) String() string {
 func (*RequestMultiMessage) ProtoMessage() {}
 
 func (x *RequestMultiMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_cs_message_proto_msgTypes[4]
+	mi := &file_cs_message_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -390,7 +539,7 @@ func (x *RequestMultiMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestMultiMessage.ProtoReflect.Descriptor instead.
 func (*RequestMultiMessage) Descriptor() ([]byte, []int) {
-	return file_cs_message_proto_rawDescGZIP(), []int{4}
+	return file_cs_message_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *RequestMultiMessage) GetBottomId() uint64 {
@@ -426,7 +575,7 @@ type RequestEstablishConnection struct {
 func (x *RequestEstablishConnection) Reset() {
 	*x = RequestEstablishConnection{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cs_message_proto_msgTypes[5]
+		mi := &file_cs_message_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -439,7 +588,7 @@ func (x *RequestEstablishConnection) String() string {
 func (*RequestEstablishConnection) ProtoMessage() {}
 
 func (x *RequestEstablishConnection) ProtoReflect() protoreflect.Message {
-	mi := &file_cs_message_proto_msgTypes[5]
+	mi := &file_cs_message_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -452,7 +601,7 @@ func (x *RequestEstablishConnection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestEstablishConnection.ProtoReflect.Descriptor instead.
 func (*RequestEstablishConnection) Descriptor() ([]byte, []int) {
-	return file_cs_message_proto_rawDescGZIP(), []int{5}
+	return file_cs_message_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *RequestEstablishConnection) GetToken() string {
@@ -481,7 +630,7 @@ type ResponseEstablishConnection struct {
 func (x *ResponseEstablishConnection) Reset() {
 	*x = ResponseEstablishConnection{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cs_message_proto_msgTypes[6]
+		mi := &file_cs_message_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -494,7 +643,7 @@ func (x *ResponseEstablishConnection) String() string {
 func (*ResponseEstablishConnection) ProtoMessage() {}
 
 func (x *ResponseEstablishConnection) ProtoReflect() protoreflect.Message {
-	mi := &file_cs_message_proto_msgTypes[6]
+	mi := &file_cs_message_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -507,7 +656,7 @@ func (x *ResponseEstablishConnection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseEstablishConnection.ProtoReflect.Descriptor instead.
 func (*ResponseEstablishConnection) Descriptor() ([]byte, []int) {
-	return file_cs_message_proto_rawDescGZIP(), []int{6}
+	return file_cs_message_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ResponseEstablishConnection) GetPrivateChat() []int64 {
@@ -528,54 +677,73 @@ var File_cs_message_proto protoreflect.FileDescriptor
 
 var file_cs_message_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x63, 0x73, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x22, 0x27, 0x0a, 0x0d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xe0, 0x01, 0x0a, 0x07,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x06, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65,
-	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x10, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12,
+	0x74, 0x6f, 0x22, 0xb0, 0x01, 0x0a, 0x0d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65,
+	0x74, 0x72, 0x79, 0x41, 0x66, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x72, 0x65, 0x74, 0x72, 0x79, 0x41, 0x66, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x22, 0x35,
+	0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55,
+	0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x61, 0x74, 0x65,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x64, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x61, 0x6e,
+	0x6e, 0x65, 0x64, 0x10, 0x02, 0x22, 0xab, 0x02, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x06, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x10, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x10, 0x52, 0x08, 0x72, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x06, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x6e,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x10, 0x52, 0x08, 0x6d, 0x65, 0x6e,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e,
+	0x41, 0x6c, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6d, 0x65, 0x6e, 0x74, 0x69,
+	0x6f, 0x6e, 0x41, 0x6c, 0x6c, 0x22, 0x3c, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x65, 0x78, 0x74, 0x10, 0x00, 0x12, 0x09,
+	0x0a, 0x05, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x6d, 0x6f,
+	0x6a, 0x69, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65,
+	0x64, 0x10, 0x03, 0x22, 0x3c, 0x0a, 0x0e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x06, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x10, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x72, 0x22, 0x3e, 0x0a, 0x0a, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x47, 0x61, 0x70, 0x12,
+	0x1a, 0x0a, 0x08, 0x62, 0x6f, 0x74, 0x74, 0x6f, 0x6d, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x06, 0x52, 0x08, 0x62, 0x6f, 0x74, 0x74, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x6f, 0x70, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x06, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x49,
+	0x64, 0x22, 0x53, 0x0a, 0x0c, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x24, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x03, 0x67, 0x61, 0x70, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x47, 0x61,
+	0x70, 0x52, 0x03, 0x67, 0x61, 0x70, 0x22, 0x63, 0x0a, 0x13, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x62, 0x6f, 0x74, 0x74, 0x6f, 0x6d, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x06, 0x52,
+	0x08, 0x62, 0x6f, 0x74, 0x74, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70,
+	0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x06, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x49, 0x64, 0x12,
 	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x10, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x74,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x06, 0x52, 0x09,
-	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18,
-	0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x22,
-	0x2d, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08,
-	0x0a, 0x04, 0x54, 0x65, 0x78, 0x74, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x49, 0x6d, 0x61, 0x67,
-	0x65, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x6d, 0x6f, 0x6a, 0x69, 0x10, 0x02, 0x22, 0x3c,
-	0x0a, 0x0e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x06, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x10, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x22, 0x34, 0x0a, 0x0c,
-	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x24, 0x0a, 0x08,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08,
-	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x73, 0x22, 0x63, 0x0a, 0x13, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x75, 0x6c,
-	0x74, 0x69, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x6f, 0x74,
-	0x74, 0x6f, 0x6d, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x06, 0x52, 0x08, 0x62, 0x6f, 0x74,
-	0x74, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x49, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x06, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72,
-	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x10, 0x52, 0x08, 0x72,
-	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x22, 0x9e, 0x01, 0x0a, 0x1a, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x45, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x44, 0x0a, 0x08,
-	0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28,
-	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x45, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x69, 0x73,
-	0x68, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x6c, 0x61, 0x74,
-	0x66, 0x6f, 0x72, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f,
-	0x72, 0x6d, 0x22, 0x24, 0x0a, 0x0c, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x57, 0x65, 0x62, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x41,
-	0x6e, 0x64, 0x72, 0x6f, 0x69, 0x64, 0x10, 0x01, 0x22, 0x5d, 0x0a, 0x1b, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x45, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x43, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61,
-	0x74, 0x65, 0x43, 0x68, 0x61, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x10, 0x52, 0x0b, 0x70, 0x72,
-	0x69, 0x76, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x67, 0x72, 0x6f,
-	0x75, 0x70, 0x43, 0x68, 0x61, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x10, 0x52, 0x09, 0x67, 0x72,
-	0x6f, 0x75, 0x70, 0x43, 0x68, 0x61, 0x74, 0x42, 0x0c, 0x5a, 0x0a, 0x2e, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x10, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x22, 0x9e, 0x01, 0x0a, 0x1a,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x45, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x69, 0x73, 0x68,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x44, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x28, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x45, 0x73, 0x74, 0x61,
+	0x62, 0x6c, 0x69, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x70, 0x6c,
+	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x22, 0x24, 0x0a, 0x0c, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f,
+	0x72, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x57, 0x65, 0x62, 0x10, 0x00, 0x12,
+	0x0b, 0x0a, 0x07, 0x41, 0x6e, 0x64, 0x72, 0x6f, 0x69, 0x64, 0x10, 0x01, 0x22, 0x5d, 0x0a, 0x1b,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x45, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x70,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x10,
+	0x52, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x68, 0x61, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x10,
+	0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x68, 0x61, 0x74, 0x42, 0x07, 0x5a, 0x05, 0x2e,
+	0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -590,28 +758,32 @@ func file_cs_message_proto_rawDescGZIP() []byte {
 	return file_cs_message_proto_rawDescData
 }
 
-var file_cs_message_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_cs_message_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_cs_message_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_cs_message_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_cs_message_proto_goTypes = []interface{}{
-	(MessageContentType)(0),                     // 0: Message.contentType
-	(RequestEstablishConnectionPlatformType)(0), // 1: RequestEstablishConnection.platformType
-	(*ErrorResponse)(nil),                       // 2: ErrorResponse
-	(*Message)(nil),                             // 3: Message
-	(*RequestMessage)(nil),                      // 4: RequestMessage
-	(*MultiMessage)(nil),                        // 5: MultiMessage
-	(*RequestMultiMessage)(nil),                 // 6: RequestMultiMessage
-	(*RequestEstablishConnection)(nil),          // 7: RequestEstablishConnection
-	(*ResponseEstablishConnection)(nil),         // 8: ResponseEstablishConnection
+	(ErrorResponseErrorCode)(0),                 // 0: ErrorResponse.errorCode
+	(MessageContentType)(0),                     // 1: Message.contentType
+	(RequestEstablishConnectionPlatformType)(0), // 2: RequestEstablishConnection.platformType
+	(*ErrorResponse)(nil),                       // 3: ErrorResponse
+	(*Message)(nil),                             // 4: Message
+	(*RequestMessage)(nil),                      // 5: RequestMessage
+	(*MessageGap)(nil),                          // 6: MessageGap
+	(*MultiMessage)(nil),                        // 7: MultiMessage
+	(*RequestMultiMessage)(nil),                 // 8: RequestMultiMessage
+	(*RequestEstablishConnection)(nil),          // 9: RequestEstablishConnection
+	(*ResponseEstablishConnection)(nil),         // 10: ResponseEstablishConnection
 }
 var file_cs_message_proto_depIdxs = []int32{
-	0, // 0: Message.type:type_name -> Message.contentType
-	3, // 1: MultiMessage.messages:type_name -> Message
-	1, // 2: RequestEstablishConnection.platform:type_name -> RequestEstablishConnection.platformType
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	0, // 0: ErrorResponse.code:type_name -> ErrorResponse.errorCode
+	1, // 1: Message.type:type_name -> Message.contentType
+	4, // 2: MultiMessage.messages:type_name -> Message
+	6, // 3: MultiMessage.gap:type_name -> MessageGap
+	2, // 4: RequestEstablishConnection.platform:type_name -> RequestEstablishConnection.platformType
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_cs_message_proto_init() }
@@ -657,7 +829,7 @@ func file_cs_message_proto_init() {
 			}
 		}
 		file_cs_message_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MultiMessage); i {
+			switch v := v.(*MessageGap); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -669,7 +841,7 @@ func file_cs_message_proto_init() {
 			}
 		}
 		file_cs_message_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestMultiMessage); i {
+			switch v := v.(*MultiMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -681,7 +853,7 @@ func file_cs_message_proto_init() {
 			}
 		}
 		file_cs_message_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestEstablishConnection); i {
+			switch v := v.(*RequestMultiMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -693,6 +865,18 @@ func file_cs_message_proto_init() {
 			}
 		}
 		file_cs_message_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestEstablishConnection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cs_message_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ResponseEstablishConnection); i {
 			case 0:
 				return &v.state
@@ -710,8 +894,8 @@ func file_cs_message_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_cs_message_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   7,
+			NumEnums:      3,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
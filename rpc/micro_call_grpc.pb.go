@@ -25,6 +25,7 @@ type ServerNodeClient interface {
 	KickUserOffOnSpecificPlatform(ctx context.Context, in *KickOffRequest, opts ...grpc.CallOption) (*Response, error)
 	BroadcastNotification(ctx context.Context, in *NotificationRequest, opts ...grpc.CallOption) (*Response, error)
 	BroadcastMessage(ctx context.Context, in *MessageRequest, opts ...grpc.CallOption) (*Response, error)
+	ListUserConnections(ctx context.Context, in *ConnectionsRequest, opts ...grpc.CallOption) (*ConnectionsResponse, error)
 }
 
 type serverNodeClient struct {
@@ -62,6 +63,15 @@ func (c *serverNodeClient) BroadcastMessage(ctx context.Context, in *MessageRequ
 	return out, nil
 }
 
+func (c *serverNodeClient) ListUserConnections(ctx context.Context, in *ConnectionsRequest, opts ...grpc.CallOption) (*ConnectionsResponse, error) {
+	out := new(ConnectionsResponse)
+	err := c.cc.Invoke(ctx, "/ServerNode/ListUserConnections", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServerNodeServer is the server API for ServerNode service.
 // All implementations must embed UnimplementedServerNodeServer
 // for forward compatibility
@@ -69,6 +79,7 @@ type ServerNodeServer interface {
 	KickUserOffOnSpecificPlatform(context.Context, *KickOffRequest) (*Response, error)
 	BroadcastNotification(context.Context, *NotificationRequest) (*Response, error)
 	BroadcastMessage(context.Context, *MessageRequest) (*Response, error)
+	ListUserConnections(context.Context, *ConnectionsRequest) (*ConnectionsResponse, error)
 	mustEmbedUnimplementedServerNodeServer()
 }
 
@@ -85,6 +96,9 @@ func (UnimplementedServerNodeServer) BroadcastNotification(context.Context, *Not
 func (UnimplementedServerNodeServer) BroadcastMessage(context.Context, *MessageRequest) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BroadcastMessage not implemented")
 }
+func (UnimplementedServerNodeServer) ListUserConnections(context.Context, *ConnectionsRequest) (*ConnectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserConnections not implemented")
+}
 func (UnimplementedServerNodeServer) mustEmbedUnimplementedServerNodeServer() {}
 
 // UnsafeServerNodeServer may be embedded to opt out of forward compatibility for this service.
@@ -152,6 +166,24 @@ func _ServerNode_BroadcastMessage_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServerNode_ListUserConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerNodeServer).ListUserConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ServerNode/ListUserConnections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerNodeServer).ListUserConnections(ctx, req.(*ConnectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ServerNode_ServiceDesc is the grpc.ServiceDesc for ServerNode service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -171,6 +203,10 @@ var ServerNode_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BroadcastMessage",
 			Handler:    _ServerNode_BroadcastMessage_Handler,
 		},
+		{
+			MethodName: "ListUserConnections",
+			Handler:    _ServerNode_ListUserConnections_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "micro_call.proto",
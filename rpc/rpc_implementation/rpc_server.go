@@ -2,8 +2,10 @@ package rpc_implementation
 
 import (
 	"context"
+	"crypto/tls"
 	"github.com/golang/protobuf/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"liveChat/constants"
 	"liveChat/controllers"
 	"liveChat/log"
@@ -14,13 +16,18 @@ import (
 
 var grpcServer *grpc.Server
 
-func InitRpcServer(listenAddress string) {
+// InitRpcServer tlsConfig 不为 nil 时使用双向 TLS，只接受持有集群 CA 签发证书的节点调用
+func InitRpcServer(listenAddress string, tlsConfig *tls.Config) {
 	acp, err := net.Listen("tcp", listenAddress)
 	if err != nil {
 		panic(err)
 	}
 
-	grpcServer = grpc.NewServer()
+	var options []grpc.ServerOption
+	if tlsConfig != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	grpcServer = grpc.NewServer(options...)
 	rpc.RegisterServerNodeServer(grpcServer, &RpcServer{})
 	if err = grpcServer.Serve(acp); err != nil {
 		panic(err)
@@ -45,14 +52,11 @@ func (s RpcServer) BroadcastNotification(ctx context.Context, request *rpc.Notif
 	userList := make([]int64, 0)
 	if request.Receiver > 0 {
 		userList = append(userList, request.Receiver)
-	} else if userList, err = getUserListInGroup(request.Receiver, true); err != nil {
+	} else if userList, err = controllers.GetGroupNotificationReceivers(request.Receiver, byte(request.Op)); err != nil {
 		return generateRpcResponse(request.RequestId, false, false, err.Error()), nil
 	}
 
-	if sendToUser(constants.NotificationRequestLoad, userList, data) == 0 {
-		return generateRpcResponse(request.RequestId, false, true, ""), nil
-	}
-	return generateRpcResponse(request.RequestId, true, true, ""), nil
+	return generateDeliveredResponse(request.RequestId, sendToUser(constants.NotificationRequestLoad, userList, data)), nil
 }
 
 func (s RpcServer) BroadcastMessage(ctx context.Context, request *rpc.MessageRequest) (*rpc.Response, error) {
@@ -64,14 +68,23 @@ func (s RpcServer) BroadcastMessage(ctx context.Context, request *rpc.MessageReq
 	userList := make([]int64, 0)
 	if request.Message.Receiver > 0 {
 		userList = append(userList, request.Message.Receiver)
-	} else if userList, err = getUserListInGroup(request.Message.Receiver, false); err != nil {
+	} else if userList, err = controllers.GetUserIdListInGroup(request.Message.Receiver, false); err != nil {
 		return generateRpcResponse(request.RequestId, false, false, err.Error()), nil
 	}
 
-	if sendToUser(constants.MessageLoad, userList, data) == 0 {
-		return generateRpcResponse(request.RequestId, false, true, ""), nil
+	return generateDeliveredResponse(request.RequestId, sendToUser(constants.MessageLoad, userList, data)), nil
+}
+
+func (s RpcServer) ListUserConnections(ctx context.Context, request *rpc.ConnectionsRequest) (*rpc.ConnectionsResponse, error) {
+	response := &rpc.ConnectionsResponse{RequestId: request.RequestId}
+	for _, info := range controllers.GetConnectionInfos(request.UserId) {
+		response.Connections = append(response.Connections, &rpc.ConnectionInfo{
+			Platform:      rpc.KickOffRequest_PlatformType(info.Platform),
+			RemoteAddress: info.RemoteAddress,
+			ConnectedAt:   info.ConnectedAt,
+		})
 	}
-	return generateRpcResponse(request.RequestId, true, true, ""), nil
+	return response, nil
 }
 
 func generateRpcResponse(requestId uint64, isProcessed, isSucceeded bool, failureReason string) *rpc.Response {
@@ -83,7 +96,14 @@ func generateRpcResponse(requestId uint64, isProcessed, isSucceeded bool, failur
 	}
 }
 
-func sendToUser(msgType byte, userList []int64, data []byte) (counter int) {
+func generateDeliveredResponse(requestId uint64, deliveredUsers []int64) *rpc.Response {
+	response := generateRpcResponse(requestId, len(deliveredUsers) != 0, true, "")
+	response.DeliveredUsers = deliveredUsers
+	return response
+}
+
+// sendToUser 向本节点上持有连接的用户发送数据，返回实际投递到的用户 id
+func sendToUser(msgType byte, userList []int64, data []byte) (deliveredUsers []int64) {
 	data = tools.GenerateResponseBytes(msgType, []byte{0, 0, 0, 0}, data)
 	for _, userId := range userList {
 		conns := controllers.GetConnection(userId)
@@ -91,7 +111,7 @@ func sendToUser(msgType byte, userList []int64, data []byte) (counter int) {
 			continue
 		}
 
-		counter++
+		deliveredUsers = append(deliveredUsers, userId)
 		for _, conn := range conns {
 			err := conn.AsyncWrite(data, nil)
 			if err != nil {
@@ -101,26 +121,3 @@ func sendToUser(msgType byte, userList []int64, data []byte) (counter int) {
 	}
 	return
 }
-
-func getUserListInGroup(groupId int64, onlyAdministrator bool) (userList []int64, err error) {
-	info, err := controllers.GetUserListInGroup(groupId, false)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, member := range info {
-		if member.IsDeleted {
-			continue
-		}
-
-		if onlyAdministrator {
-			if member.IsAdministrator {
-				userList = append(userList, member.MemberId)
-			}
-		} else {
-			userList = append(userList, member.MemberId)
-		}
-	}
-
-	return userList, nil
-}
@@ -0,0 +1,247 @@
+package search
+
+import (
+	"context"
+	"liveChat/db"
+	"liveChat/entities"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+type documentKey struct {
+	chatId int64
+	seq    uint64
+}
+
+// invertedIndex 是供单机部署使用的内存倒排索引。
+// 拉丁字母与数字按单词切分，其余字符（如中日韩文字）按单字切分，
+// 命中候选文档后再校验关键词是否完整出现在内容中
+type invertedIndex struct {
+	lock      sync.RWMutex
+	postings  map[string]map[documentKey]struct{}
+	documents map[documentKey]*entities.Message
+}
+
+func newInvertedIndex() *invertedIndex {
+	return &invertedIndex{
+		postings:  make(map[string]map[documentKey]struct{}),
+		documents: make(map[documentKey]*entities.Message),
+	}
+}
+
+func newInvertedIndexFromDb() (*invertedIndex, error) {
+	idx := newInvertedIndex()
+	if err := db.ScanMessages(context.Background(), idx.Add); err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
+
+// Add 将消息加入索引，加密消息的内容为密文，不加入索引
+func (idx *invertedIndex) Add(m *entities.Message) error {
+	if m.Type == entities.Encrypted {
+		return nil
+	}
+
+	key := documentKey{m.Receiver, m.Id}
+	tokens := tokenize(m.Content)
+
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	idx.documents[key] = m
+	for _, token := range tokens {
+		docs, ok := idx.postings[token]
+		if !ok {
+			docs = make(map[documentKey]struct{})
+			idx.postings[token] = docs
+		}
+		docs[key] = struct{}{}
+	}
+	return nil
+}
+
+// Purge 移除会话中序号不大于 purgedSeq 的消息
+func (idx *invertedIndex) Purge(chatId int64, purgedSeq uint64) error {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	for key := range idx.documents {
+		if key.chatId == chatId && key.seq <= purgedSeq {
+			idx.remove(key)
+		}
+	}
+	return nil
+}
+
+func (idx *invertedIndex) Delete(chatId int64, seq uint64) error {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	idx.remove(documentKey{chatId, seq})
+	return nil
+}
+
+// remove 调用方需持有写锁
+func (idx *invertedIndex) remove(key documentKey) {
+	m, ok := idx.documents[key]
+	if !ok {
+		return
+	}
+
+	delete(idx.documents, key)
+	for _, token := range tokenize(m.Content) {
+		if docs, ok := idx.postings[token]; ok {
+			delete(docs, key)
+			if len(docs) == 0 {
+				delete(idx.postings, token)
+			}
+		}
+	}
+}
+
+func (idx *invertedIndex) Search(ctx context.Context, q *Query) ([]Hit, int64, error) {
+	keywords := strings.Fields(q.Keyword)
+	tokens := tokenize(q.Keyword)
+	if len(tokens) == 0 {
+		return []Hit{}, 0, nil
+	}
+
+	idx.lock.RLock()
+	candidates := idx.intersect(tokens)
+	matched := make([]*entities.Message, 0, len(candidates))
+	for _, key := range candidates {
+		m := idx.documents[key]
+		if matchFilter(m, &q.MessageFilter) && containsAll(m.Content, keywords) {
+			matched = append(matched, m)
+		}
+	}
+	idx.lock.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Timestamp != matched[j].Timestamp {
+			return matched[i].Timestamp > matched[j].Timestamp
+		}
+		return matched[i].Id > matched[j].Id
+	})
+
+	if q.Cursor >= int64(len(matched)) {
+		return []Hit{}, 0, nil
+	}
+
+	end, nextCursor := q.Cursor+q.Limit, int64(0)
+	if end < int64(len(matched)) {
+		nextCursor = end
+	} else {
+		end = int64(len(matched))
+	}
+
+	hits := make([]Hit, 0, end-q.Cursor)
+	for _, m := range matched[q.Cursor:end] {
+		hits = append(hits, Hit{Message: *m, Highlight: Highlight(m.Content, keywords)})
+	}
+	return hits, nextCursor, nil
+}
+
+func (idx *invertedIndex) intersect(tokens []string) []documentKey {
+	lists := make([]map[documentKey]struct{}, 0, len(tokens))
+	for _, token := range tokens {
+		docs, ok := idx.postings[token]
+		if !ok {
+			return nil
+		}
+		lists = append(lists, docs)
+	}
+
+	sort.Slice(lists, func(i, j int) bool { return len(lists[i]) < len(lists[j]) })
+
+	ret := make([]documentKey, 0, len(lists[0]))
+	for key := range lists[0] {
+		found := true
+		for _, docs := range lists[1:] {
+			if _, ok := docs[key]; !ok {
+				found = false
+				break
+			}
+		}
+		if found {
+			ret = append(ret, key)
+		}
+	}
+	return ret
+}
+
+func matchFilter(m *entities.Message, filter *db.MessageFilter) bool {
+	if filter.Sender != 0 && m.Sender != filter.Sender {
+		return false
+	}
+	if filter.From != 0 && m.Timestamp < filter.From {
+		return false
+	}
+	if filter.To != 0 && m.Timestamp > filter.To {
+		return false
+	}
+
+	if filter.ChatId < 0 {
+		return m.Receiver == filter.ChatId
+	} else if filter.ChatId > 0 {
+		return (m.Sender == filter.UserId && m.Receiver == filter.ChatId) ||
+			(m.Sender == filter.ChatId && m.Receiver == filter.UserId)
+	}
+
+	if m.Receiver == filter.UserId || (m.Sender == filter.UserId && m.Receiver > 0) {
+		return true
+	}
+	for _, groupId := range filter.GroupIds {
+		if m.Receiver == groupId {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAll(content string, keywords []string) bool {
+	content = strings.ToLower(content)
+	for _, keyword := range keywords {
+		if !strings.Contains(content, strings.ToLower(keyword)) {
+			return false
+		}
+	}
+	return true
+}
+
+func tokenize(content string) []string {
+	tokens := make([]string, 0)
+	visited := make(map[string]struct{})
+	appendToken := func(token string) {
+		if _, ok := visited[token]; ok {
+			return
+		}
+		visited[token] = struct{}{}
+		tokens = append(tokens, token)
+	}
+
+	word := strings.Builder{}
+	flushWord := func() {
+		if word.Len() != 0 {
+			appendToken(word.String())
+			word.Reset()
+		}
+	}
+
+	for _, r := range strings.ToLower(content) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			word.WriteRune(r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushWord()
+			appendToken(string(r))
+		default:
+			flushWord()
+		}
+	}
+	flushWord()
+	return tokens
+}
@@ -0,0 +1,181 @@
+package search
+
+import (
+	"context"
+	"liveChat/db"
+	"liveChat/entities"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	cases := []struct {
+		content  string
+		keywords []string
+		expected string
+	}{
+		{"Hello World", []string{"world"}, "Hello <em>World</em>"},
+		{"今天天气不错", []string{"天气"}, "今天<em>天气</em>不错"},
+		{"aaa", []string{"a", "aa"}, "<em>aaa</em>"},
+		{"no match", []string{"xyz"}, "no match"},
+		{"<b>a&b</b>", []string{"a&b"}, "&lt;b&gt;<em>a&amp;b</em>&lt;/b&gt;"},
+	}
+
+	for _, c := range cases {
+		if ret := Highlight(c.content, c.keywords); ret != c.expected {
+			t.Errorf("Highlight(%q, %v) = %q, expected %q", c.content, c.keywords, ret, c.expected)
+		}
+	}
+}
+
+func TestInvertedIndexSearch(t *testing.T) {
+	const (
+		alice  = int64(1)
+		bob    = int64(2)
+		carol  = int64(3)
+		group  = int64(-100)
+		group2 = int64(-200)
+	)
+
+	idx := newInvertedIndex()
+	messages := []*entities.Message{
+		entities.NewMessage(1, alice, bob, 100, entities.Text, "明天一起吃饭吗"),
+		entities.NewMessage(1, bob, alice, 101, entities.Text, "好的，明天中午吃饭"),
+		entities.NewMessage(1, alice, group, 102, entities.Text, "Meeting tomorrow, 明天开会"),
+		entities.NewMessage(1, carol, group2, 103, entities.Text, "明天放假"),
+		entities.NewMessage(2, carol, alice, 104, entities.Text, "天明了"),
+	}
+	for _, m := range messages {
+		if err := idx.Add(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	search := func(filter db.MessageFilter, cursor, limit int64) ([]Hit, int64) {
+		hits, next, err := idx.Search(context.Background(), &Query{MessageFilter: filter, Cursor: cursor, Limit: limit})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hits, next
+	}
+
+	hits, _ := search(db.MessageFilter{Keyword: "明天", UserId: alice, GroupIds: []int64{group}}, 0, 10)
+	if len(hits) != 3 {
+		t.Fatalf("expected 3 hits in alice's chats, got %d", len(hits))
+	}
+	if hits[0].Message.Timestamp != 102 || hits[0].Highlight != "Meeting tomorrow, <em>明天</em>开会" {
+		t.Errorf("unexpected first hit: %+v", hits[0])
+	}
+
+	hits, _ = search(db.MessageFilter{Keyword: "MEETING", UserId: alice, GroupIds: []int64{group}}, 0, 10)
+	if len(hits) != 1 {
+		t.Errorf("expected case-insensitive word match, got %d hits", len(hits))
+	}
+
+	hits, _ = search(db.MessageFilter{Keyword: "明天", UserId: alice, ChatId: bob, Sender: bob}, 0, 10)
+	if len(hits) != 1 || hits[0].Message.Sender != bob {
+		t.Errorf("expected only bob's message in the private chat, got %+v", hits)
+	}
+
+	hits, _ = search(db.MessageFilter{Keyword: "明天", UserId: alice, GroupIds: []int64{group}, From: 101, To: 101}, 0, 10)
+	if len(hits) != 1 || hits[0].Message.Timestamp != 101 {
+		t.Errorf("expected time range filter to apply, got %+v", hits)
+	}
+
+	page, next := search(db.MessageFilter{Keyword: "明天", UserId: alice, GroupIds: []int64{group}}, 0, 2)
+	if len(page) != 2 || next != 2 {
+		t.Fatalf("expected first page of 2 with cursor 2, got %d hits and cursor %d", len(page), next)
+	}
+	page, next = search(db.MessageFilter{Keyword: "明天", UserId: alice, GroupIds: []int64{group}}, next, 2)
+	if len(page) != 1 || next != 0 {
+		t.Errorf("expected last page of 1 with no cursor, got %d hits and cursor %d", len(page), next)
+	}
+}
+
+func TestInvertedIndexPurge(t *testing.T) {
+	const (
+		alice = int64(1)
+		group = int64(-100)
+	)
+
+	idx := newInvertedIndex()
+	for seq := uint64(1); seq <= 3; seq++ {
+		if err := idx.Add(entities.NewMessage(seq, alice, group, 100+seq, entities.Text, "明天开会")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := idx.Add(entities.NewMessage(1, alice, -200, 100, entities.Text, "明天开会")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := idx.Purge(group, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	hits, _, err := idx.Search(context.Background(), &Query{
+		MessageFilter: db.MessageFilter{Keyword: "开会", UserId: alice, GroupIds: []int64{group, -200}},
+		Limit:         10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 2 {
+		t.Fatalf("expected 2 hits after purge, got %d", len(hits))
+	}
+	for _, hit := range hits {
+		if hit.Message.Receiver == group && hit.Message.Id <= 2 {
+			t.Errorf("purged message still searchable: %+v", hit.Message)
+		}
+	}
+}
+
+func TestInvertedIndexSkipsEncrypted(t *testing.T) {
+	const alice, bob = int64(1), int64(2)
+
+	idx := newInvertedIndex()
+	if err := idx.Add(entities.NewMessage(1, alice, bob, 100, entities.Encrypted, "c2VjcmV0")); err != nil {
+		t.Fatal(err)
+	}
+
+	hits, _, err := idx.Search(context.Background(), &Query{
+		MessageFilter: db.MessageFilter{Keyword: "c2VjcmV0", UserId: alice, ChatId: bob},
+		Limit:         10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 0 {
+		t.Errorf("encrypted message is searchable: %+v", hits)
+	}
+}
+
+func TestInvertedIndexDelete(t *testing.T) {
+	const (
+		alice = int64(1)
+		group = int64(-100)
+	)
+
+	idx := newInvertedIndex()
+	for seq := uint64(1); seq <= 2; seq++ {
+		if err := idx.Add(entities.NewMessage(seq, alice, group, 100+seq, entities.Text, "明天开会")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := idx.Delete(group, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := idx.Delete(group, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	hits, _, err := idx.Search(context.Background(), &Query{
+		MessageFilter: db.MessageFilter{Keyword: "开会", UserId: alice, GroupIds: []int64{group}},
+		Limit:         10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 1 || hits[0].Message.Id != 2 {
+		t.Errorf("expected only message 2 after delete, got %+v", hits)
+	}
+}
@@ -0,0 +1,168 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"html"
+	"liveChat/db"
+	"liveChat/entities"
+	"liveChat/log"
+	"strings"
+)
+
+const (
+	EngineMongoDB  = "mongodb"
+	EngineInverted = "inverted"
+)
+
+const (
+	highlightPrefix = "<em>"
+	highlightSuffix = "</em>"
+)
+
+var ErrorUnknownEngine = errors.New("未知的检索引擎")
+
+type Query struct {
+	db.MessageFilter
+	Cursor int64
+	Limit  int64
+}
+
+type Hit struct {
+	Message   entities.Message
+	Highlight string
+}
+
+// MessageIndex 是消息检索索引的抽象，Add 会在每条消息入库后被调用，Purge 会在会话消息按保留策略清理后被调用，
+// Delete 会在单条消息被删除后被调用
+type MessageIndex interface {
+	Add(m *entities.Message) error
+	Purge(chatId int64, purgedSeq uint64) error
+	Delete(chatId int64, seq uint64) error
+	Search(ctx context.Context, q *Query) (hits []Hit, nextCursor int64, err error)
+}
+
+var index MessageIndex
+
+func InitMessageIndex(engine string) {
+	var err error
+	switch engine {
+	case "", EngineMongoDB:
+		index, err = newMongoIndex()
+	case EngineInverted:
+		index, err = newInvertedIndexFromDb()
+	default:
+		err = ErrorUnknownEngine
+	}
+
+	if err != nil {
+		panic(err)
+	}
+
+	db.RegisterMessageHook(func(m *entities.Message) {
+		if err := index.Add(m); err != nil {
+			log.Error(err.Error())
+		}
+	})
+	db.RegisterPurgeHook(func(chatId int64, purgedSeq uint64) {
+		if err := index.Purge(chatId, purgedSeq); err != nil {
+			log.Error(err.Error())
+		}
+	})
+	db.RegisterDeleteHook(func(chatId int64, seq uint64) {
+		if err := index.Delete(chatId, seq); err != nil {
+			log.Error(err.Error())
+		}
+	})
+}
+
+func SearchMessages(ctx context.Context, q *Query) ([]Hit, int64, error) {
+	return index.Search(ctx, q)
+}
+
+// Highlight 将内容中与关键词匹配的部分（忽略大小写）用 <em></em> 包裹，其余内容按 HTML 转义，结果可直接作为 HTML 展示
+func Highlight(content string, keywords []string) string {
+	lower := strings.ToLower(content)
+	if len(lower) != len(content) {
+		lower = content
+	}
+
+	marks := make([]bool, len(content))
+	for _, keyword := range keywords {
+		keyword = strings.ToLower(keyword)
+		if keyword == "" {
+			continue
+		}
+
+		for offset := 0; offset < len(lower); {
+			pos := strings.Index(lower[offset:], keyword)
+			if pos == -1 {
+				break
+			}
+			for i := offset + pos; i < offset+pos+len(keyword); i++ {
+				marks[i] = true
+			}
+			offset += pos + len(keyword)
+		}
+	}
+
+	// 匹配的边界总在完整的关键词处，按段转义不会截断多字节字符
+	builder := strings.Builder{}
+	builder.Grow(len(content) + 16)
+	for start := 0; start < len(content); {
+		end := start + 1
+		for end < len(content) && marks[end] == marks[start] {
+			end++
+		}
+
+		if marks[start] {
+			builder.WriteString(highlightPrefix)
+			builder.WriteString(html.EscapeString(content[start:end]))
+			builder.WriteString(highlightSuffix)
+		} else {
+			builder.WriteString(html.EscapeString(content[start:end]))
+		}
+		start = end
+	}
+	return builder.String()
+}
+
+type mongoIndex struct{}
+
+func newMongoIndex() (*mongoIndex, error) {
+	if err := db.EnsureMessageTextIndex(context.Background()); err != nil {
+		return nil, err
+	}
+	return &mongoIndex{}, nil
+}
+
+func (idx *mongoIndex) Add(m *entities.Message) error {
+	return nil
+}
+
+func (idx *mongoIndex) Purge(chatId int64, purgedSeq uint64) error {
+	return nil
+}
+
+func (idx *mongoIndex) Delete(chatId int64, seq uint64) error {
+	return nil
+}
+
+func (idx *mongoIndex) Search(ctx context.Context, q *Query) ([]Hit, int64, error) {
+	messages, err := db.SearchMessageByText(ctx, &q.MessageFilter, q.Cursor, q.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	keywords := strings.Fields(q.Keyword)
+	hits := make([]Hit, len(messages), len(messages))
+	for i := range messages {
+		hits[i] = Hit{Message: messages[i], Highlight: Highlight(messages[i].Content, keywords)}
+	}
+
+	nextCursor := int64(0)
+	if int64(len(messages)) == q.Limit {
+		nextCursor = q.Cursor + q.Limit
+	}
+	return hits, nextCursor, nil
+}
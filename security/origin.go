@@ -0,0 +1,95 @@
+package security
+
+import (
+	"fmt"
+	"liveChat/config"
+	"net"
+	"strings"
+)
+
+// OriginPolicy 根据允许列表校验请求的 Origin 与 Host，列表中的 * 可匹配任意字符，匹配时忽略大小写。
+// 列表为空时不限制对应的字段
+type OriginPolicy struct {
+	origins []string
+	hosts   []string
+}
+
+func NewOriginPolicy(cfg config.CorsConfig) *OriginPolicy {
+	return &OriginPolicy{origins: normalizePatterns(cfg.AllowedOrigins), hosts: normalizePatterns(cfg.AllowedHosts)}
+}
+
+func normalizePatterns(patterns []string) []string {
+	ret := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if pattern = strings.ToLower(strings.TrimSpace(pattern)); pattern != "" {
+			ret = append(ret, strings.TrimSuffix(pattern, "/"))
+		}
+	}
+	return ret
+}
+
+// CheckOrigin Origin 为空时视为非浏览器请求，不做限制
+func (p *OriginPolicy) CheckOrigin(origin string) error {
+	if origin == "" || len(p.origins) == 0 {
+		return nil
+	}
+
+	origin = strings.ToLower(origin)
+	for _, pattern := range p.origins {
+		if matchWildcard(pattern, origin) {
+			return nil
+		}
+	}
+	return fmt.Errorf("Origin %s 不在允许列表中", origin)
+}
+
+// CheckHost 允许列表中未写端口的项匹配任意端口
+func (p *OriginPolicy) CheckHost(host string) error {
+	if len(p.hosts) == 0 {
+		return nil
+	} else if host == "" {
+		return fmt.Errorf("缺少 Host")
+	}
+
+	host = strings.ToLower(host)
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+
+	for _, pattern := range p.hosts {
+		if matchWildcard(pattern, host) || matchWildcard(pattern, hostname) {
+			return nil
+		}
+	}
+	return fmt.Errorf("Host %s 不在允许列表中", host)
+}
+
+// matchWildcard 判断 s 是否匹配 pattern。单独的 * 匹配任意字符串，其余位置的 * 匹配不含 / 与 : 的任意字符，
+// 避免通配跨越协议、端口或路径
+func matchWildcard(pattern, s string) bool {
+	return pattern == "*" || matchPattern(pattern, s)
+}
+
+func matchPattern(pattern, s string) bool {
+	for len(pattern) != 0 {
+		if pattern[0] != '*' {
+			if len(s) == 0 || s[0] != pattern[0] {
+				return false
+			}
+			pattern, s = pattern[1:], s[1:]
+			continue
+		}
+
+		pattern = pattern[1:]
+		for i := 0; ; i++ {
+			if matchPattern(pattern, s[i:]) {
+				return true
+			}
+			if i == len(s) || s[i] == '/' || s[i] == ':' {
+				return false
+			}
+		}
+	}
+	return len(s) == 0
+}
@@ -0,0 +1,71 @@
+package security
+
+import (
+	"liveChat/config"
+	"testing"
+)
+
+func TestMatchWildcard(t *testing.T) {
+	cases := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"https://example.com", "https://example.com", true},
+		{"https://example.com", "https://example.com.evil.org", false},
+		{"https://*.example.com", "https://app.example.com", true},
+		{"https://*.example.com", "https://a.b.example.com", true},
+		{"https://*.example.com", "https://example.com", false},
+		{"https://*.example.com", "https://evil.org/.example.com", false},
+		{"https://*.example.com", "https://evil.org:1.example.com", false},
+		{"*", "anything", true},
+		{"http://localhost:*", "http://localhost:3000", true},
+		{"*.example.*", "api.example.org", true},
+		{"a*a", "a", false},
+	}
+
+	for _, c := range cases {
+		if got := matchWildcard(c.pattern, c.s); got != c.want {
+			t.Errorf("matchWildcard(%q, %q) = %v, want %v", c.pattern, c.s, got, c.want)
+		}
+	}
+}
+
+func TestOriginPolicy(t *testing.T) {
+	policy := NewOriginPolicy(config.CorsConfig{
+		AllowedOrigins: []string{"https://*.Example.com/", " https://chat.example.org "},
+		AllowedHosts:   []string{"api.example.com", "*.internal:8443"},
+	})
+
+	origins := map[string]bool{
+		"":                          true,
+		"https://web.example.com":   true,
+		"HTTPS://WEB.EXAMPLE.COM":   true,
+		"https://chat.example.org":  true,
+		"http://web.example.com":    false,
+		"https://chat.example.org2": false,
+	}
+	for origin, want := range origins {
+		if err := policy.CheckOrigin(origin); (err == nil) != want {
+			t.Errorf("origin %q: got %v, want allowed %v", origin, err, want)
+		}
+	}
+
+	hosts := map[string]bool{
+		"api.example.com":     true,
+		"api.example.com:443": true,
+		"node1.internal:8443": true,
+		"node1.internal:80":   false,
+		"other.example.com":   false,
+		"":                    false,
+	}
+	for host, want := range hosts {
+		if err := policy.CheckHost(host); (err == nil) != want {
+			t.Errorf("host %q: got %v, want allowed %v", host, err, want)
+		}
+	}
+
+	open := NewOriginPolicy(config.CorsConfig{})
+	if open.CheckOrigin("https://any.org") != nil || open.CheckHost("any.org") != nil {
+		t.Error("empty allow-list should not restrict")
+	}
+}
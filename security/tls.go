@@ -0,0 +1,209 @@
+package security
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"liveChat/config"
+	"liveChat/log"
+	"os"
+	"sync"
+	"time"
+)
+
+const defaultReloadInterval = time.Minute
+
+var (
+	ErrorClusterCaMissing  = errors.New("节点间双向 TLS 需要配置集群 CA")
+	ErrorCaFileIllegal     = errors.New("CA 文件中没有可用的证书")
+	ErrorNoPeerCertificate = errors.New("对端未提供证书")
+)
+
+// certificateStore 保存当前使用的证书与 CA，文件修改时间变化时重新加载，加载失败时继续使用旧的证书
+type certificateStore struct {
+	cfg config.TLSConfig
+
+	lock        sync.RWMutex
+	certificate *tls.Certificate
+	caPool      *x509.CertPool
+	modTimes    map[string]time.Time
+}
+
+func newCertificateStore(cfg config.TLSConfig) (*certificateStore, error) {
+	s := &certificateStore{cfg: cfg, modTimes: make(map[string]time.Time)}
+	if _, err := s.reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// reload 在任一文件的修改时间变化时重新加载全部文件，返回是否发生了重新加载
+func (s *certificateStore) reload() (bool, error) {
+	files := []string{s.cfg.CertFile, s.cfg.KeyFile}
+	if s.cfg.ClientCaFile != "" {
+		files = append(files, s.cfg.ClientCaFile)
+	}
+
+	modTimes := make(map[string]time.Time, len(files))
+	changed := false
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return false, err
+		}
+		modTimes[file] = info.ModTime()
+		if t, ok := s.modTimes[file]; !ok || !t.Equal(info.ModTime()) {
+			changed = true
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(s.cfg.CertFile, s.cfg.KeyFile)
+	if err != nil {
+		return false, err
+	}
+
+	var caPool *x509.CertPool
+	if s.cfg.ClientCaFile != "" {
+		if caPool, err = loadCaPool(s.cfg.ClientCaFile); err != nil {
+			return false, err
+		}
+	}
+
+	s.lock.Lock()
+	s.certificate, s.caPool, s.modTimes = &certificate, caPool, modTimes
+	s.lock.Unlock()
+	return true, nil
+}
+
+func (s *certificateStore) watch(interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultReloadInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := s.reload(); err != nil {
+				log.Error(fmt.Sprintf("重新加载证书 %s 失败，继续使用旧证书: %s", s.cfg.CertFile, err.Error()))
+			}
+		}
+	}()
+}
+
+func (s *certificateStore) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.certificate, nil
+}
+
+func (s *certificateStore) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.certificate, nil
+}
+
+func (s *certificateStore) getCaPool() *x509.CertPool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.caPool
+}
+
+// verifyServer 使用当前的 CA 校验被调用节点的证书，使 CA 的更新无需重新建立配置
+func (s *certificateStore) verifyServer(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return ErrorNoPeerCertificate
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       state.ServerName,
+		Roots:         s.getCaPool(),
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	return err
+}
+
+func loadCaPool(path string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, ErrorCaFileIllegal
+	}
+	return pool, nil
+}
+
+// NewServerTLSConfig 返回支持证书热加载的服务端配置，未配置证书时返回 nil。
+// 配置了 ClientCaFile 时要求客户端提供由该 CA 签发的证书
+func NewServerTLSConfig(cfg config.TLSConfig, reloadInterval time.Duration) (*tls.Config, error) {
+	if !cfg.IsEnabled() {
+		return nil, nil
+	}
+
+	store, err := newCertificateStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	store.watch(reloadInterval)
+	return newServerTLSConfig(store), nil
+}
+
+func newServerTLSConfig(store *certificateStore) *tls.Config {
+	tlsConfig := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: store.getCertificate,
+	}
+	if store.cfg.ClientCaFile == "" {
+		return tlsConfig
+	}
+
+	// 每次握手时使用当前的 CA，CA 文件更新后新连接立即生效
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			c := tlsConfig.Clone()
+			c.ClientAuth = tls.RequireAndVerifyClientCert
+			c.ClientCAs = store.getCaPool()
+			return c, nil
+		},
+	}
+}
+
+// NewClusterTLSConfig 返回节点间双向 TLS 使用的服务端与客户端配置，未配置证书时均返回 nil
+func NewClusterTLSConfig(cfg config.TLSConfig, reloadInterval time.Duration) (server, client *tls.Config, err error) {
+	if !cfg.IsEnabled() {
+		return nil, nil, nil
+	} else if cfg.ClientCaFile == "" {
+		return nil, nil, ErrorClusterCaMissing
+	}
+
+	store, err := newCertificateStore(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	store.watch(reloadInterval)
+	return newServerTLSConfig(store), newClientTLSConfig(store), nil
+}
+
+func newClientTLSConfig(store *certificateStore) *tls.Config {
+	// 默认的校验只能使用创建配置时的 CA，这里改为在 VerifyConnection 中使用当前的 CA 校验
+	return &tls.Config{
+		MinVersion:           tls.VersionTLS12,
+		GetClientCertificate: store.getClientCertificate,
+		InsecureSkipVerify:   true,
+		VerifyConnection:     store.verifyServer,
+	}
+}
@@ -0,0 +1,206 @@
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"liveChat/config"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCa struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCa(t *testing.T) *testCa {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCa{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue 签发同时可用于服务端与客户端认证的节点证书，返回 pem 编码的证书与私钥
+func (ca *testCa) issue(t *testing.T, serial int64, name string) (certPem, keyPem []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeNodeFiles(t *testing.T, dir string, ca *testCa, serial int64, modTime time.Time) config.TLSConfig {
+	cfg := config.TLSConfig{
+		CertFile:     filepath.Join(dir, "node.crt"),
+		KeyFile:      filepath.Join(dir, "node.key"),
+		ClientCaFile: filepath.Join(dir, "ca.crt"),
+	}
+	certPem, keyPem := ca.issue(t, serial, "localhost")
+	writeFile(t, cfg.CertFile, certPem, modTime)
+	writeFile(t, cfg.KeyFile, keyPem, modTime)
+	writeFile(t, cfg.ClientCaFile, ca.pem, modTime)
+	return cfg
+}
+
+func TestCertificateStoreReload(t *testing.T) {
+	var (
+		ca      = newTestCa(t)
+		dir     = t.TempDir()
+		modTime = time.Now().Add(-time.Minute)
+		cfg     = writeNodeFiles(t, dir, ca, 2, modTime)
+	)
+
+	store, err := newCertificateStore(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _ := store.getCertificate(nil)
+
+	if reloaded, err := store.reload(); err != nil || reloaded {
+		t.Fatalf("unchanged files: reloaded %v, err %v", reloaded, err)
+	}
+
+	writeNodeFiles(t, dir, ca, 3, modTime.Add(time.Second))
+	if reloaded, err := store.reload(); err != nil || !reloaded {
+		t.Fatalf("changed files: reloaded %v, err %v", reloaded, err)
+	}
+	if second, _ := store.getCertificate(nil); second == first {
+		t.Error("certificate is not replaced after reload")
+	}
+
+	writeFile(t, cfg.KeyFile, []byte("broken"), modTime.Add(time.Second*2))
+	if _, err := store.reload(); err == nil {
+		t.Error("broken key should fail to load")
+	}
+	if current, _ := store.getCertificate(nil); current == first || current == nil {
+		t.Error("previous certificate should be kept when reload fails")
+	}
+}
+
+func TestClusterMutualTLS(t *testing.T) {
+	var (
+		ca  = newTestCa(t)
+		cfg = writeNodeFiles(t, t.TempDir(), ca, 2, time.Now())
+	)
+
+	server, client, err := NewClusterTLSConfig(cfg, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = conn.(*tls.Conn).Handshake()
+				_, _ = conn.Write([]byte{1})
+			}()
+		}
+	}()
+
+	handshake := func(c *tls.Config) error {
+		c = c.Clone()
+		c.ServerName = "localhost"
+		conn, err := tls.Dial("tcp", listener.Addr().String(), c)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		// TLS 1.3 中服务端对客户端证书的拒绝在读取时才会返回
+		_, err = conn.Read(make([]byte, 1))
+		return err
+	}
+
+	if err = handshake(client); err != nil {
+		t.Errorf("cluster member rejected: %v", err)
+	}
+
+	anonymous := &tls.Config{RootCAs: x509.NewCertPool()}
+	anonymous.RootCAs.AddCert(ca.cert)
+	if err = handshake(anonymous); err == nil {
+		t.Error("client without certificate accepted")
+	}
+
+	otherCfg := writeNodeFiles(t, t.TempDir(), newTestCa(t), 2, time.Now())
+	_, otherClient, err := NewClusterTLSConfig(otherCfg, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = handshake(otherClient); err == nil {
+		t.Error("node from another cluster accepted")
+	}
+}
+
+func TestClusterTLSRequiresCa(t *testing.T) {
+	_, _, err := NewClusterTLSConfig(config.TLSConfig{CertFile: "a", KeyFile: "b"}, 0)
+	if err != ErrorClusterCaMissing {
+		t.Errorf("got %v, want %v", err, ErrorClusterCaMissing)
+	}
+
+	if server, client, err := NewClusterTLSConfig(config.TLSConfig{}, 0); server != nil || client != nil || err != nil {
+		t.Error("disabled config should return nil")
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"liveChat/db"
 	"liveChat/entities"
 	"liveChat/log"
+	"liveChat/moderation"
 	"liveChat/pool"
 	"liveChat/rpc"
 	"liveChat/tools"
@@ -21,8 +22,13 @@ import (
 
 var workerPool *pool.WorkerPool
 
+var (
+	ErrorMessageStoreFailed = errors.New("消息入库失败")
+	ErrorEncryptedGroupChat = errors.New("加密消息仅支持私聊")
+)
+
 func init() {
-	workerPool, _ = pool.NewWorkerPool(requestAsyncHandler)
+	workerPool, _ = pool.NewWorkerPool("tcp", requestAsyncHandler)
 }
 
 func PushTask(arg interface{}) {
@@ -65,6 +71,11 @@ func requestAsyncHandler(arg interface{}) {
 		pool.PutRequestPackage(task)
 	}()
 
+	if limitedSlice, limited := checkLoadRate(ctx.UserId, task.RequestType); limited {
+		retSlice, retType = limitedSlice, constants.ErrorResponseLoad
+		return
+	}
+
 	switch task.RequestType {
 	case constants.ErrorResponseLoad:
 	case constants.SuccessResponseLoad:
@@ -80,22 +91,10 @@ func requestAsyncHandler(arg interface{}) {
 			return
 		}
 
-		if err = checkAuthForRelationships(ctx.UserId, message.Receiver); err != nil {
-			return
-		}
-
-		if err = db.AddMessage(context.Background(), &message); err != nil {
-			err = errors.New(fmt.Sprintf("消息入库失败: %s", err.Error()))
+		if err = PostMessage(&message); err != nil {
 			return
 		}
 
-		SendMessage(&message)
-		err = db.CacheMessageWithTimeOut(entities.NewMessageFromProtobufWithSeq(&message))
-		if err != nil {
-			log.Error(fmt.Sprintf("消息缓存 Redis 失败: %s", err.Error()))
-			err = nil
-		}
-
 		retType = constants.SuccessResponseLoad
 
 	case constants.RequestMessageLoad:
@@ -115,6 +114,13 @@ func requestAsyncHandler(arg interface{}) {
 			message, err = db.GetMessageInSeq(context.Background(), request.Receiver, request.Id)
 		}
 
+		if err != nil {
+			if purgedSeq, _ := db.GetChatPurgedSequence(context.Background(), request.Receiver); request.Id <= purgedSeq {
+				err = errors.New("消息已按保留策略清理")
+				return
+			}
+		}
+
 		if err != nil {
 			err = errors.New(fmt.Sprintf("从数据库中获取消息失败: %s", err.Error()))
 			return
@@ -138,8 +144,11 @@ func requestAsyncHandler(arg interface{}) {
 			return
 		}
 
-		var messages []entities.Message
-		messages, err = db.GetMessageInSeqRange(context.Background(), request.Receiver, request.BottomId, request.TopId)
+		var (
+			messages []entities.Message
+			gap      *entities.MessageGap
+		)
+		messages, gap, err = db.GetMessageInSeqRange(context.Background(), request.Receiver, request.BottomId, request.TopId)
 		if err != nil {
 			err = errors.New(fmt.Sprintf("从数据库中批量获取消息失败: %s", err.Error()))
 			return
@@ -151,6 +160,9 @@ func requestAsyncHandler(arg interface{}) {
 		}
 
 		multiMessage := rpc.MultiMessage{Messages: protoMessageSlice}
+		if gap != nil {
+			multiMessage.Gap = &rpc.MessageGap{BottomId: gap.BottomId, TopId: gap.TopId}
+		}
 
 		retSlice, err = proto.Marshal(&multiMessage)
 		if err != nil {
@@ -200,6 +212,67 @@ func requestAsyncHandler(arg interface{}) {
 	}
 }
 
+// PostMessage 校验发送者权限后将消息入库、投递并缓存，加密消息不审核内容、原样转发与存储，成功后 message.Id 为分配的序号
+func PostMessage(message *rpc.Message) error {
+	defer messageSendSeconds.With().ObserveSince(time.Now())
+
+	if message.Type == rpc.Message_Encrypted && message.Receiver < 0 {
+		return ErrorEncryptedGroupChat
+	}
+
+	if err := checkAuthForRelationships(message.Sender, message.Receiver); err != nil {
+		return err
+	}
+
+	if err := checkBlocked(message.Sender, message.Receiver); err != nil {
+		return err
+	}
+
+	if err := controllers.CheckIsUserMuted(message.Sender, message.Receiver); err != nil {
+		return err
+	}
+
+	mentionedUsers, err := controllers.ValidateMentions(message.Sender, message.Receiver, message.Mentions, message.MentionAll)
+	if err != nil {
+		return err
+	}
+
+	if err = moderateMessage(message); err != nil {
+		return err
+	}
+
+	if err = db.AddMessage(context.Background(), message); err != nil {
+		return fmt.Errorf("%w: %s", ErrorMessageStoreFailed, err.Error())
+	}
+
+	SendMessage(message)
+	entity := entities.NewMessageFromProtobufWithSeq(message)
+	if err = db.AddMentions(context.Background(), entity, mentionedUsers); err != nil {
+		log.Error(fmt.Sprintf("提及记录入库失败: %s", err.Error()))
+	}
+
+	if err = db.CacheMessageWithTimeOut(entity); err != nil {
+		log.Error(fmt.Sprintf("消息缓存 Redis 失败: %s", err.Error()))
+	}
+	return nil
+}
+
+// moderateMessage 审核文本消息，命中屏蔽词时原地替换消息内容。加密消息服务端无法审核
+func moderateMessage(message *rpc.Message) error {
+	if message.Type != rpc.Message_Text {
+		return nil
+	}
+
+	for i, content := range message.Contents {
+		verdict, err := moderation.Moderate(message.Sender, moderation.SceneMessage, content)
+		if err != nil {
+			return err
+		}
+		message.Contents[i] = verdict.Text
+	}
+	return nil
+}
+
 func checkAuthForRelationships(sender, receiver int64) error {
 	if receiver < 0 {
 		flag, err := controllers.CheckIsUserInGroup(sender, receiver, false)
@@ -220,6 +293,21 @@ func checkAuthForRelationships(sender, receiver int64) error {
 	return nil
 }
 
+// checkBlocked 检查私聊消息的发送者是否被接收者拉黑，只用于发送，不影响历史消息的读取
+func checkBlocked(sender, receiver int64) error {
+	if receiver < 0 {
+		return nil
+	}
+
+	flag, err := controllers.CheckIsUserBlocked(receiver, sender, false)
+	if err != nil {
+		return errors.New(fmt.Sprintf("无法鉴别用户信息: %s", err.Error()))
+	} else if flag {
+		return errors.New("已被目标用户加入黑名单")
+	}
+	return nil
+}
+
 func errorHandleHook(errInfo string) (retSlice []byte) {
 	log.Error(errInfo)
 	retSlice = tools.GenerateErrorResponseBytes(errInfo)
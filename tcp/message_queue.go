@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"github.com/Shopify/sarama"
 	"github.com/golang/protobuf/proto"
+	"liveChat/bot"
 	"liveChat/controllers"
 	"liveChat/db"
 	"liveChat/log"
+	"liveChat/push"
 	"liveChat/rpc"
+	"liveChat/webhook"
 	"time"
 )
 
@@ -45,19 +48,39 @@ func consumeMessageFunc(m *sarama.ConsumerMessage) {
 		return
 	}
 
+	webhook.PublishMessage(&message)
+
 	messageRequest := rpc.MessageRequest{
 		RequestId: 0,
 		Message:   &message,
 	}
 
+	start := time.Now()
+	delivered := make(map[int64]struct{})
 	clients := controllers.GetAllServerClients()
 	for _, client := range clients {
 		ctx, cfn := context.WithTimeout(context.Background(), time.Second*3)
-		_, err := client.BroadcastMessage(ctx, &messageRequest, nil)
+		resp, err := client.BroadcastMessage(ctx, &messageRequest, nil)
 		cfn()
 		if err != nil {
 			log.Error(err.Error())
 			continue
 		}
+
+		for _, userId := range resp.DeliveredUsers {
+			delivered[userId] = struct{}{}
+		}
+	}
+	messageFanoutSeconds.With().ObserveSince(start)
+
+	recipients := []int64{message.Receiver}
+	if message.Receiver < 0 {
+		var err error
+		if recipients, err = controllers.GetUserIdListInGroup(message.Receiver, false); err != nil {
+			log.Error(fmt.Sprintf("获取群组 %d 成员失败: %s", message.Receiver, err.Error()))
+			return
+		}
 	}
+	bot.DeliverMessage(&message, recipients)
+	push.DispatchMessage(&message, push.UndeliveredUsers(recipients, delivered))
 }
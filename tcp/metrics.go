@@ -0,0 +1,10 @@
+package tcp
+
+import "liveChat/metrics"
+
+var (
+	messageSendSeconds = metrics.NewHistogramVec("livechat_message_send_seconds",
+		"消息从校验到入库、投递 Kafka 的耗时", nil)
+	messageFanoutSeconds = metrics.NewHistogramVec("livechat_message_fanout_seconds",
+		"消息从 Kafka 取出后广播到全部节点的耗时", nil)
+)
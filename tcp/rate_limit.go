@@ -0,0 +1,50 @@
+package tcp
+
+import (
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"liveChat/constants"
+	"liveChat/log"
+	"liveChat/ratelimit"
+	"liveChat/rpc"
+)
+
+// loadNames 为可在配置中限流的请求类型，建立连接前尚无用户 id，不在此列
+var loadNames = map[byte]string{
+	constants.MessageLoad:             "message",
+	constants.RequestMessageLoad:      "requestMessage",
+	constants.RequestMultiMessageLoad: "requestMultiMessage",
+	constants.NotificationRequestLoad: "notificationRequest",
+	constants.HeartBeatLoad:           "heartBeat",
+}
+
+// checkLoadRate 以用户 id 与请求类型限流，被限流时返回 ErrorResponse 回包
+func checkLoadRate(userId int64, requestType byte) (retSlice []byte, limited bool) {
+	name, ok := loadNames[requestType]
+	if !ok {
+		return nil, false
+	}
+
+	decision, err := ratelimit.AllowLoad(ratelimit.UserSubject(userId), name)
+	if err != nil {
+		// 限流存储不可用时放行，避免影响正常收发
+		log.Error(fmt.Sprintf("限流检查失败: %s", err.Error()))
+		return nil, false
+	} else if decision.Allowed {
+		return nil, false
+	}
+
+	response := &rpc.ErrorResponse{
+		Reason:       "请求过于频繁，请稍后再试",
+		Code:         rpc.ErrorResponse_RateLimited,
+		RetryAfterMs: decision.RetryAfter.Milliseconds(),
+	}
+	if decision.Banned {
+		response.Reason = "请求频率持续超限，已被暂时封禁"
+		response.Code = rpc.ErrorResponse_Banned
+	}
+	log.Error(fmt.Sprintf("用户 %d 的 %s 请求被限流: %s", userId, name, response.Reason))
+
+	retSlice, _ = proto.Marshal(response)
+	return retSlice, true
+}
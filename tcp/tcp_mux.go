@@ -1,6 +1,7 @@
 package tcp
 
 import (
+	"crypto/tls"
 	"fmt"
 	"github.com/gobwas/ws"
 	"github.com/gobwas/ws/wsutil"
@@ -9,13 +10,43 @@ import (
 	"liveChat/controllers"
 	"liveChat/log"
 	"liveChat/pool"
+	"liveChat/security"
+	nethttp "net/http"
+	"strings"
 )
 
 var upgrade = ws.Upgrader{
-	OnHost: func(host []byte) error {
-		// TODO 加入 CORS 判断
+	OnHost:   checkHandshakeHost,
+	OnHeader: checkHandshakeHeader,
+}
+
+var originPolicy *security.OriginPolicy
+
+// InitOriginPolicy 设置 WebSocket 握手允许的来源，需要在 InitiateTcpServer 之前调用
+func InitOriginPolicy(policy *security.OriginPolicy) {
+	originPolicy = policy
+}
+
+func checkHandshakeHost(host []byte) error {
+	if originPolicy == nil {
 		return nil
-	},
+	}
+
+	if err := originPolicy.CheckHost(string(host)); err != nil {
+		return ws.RejectConnectionError(ws.RejectionStatus(nethttp.StatusForbidden), ws.RejectionReason(err.Error()))
+	}
+	return nil
+}
+
+func checkHandshakeHeader(key, value []byte) error {
+	if originPolicy == nil || !strings.EqualFold(string(key), "Origin") {
+		return nil
+	}
+
+	if err := originPolicy.CheckOrigin(string(value)); err != nil {
+		return ws.RejectConnectionError(ws.RejectionStatus(nethttp.StatusForbidden), ws.RejectionReason(err.Error()))
+	}
+	return nil
 }
 
 var engine *engineImplementation
@@ -50,6 +81,7 @@ func (engine *engineImplementation) OnTraffic(c gnet.Conn) (action gnet.Action)
 	if c.Context() == nil {
 		_, err := upgrade.Upgrade(c)
 		if err != nil {
+			log.Error(fmt.Sprintf("拒绝来自 %s 的 WebSocket 握手: %s", c.RemoteAddr(), err.Error()))
 			return gnet.Close
 		}
 
@@ -85,7 +117,17 @@ func (engine *engineImplementation) OnTraffic(c gnet.Conn) (action gnet.Action)
 	return gnet.None
 }
 
-func InitiateTcpServer(address string) {
+// InitiateTcpServer tlsConfig 不为 nil 时在 address 上终止 TLS，WebSocket 服务改为监听本地的 internalAddress
+func InitiateTcpServer(address string, tlsConfig *tls.Config, internalAddress string) {
+	if tlsConfig != nil {
+		go func() {
+			if err := serveTLS(address, tlsConfig, internalAddress); err != nil {
+				panic(err)
+			}
+		}()
+		address = internalAddress
+	}
+
 	engine = &engineImplementation{}
 	if err := gnet.Run(engine, address, gnet.WithMulticore(true)); err != nil {
 		panic(err)
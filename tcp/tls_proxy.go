@@ -0,0 +1,79 @@
+package tcp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"liveChat/log"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	tlsHandshakeTimeout = time.Second * 10
+	upstreamDialTimeout = time.Second * 3
+)
+
+// serveTLS gnet 不支持 TLS，这里在 address 上接受 TLS 连接，握手完成后将解密的数据转发至本地的 WebSocket 服务
+func serveTLS(address string, tlsConfig *tls.Config, internalAddress string) error {
+	listener, err := tls.Listen("tcp", stripProtocol(address), tlsConfig)
+	if err != nil {
+		return err
+	}
+
+	target := stripProtocol(internalAddress)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return err
+			}
+			log.Error(fmt.Sprintf("接受 TLS 连接失败: %s", err.Error()))
+			time.Sleep(time.Millisecond * 100)
+			continue
+		}
+		go proxyTLSConnection(conn.(*tls.Conn), target)
+	}
+}
+
+func proxyTLSConnection(conn *tls.Conn, target string) {
+	defer conn.Close()
+
+	ctx, cfn := context.WithTimeout(context.Background(), tlsHandshakeTimeout)
+	err := conn.HandshakeContext(ctx)
+	cfn()
+	if err != nil {
+		log.Error(fmt.Sprintf("TLS 握手失败: %s", err.Error()))
+		return
+	}
+
+	upstream, err := net.DialTimeout("tcp", target, upstreamDialTimeout)
+	if err != nil {
+		log.Error(fmt.Sprintf("连接本地 WebSocket 服务失败: %s", err.Error()))
+		return
+	}
+	defer upstream.Close()
+
+	// 任一方向结束后关闭两端的连接，另一方向的复制随之退出
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(upstream, conn)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(conn, upstream)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// stripProtocol 去掉 gnet 地址中的协议前缀，如 tcp://0.0.0.0:5678
+func stripProtocol(address string) string {
+	if i := strings.Index(address, "://"); i >= 0 {
+		return address[i+3:]
+	}
+	return address
+}
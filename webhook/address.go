@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrorInvalidUrl     = errors.New("回调地址无效")
+	ErrorPrivateAddress = errors.New("回调地址不能指向内网、回环或链路本地地址")
+)
+
+// 运营商级 NAT 地址段，net.IP 的方法不覆盖
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip))
+}
+
+// ValidatePublicUrl 检查回调地址为 http 或 https，且域名解析出的全部地址均为公网地址
+func ValidatePublicUrl(ctx context.Context, rawUrl string) error {
+	u, err := url.Parse(rawUrl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrorInvalidUrl
+	}
+
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil || len(addresses) == 0 {
+		return ErrorInvalidUrl
+	}
+	for _, address := range addresses {
+		if !isPublicIP(address.IP) {
+			return ErrorPrivateAddress
+		}
+	}
+	return nil
+}
+
+// NewPublicClient 返回只能连接公网地址的客户端。连接时再次检查实际地址，
+// 防止创建后修改域名解析或通过重定向访问内网
+func NewPublicClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return ErrorPrivateAddress
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConnsPerHost: defaultWorkerNumber,
+		},
+	}
+}
@@ -0,0 +1,165 @@
+package webhook
+
+import (
+	"liveChat/config"
+	"liveChat/entities"
+	"liveChat/rpc"
+	"strings"
+)
+
+type MessageData struct {
+	Id         uint64  `json:"id"`
+	Sender     int64   `json:"sender"`
+	Receiver   int64   `json:"receiver"`
+	Timestamp  uint64  `json:"timestamp"`
+	Type       int32   `json:"type"`
+	Content    string  `json:"content"`
+	Mentions   []int64 `json:"mentions,omitempty"`
+	MentionAll bool    `json:"mentionAll,omitempty"`
+}
+
+type NotificationData struct {
+	Seq        uint64 `json:"seq"`
+	SenderId   int64  `json:"senderId"`
+	ReceiverId int64  `json:"receiverId"`
+	IsHandled  bool   `json:"isHandled"`
+	IsAgree    bool   `json:"isAgree"`
+	Message    string `json:"message,omitempty"`
+}
+
+type GroupData struct {
+	GroupId    int64 `json:"groupId"`
+	OperatorId int64 `json:"operatorId"`
+}
+
+var eventTypes = map[string]struct{}{
+	EventMessageCreated:        {},
+	EventFriendRequested:       {},
+	EventFriendApproved:        {},
+	EventFriendRefused:         {},
+	EventFriendDeleted:         {},
+	EventGroupCreated:          {},
+	EventGroupDeleted:          {},
+	EventGroupJoinRequested:    {},
+	EventGroupJoinRefused:      {},
+	EventGroupMemberJoined:     {},
+	EventGroupMemberLeft:       {},
+	EventGroupOwnerTransferred: {},
+}
+
+func IsValidEventType(eventType string) bool {
+	_, ok := eventTypes[eventType]
+	return ok
+}
+
+var dispatcher *Dispatcher
+
+func InitWebhook(cfg config.WebhookConfig) {
+	dispatcher = NewDispatcher(databaseStore{}, cfg)
+	dispatcher.Recover()
+}
+
+func GetDispatcher() *Dispatcher {
+	return dispatcher
+}
+
+func Publish(eventType string, chatId int64, data interface{}) {
+	if dispatcher == nil {
+		return
+	}
+	dispatcher.Publish(eventType, chatId, data)
+}
+
+// PublishToBot 通过分发器投递机器人事件，分发器未初始化时返回错误
+func PublishToBot(botId int64, eventType, eventId string, payload []byte) error {
+	if dispatcher == nil {
+		return ErrorSubscriptionNotExist
+	}
+	return dispatcher.PublishToBot(botId, eventType, eventId, payload)
+}
+
+func NewMessageData(m *rpc.Message) *MessageData {
+	return &MessageData{
+		Id:         m.Id,
+		Sender:     m.Sender,
+		Receiver:   m.Receiver,
+		Timestamp:  m.Timestamp,
+		Type:       int32(m.Type),
+		Content:    strings.Join(m.Contents, ""),
+		Mentions:   m.Mentions,
+		MentionAll: m.MentionAll,
+	}
+}
+
+func PublishMessage(m *rpc.Message) {
+	Publish(EventMessageCreated, m.Receiver, NewMessageData(m))
+}
+
+// PublishNotification 将通知转换为对应的社交关系事件，无对应事件的通知会被忽略
+func PublishNotification(n *rpc.NotificationRequest) {
+	eventType, chatId := NotificationEvent(n)
+	if eventType == "" {
+		return
+	}
+
+	Publish(eventType, chatId, NewNotificationData(n))
+}
+
+func NewNotificationData(n *rpc.NotificationRequest) *NotificationData {
+	return &NotificationData{
+		Seq:        n.Id,
+		SenderId:   n.Sender,
+		ReceiverId: n.Receiver,
+		IsHandled:  n.IsHandledByAuth,
+		IsAgree:    n.IsAgree,
+		Message:    n.Message,
+	}
+}
+
+// NotificationEvent 返回通知对应的事件类型与所属会话，无对应事件时事件类型为空
+func NotificationEvent(n *rpc.NotificationRequest) (eventType string, chatId int64) {
+	op := byte(n.Op)
+	if byte(n.ReceiveType) == entities.Friend {
+		switch op {
+		case entities.Add:
+			eventType = EventFriendRequested
+		case entities.Approve:
+			eventType = EventFriendApproved
+		case entities.Refuse:
+			eventType = EventFriendRefused
+		case entities.Delete:
+			eventType = EventFriendDeleted
+		}
+		return eventType, n.Receiver
+	}
+
+	if byte(n.ReceiveType) != entities.Group {
+		return "", 0
+	}
+
+	// 群组通知中群组 id 为负数，可能位于发送方或接收方
+	chatId = n.Receiver
+	if n.Sender < 0 {
+		chatId = n.Sender
+	}
+
+	switch op {
+	case entities.Add:
+		// 审批后会重新下发已处理的申请，避免重复产生事件
+		if !n.IsHandledByAuth {
+			eventType = EventGroupJoinRequested
+		}
+	case entities.Approve:
+		eventType = EventGroupMemberJoined
+	case entities.Refuse:
+		eventType = EventGroupJoinRefused
+	case entities.Delete:
+		// 由群组发出的删除通知表示群组被解散，解散事件单独发布
+		if n.Sender >= 0 {
+			eventType = EventGroupMemberLeft
+		}
+	case entities.Transfer:
+		eventType = EventGroupOwnerTransferred
+	}
+	return eventType, chatId
+}
@@ -0,0 +1,474 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"liveChat/config"
+	"liveChat/db"
+	"liveChat/entities"
+	"liveChat/log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	EventMessageCreated = "message.created"
+
+	EventFriendRequested = "friend.requested"
+	EventFriendApproved  = "friend.approved"
+	EventFriendRefused   = "friend.refused"
+	EventFriendDeleted   = "friend.deleted"
+
+	EventGroupCreated          = "group.created"
+	EventGroupDeleted          = "group.deleted"
+	EventGroupJoinRequested    = "group.join_requested"
+	EventGroupJoinRefused      = "group.join_refused"
+	EventGroupMemberJoined     = "group.member_joined"
+	EventGroupMemberLeft       = "group.member_left"
+	EventGroupOwnerTransferred = "group.owner_transferred"
+)
+
+const (
+	SignatureHeader = "X-LiveChat-Signature"
+	TimestampHeader = "X-LiveChat-Timestamp"
+	EventHeader     = "X-LiveChat-Event"
+	DeliveryHeader  = "X-LiveChat-Delivery"
+
+	signaturePrefix = "sha256="
+)
+
+const (
+	defaultMaxAttempts   = 8
+	defaultBaseBackoff   = time.Second * 2
+	defaultMaxBackoff    = time.Minute * 10
+	defaultTimeout       = time.Second * 5
+	defaultWorkerNumber  = 4
+	defaultQueueSize     = 10000
+	subscriptionCacheTTL = time.Second * 30
+
+	// 待投递记录超过 maxBackoff + staleGracePeriod 未更新时视为负责的节点已退出，由其他节点认领
+	staleGracePeriod = time.Minute * 5
+	recoverInterval  = time.Minute
+	recoverBatchSize = 1000
+)
+
+var (
+	ErrorSubscriptionNotExist = errors.New("订阅不存在或已停用")
+	ErrorDeliveryQueueFull    = errors.New("投递队列已满")
+	ErrorDeliveryPending      = errors.New("投递尚未结束，不能重放")
+)
+
+type Event struct {
+	Id        string      `json:"id"`
+	Type      string      `json:"type"`
+	Timestamp int64       `json:"timestamp"`
+	ChatId    int64       `json:"chatId"`
+	Data      interface{} `json:"data"`
+}
+
+// Store 为分发器读取订阅与保存投递记录的来源
+type Store interface {
+	GetSubscriptions() ([]entities.WebhookSubscription, error)
+	SaveDelivery(d *entities.WebhookDelivery) error
+	GetDelivery(id string) (*entities.WebhookDelivery, error)
+	// SwapDelivery 仅在记录的状态与更新时间未被修改时保存，返回是否保存成功
+	SwapDelivery(d *entities.WebhookDelivery, status string, updatedAt int64) (bool, error)
+	GetStaleDeliveries(updatedBefore int64, limit int) ([]entities.WebhookDelivery, error)
+}
+
+// BotTarget 提供机器人的回调地址，由 bot 包注册以避免循环引用
+type BotTarget interface {
+	// GetBotWebhook 机器人不存在或未设置 webhook 时返回 ErrorSubscriptionNotExist
+	GetBotWebhook(botId int64) (url, secret string, err error)
+	// OnDeliveryDead 在投递最终失败后调用，用于将事件转入机器人收件箱
+	OnDeliveryDead(d *entities.WebhookDelivery)
+}
+
+type databaseStore struct{}
+
+func (databaseStore) GetSubscriptions() ([]entities.WebhookSubscription, error) {
+	return db.SelectWebhookSubscriptions(nil)
+}
+
+func (databaseStore) SaveDelivery(d *entities.WebhookDelivery) error {
+	return db.SaveWebhookDelivery(context.Background(), d)
+}
+
+func (databaseStore) GetDelivery(id string) (*entities.WebhookDelivery, error) {
+	return db.GetWebhookDelivery(context.Background(), id)
+}
+
+func (databaseStore) SwapDelivery(d *entities.WebhookDelivery, status string, updatedAt int64) (bool, error) {
+	return db.SwapWebhookDelivery(context.Background(), d, status, updatedAt)
+}
+
+func (databaseStore) GetStaleDeliveries(updatedBefore int64, limit int) ([]entities.WebhookDelivery, error) {
+	return db.GetStaleWebhookDeliveries(context.Background(), updatedBefore, int64(limit))
+}
+
+type Dispatcher struct {
+	store  Store
+	client *http.Client
+	tasks  chan *entities.WebhookDelivery
+	now    func() time.Time
+
+	// 机器人的回调地址由用户填写，只允许访问公网
+	bots      BotTarget
+	botClient *http.Client
+
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+
+	lock          sync.RWMutex
+	subscriptions map[int64]entities.WebhookSubscription
+	loadedAt      time.Time
+}
+
+func NewDispatcher(store Store, cfg config.WebhookConfig) *Dispatcher {
+	d := &Dispatcher{
+		store:       store,
+		client:      &http.Client{Timeout: secondsOrDefault(cfg.TimeoutSeconds, defaultTimeout)},
+		botClient:   NewPublicClient(secondsOrDefault(cfg.TimeoutSeconds, defaultTimeout)),
+		tasks:       make(chan *entities.WebhookDelivery, defaultQueueSize),
+		now:         time.Now,
+		maxAttempts: cfg.MaxAttempts,
+		baseBackoff: secondsOrDefault(cfg.BaseBackoffSeconds, defaultBaseBackoff),
+		maxBackoff:  secondsOrDefault(cfg.MaxBackoffSeconds, defaultMaxBackoff),
+	}
+	if d.maxAttempts <= 0 {
+		d.maxAttempts = defaultMaxAttempts
+	}
+
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = defaultWorkerNumber
+	}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for delivery := range d.tasks {
+				d.deliver(delivery)
+			}
+		}()
+	}
+	return d
+}
+
+// Publish 为所有匹配的订阅生成投递记录并放入队列
+func (d *Dispatcher) Publish(eventType string, chatId int64, data interface{}) {
+	subscriptions, err := d.getSubscriptions()
+	if err != nil {
+		log.Error(fmt.Sprintf("读取 webhook 订阅失败: %s", err.Error()))
+		return
+	}
+
+	now := d.now()
+	for _, subscription := range subscriptions {
+		if !subscription.Accepts(eventType, chatId) {
+			continue
+		}
+
+		id := GenerateId()
+		payload, err := json.Marshal(&Event{
+			Id:        id,
+			Type:      eventType,
+			Timestamp: now.UnixMilli(),
+			ChatId:    chatId,
+			Data:      data,
+		})
+		if err != nil {
+			log.Error(fmt.Sprintf("序列化 webhook 事件失败: %s", err.Error()))
+			return
+		}
+
+		delivery := entities.NewWebhookDelivery(id, subscription.Id, eventType, string(payload), now.UnixMilli())
+		if err = d.store.SaveDelivery(delivery); err != nil {
+			log.Error(fmt.Sprintf("保存 webhook 投递记录失败: %s", err.Error()))
+			continue
+		}
+		d.enqueue(delivery)
+	}
+}
+
+// SetBotTarget 在分发器开始投递机器人事件前调用
+func (d *Dispatcher) SetBotTarget(bots BotTarget) {
+	d.lock.Lock()
+	d.bots = bots
+	d.lock.Unlock()
+}
+
+// PublishToBot 保存机器人事件的投递记录并放入队列，与订阅共用重试与死信逻辑
+func (d *Dispatcher) PublishToBot(botId int64, eventType, eventId string, payload []byte) error {
+	if d.getBotTarget() == nil {
+		return ErrorSubscriptionNotExist
+	}
+
+	delivery := entities.NewWebhookDelivery(eventId, 0, eventType, string(payload), d.now().UnixMilli())
+	delivery.BotId = botId
+	if err := d.store.SaveDelivery(delivery); err != nil {
+		return err
+	}
+	d.enqueue(delivery)
+	return nil
+}
+
+// Replay 将一条已结束的投递记录重置为待投递状态并重新放入队列，仍在重试中的记录不能重放
+func (d *Dispatcher) Replay(id string) (*entities.WebhookDelivery, error) {
+	delivery, err := d.store.GetDelivery(id)
+	if err != nil {
+		return nil, err
+	} else if delivery.Status == entities.WebhookDeliveryPending {
+		return nil, ErrorDeliveryPending
+	}
+
+	status, updatedAt := delivery.Status, delivery.UpdatedAt
+	delivery.Status = entities.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.LastError = ""
+	delivery.UpdatedAt = d.now().UnixMilli()
+	if ok, err := d.store.SwapDelivery(delivery, status, updatedAt); err != nil {
+		return nil, err
+	} else if !ok {
+		// 同时有其他重放请求
+		return nil, ErrorDeliveryPending
+	}
+
+	if !d.enqueue(delivery) {
+		return nil, ErrorDeliveryQueueFull
+	}
+	return delivery, nil
+}
+
+// Recover 立即并定期认领滞留的待投递记录，节点重启前未完成的重试由此继续
+func (d *Dispatcher) Recover() {
+	go func() {
+		for {
+			d.recoverStale()
+			time.Sleep(recoverInterval)
+		}
+	}()
+}
+
+// recoverStale 正常重试时记录的更新间隔不超过 maxBackoff，长时间未更新说明重试的定时器已随节点退出而丢失
+func (d *Dispatcher) recoverStale() {
+	now := d.now()
+	deliveries, err := d.store.GetStaleDeliveries(now.Add(-d.maxBackoff-staleGracePeriod).UnixMilli(), recoverBatchSize)
+	if err != nil {
+		log.Error(fmt.Sprintf("读取滞留的 webhook 投递记录失败: %s", err.Error()))
+		return
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		updatedAt := delivery.UpdatedAt
+		delivery.UpdatedAt = now.UnixMilli()
+		if ok, err := d.store.SwapDelivery(delivery, entities.WebhookDeliveryPending, updatedAt); err != nil {
+			log.Error(fmt.Sprintf("认领 webhook 投递记录失败: %s", err.Error()))
+			continue
+		} else if !ok {
+			continue
+		}
+		d.enqueue(delivery)
+	}
+}
+
+// InvalidateSubscriptions 使订阅缓存失效，订阅变更后调用
+func (d *Dispatcher) InvalidateSubscriptions() {
+	d.lock.Lock()
+	d.loadedAt = time.Time{}
+	d.lock.Unlock()
+}
+
+func (d *Dispatcher) enqueue(delivery *entities.WebhookDelivery) bool {
+	select {
+	case d.tasks <- delivery:
+		return true
+	default:
+		log.Error(fmt.Sprintf("webhook 投递队列已满，投递 %s 稍后重试", delivery.Id))
+		return false
+	}
+}
+
+func (d *Dispatcher) deliver(delivery *entities.WebhookDelivery) {
+	client, url, secret, err := d.resolve(delivery)
+	if errors.Is(err, ErrorSubscriptionNotExist) {
+		delivery.Status = entities.WebhookDeliveryDead
+		delivery.LastError = err.Error()
+	} else {
+		delivery.Attempts++
+		if err == nil {
+			err = Post(client, url, secret, delivery.EventType, delivery.Id, []byte(delivery.Payload))
+		}
+
+		if err == nil {
+			delivery.Status = entities.WebhookDeliverySucceeded
+			delivery.LastError = ""
+		} else {
+			delivery.LastError = err.Error()
+			if delivery.Attempts >= d.maxAttempts {
+				delivery.Status = entities.WebhookDeliveryDead
+			}
+		}
+	}
+
+	delivery.UpdatedAt = d.now().UnixMilli()
+	if err := d.store.SaveDelivery(delivery); err != nil {
+		log.Error(fmt.Sprintf("保存 webhook 投递记录失败: %s", err.Error()))
+	}
+
+	switch delivery.Status {
+	case entities.WebhookDeliveryPending:
+		time.AfterFunc(d.backoff(delivery.Attempts), func() {
+			d.enqueue(delivery)
+		})
+	case entities.WebhookDeliveryDead:
+		if bots := d.getBotTarget(); delivery.BotId != 0 && bots != nil {
+			bots.OnDeliveryDead(delivery)
+		}
+	}
+}
+
+// resolve 返回投递使用的客户端、回调地址与签名密钥
+func (d *Dispatcher) resolve(delivery *entities.WebhookDelivery) (*http.Client, string, string, error) {
+	if delivery.BotId == 0 {
+		subscription, ok := d.getSubscription(delivery.SubscriptionId)
+		if !ok {
+			return nil, "", "", ErrorSubscriptionNotExist
+		}
+		return d.client, subscription.Url, subscription.Secret, nil
+	}
+
+	bots := d.getBotTarget()
+	if bots == nil {
+		return nil, "", "", ErrorSubscriptionNotExist
+	}
+	url, secret, err := bots.GetBotWebhook(delivery.BotId)
+	return d.botClient, url, secret, err
+}
+
+func (d *Dispatcher) getBotTarget() BotTarget {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.bots
+}
+
+// Post 以 POST 方式发送带签名的事件，回调地址返回非 2xx 状态码时视为失败
+func Post(client *http.Client, url, secret, eventType, deliveryId string, body []byte) error {
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, eventType)
+	req.Header.Set(DeliveryHeader, deliveryId)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(secret, timestamp, body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("回调地址返回状态码 %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// backoff 返回第 attempts 次失败后的等待时间，每次翻倍且不超过 maxBackoff
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.baseBackoff
+	for i := 1; i < attempts && wait < d.maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > d.maxBackoff {
+		wait = d.maxBackoff
+	}
+	return wait
+}
+
+func (d *Dispatcher) getSubscriptions() ([]entities.WebhookSubscription, error) {
+	if err := d.refreshSubscriptions(); err != nil {
+		return nil, err
+	}
+
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	ret := make([]entities.WebhookSubscription, 0, len(d.subscriptions))
+	for _, subscription := range d.subscriptions {
+		ret = append(ret, subscription)
+	}
+	return ret, nil
+}
+
+func (d *Dispatcher) getSubscription(id int64) (entities.WebhookSubscription, bool) {
+	if err := d.refreshSubscriptions(); err != nil {
+		log.Error(fmt.Sprintf("读取 webhook 订阅失败: %s", err.Error()))
+	}
+
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	subscription, ok := d.subscriptions[id]
+	return subscription, ok && !subscription.IsDisabled
+}
+
+func (d *Dispatcher) refreshSubscriptions() error {
+	d.lock.RLock()
+	isFresh := d.subscriptions != nil && d.now().Sub(d.loadedAt) < subscriptionCacheTTL
+	d.lock.RUnlock()
+	if isFresh {
+		return nil
+	}
+
+	list, err := d.store.GetSubscriptions()
+	if err != nil {
+		return err
+	}
+
+	subscriptions := make(map[int64]entities.WebhookSubscription, len(list))
+	for _, subscription := range list {
+		subscriptions[subscription.Id] = subscription
+	}
+
+	d.lock.Lock()
+	d.subscriptions = subscriptions
+	d.loadedAt = d.now()
+	d.lock.Unlock()
+	return nil
+}
+
+// Sign 计算回调请求的签名，接收方应以相同方式对 "时间戳.请求体" 计算 HMAC-SHA256 并比对
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func GenerateId() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf)
+}
+
+func secondsOrDefault(seconds int, defaultValue time.Duration) time.Duration {
+	if seconds <= 0 {
+		return defaultValue
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,300 @@
+package webhook
+
+import (
+	"errors"
+	"io"
+	"liveChat/config"
+	"liveChat/entities"
+	"liveChat/rpc"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type memoryStore struct {
+	lock          sync.Mutex
+	subscriptions []entities.WebhookSubscription
+	deliveries    map[string]entities.WebhookDelivery
+}
+
+func newMemoryStore(subscriptions ...entities.WebhookSubscription) *memoryStore {
+	return &memoryStore{subscriptions: subscriptions, deliveries: make(map[string]entities.WebhookDelivery)}
+}
+
+func (s *memoryStore) GetSubscriptions() ([]entities.WebhookSubscription, error) {
+	return s.subscriptions, nil
+}
+
+func (s *memoryStore) SaveDelivery(d *entities.WebhookDelivery) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.deliveries[d.Id] = *d
+	return nil
+}
+
+func (s *memoryStore) GetDelivery(id string) (*entities.WebhookDelivery, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if d, ok := s.deliveries[id]; ok {
+		return &d, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (s *memoryStore) SwapDelivery(d *entities.WebhookDelivery, status string, updatedAt int64) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if old, ok := s.deliveries[d.Id]; !ok || old.Status != status || old.UpdatedAt != updatedAt {
+		return false, nil
+	}
+	s.deliveries[d.Id] = *d
+	return true, nil
+}
+
+func (s *memoryStore) GetStaleDeliveries(updatedBefore int64, limit int) ([]entities.WebhookDelivery, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	ret := make([]entities.WebhookDelivery, 0)
+	for _, d := range s.deliveries {
+		if d.Status == entities.WebhookDeliveryPending && d.UpdatedAt < updatedBefore && len(ret) < limit {
+			ret = append(ret, d)
+		}
+	}
+	return ret, nil
+}
+
+func (s *memoryStore) waitFor(t *testing.T, status string) entities.WebhookDelivery {
+	deadline := time.Now().Add(time.Second * 5)
+	for time.Now().Before(deadline) {
+		s.lock.Lock()
+		for _, d := range s.deliveries {
+			if d.Status == status {
+				s.lock.Unlock()
+				return d
+			}
+		}
+		s.lock.Unlock()
+		time.Sleep(time.Millisecond * 10)
+	}
+	t.Fatalf("no delivery reached status %s", status)
+	return entities.WebhookDelivery{}
+}
+
+func newTestDispatcher(store Store, maxAttempts int) *Dispatcher {
+	d := NewDispatcher(store, config.WebhookConfig{MaxAttempts: maxAttempts, Workers: 1})
+	d.baseBackoff = time.Millisecond
+	d.maxBackoff = time.Millisecond * 4
+	return d
+}
+
+func TestSubscriptionAccepts(t *testing.T) {
+	s := entities.NewWebhookSubscription("http://localhost", "s", []string{EventMessageCreated}, []int64{-1})
+	if !s.Accepts(EventMessageCreated, -1) || s.Accepts(EventMessageCreated, -2) || s.Accepts(EventGroupDeleted, -1) {
+		t.Error("unexpected filter result")
+	}
+
+	s = entities.NewWebhookSubscription("http://localhost", "s", nil, nil)
+	if !s.Accepts(EventGroupDeleted, 10) {
+		t.Error("empty filters should accept every event")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	d := &Dispatcher{baseBackoff: time.Second, maxBackoff: time.Second * 10}
+	for attempts, expected := range map[int]time.Duration{1: time.Second, 2: time.Second * 2, 3: time.Second * 4, 5: time.Second * 10} {
+		if wait := d.backoff(attempts); wait != expected {
+			t.Errorf("backoff(%d) = %v, expected %v", attempts, wait, expected)
+		}
+	}
+}
+
+func TestDeliverySignedAndRetried(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+		if r.Header.Get(SignatureHeader) != Sign("secret", timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	subscription := entities.NewWebhookSubscription(server.URL, "secret", nil, nil)
+	subscription.Id = 1
+	store := newMemoryStore(*subscription)
+	d := newTestDispatcher(store, 5)
+
+	d.Publish(EventGroupCreated, -1, &GroupData{GroupId: -1, OperatorId: 1})
+	delivery := store.waitFor(t, entities.WebhookDeliverySucceeded)
+	if delivery.Attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", delivery.Attempts)
+	}
+}
+
+func TestDeliveryDeadLetterAndReplay(t *testing.T) {
+	var healthy int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&healthy) == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	subscription := entities.NewWebhookSubscription(server.URL, "secret", nil, nil)
+	subscription.Id = 1
+	store := newMemoryStore(*subscription)
+	d := newTestDispatcher(store, 2)
+
+	d.Publish(EventGroupDeleted, -1, nil)
+	dead := store.waitFor(t, entities.WebhookDeliveryDead)
+	if dead.Attempts != 2 || dead.LastError == "" {
+		t.Errorf("unexpected dead delivery: %+v", dead)
+	}
+
+	atomic.StoreInt32(&healthy, 1)
+	if _, err := d.Replay(dead.Id); err != nil {
+		t.Fatal(err)
+	}
+	store.waitFor(t, entities.WebhookDeliverySucceeded)
+}
+
+func TestReplayRejectsPending(t *testing.T) {
+	store := newMemoryStore()
+	store.SaveDelivery(entities.NewWebhookDelivery("pending", 1, EventGroupDeleted, "{}", time.Now().UnixMilli()))
+	d := newTestDispatcher(store, 2)
+
+	if _, err := d.Replay("pending"); !errors.Is(err, ErrorDeliveryPending) {
+		t.Errorf("expected ErrorDeliveryPending, got %v", err)
+	}
+}
+
+func TestRecoverStaleDeliveries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	subscription := entities.NewWebhookSubscription(server.URL, "secret", nil, nil)
+	subscription.Id = 1
+	store := newMemoryStore(*subscription)
+	d := newTestDispatcher(store, 2)
+
+	// 重启前留下的待投递记录，以及另一个节点刚更新过、仍在重试中的记录
+	stale := time.Now().Add(-d.maxBackoff - staleGracePeriod - time.Second).UnixMilli()
+	store.SaveDelivery(entities.NewWebhookDelivery("stale", 1, EventGroupDeleted, "{}", stale))
+	store.SaveDelivery(entities.NewWebhookDelivery("fresh", 1, EventGroupDeleted, "{}", time.Now().UnixMilli()))
+
+	d.recoverStale()
+	delivery := store.waitFor(t, entities.WebhookDeliverySucceeded)
+	if delivery.Id != "stale" {
+		t.Errorf("unexpected delivery recovered: %s", delivery.Id)
+	}
+
+	// 已被认领的记录不会被再次认领
+	d.recoverStale()
+	time.Sleep(time.Millisecond * 50)
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected 1 call, got %d", n)
+	}
+}
+
+func TestNotificationEvent(t *testing.T) {
+	cases := []struct {
+		request   *rpc.NotificationRequest
+		eventType string
+		chatId    int64
+	}{
+		{&rpc.NotificationRequest{Sender: 1, Receiver: 2, Op: rpc.NotificationRequest_OpType(entities.Approve), ReceiveType: rpc.NotificationRequest_ReceiveType(entities.Friend)}, EventFriendApproved, 2},
+		{&rpc.NotificationRequest{Sender: -5, Receiver: 2, Op: rpc.NotificationRequest_OpType(entities.Approve), ReceiveType: rpc.NotificationRequest_ReceiveType(entities.Group)}, EventGroupMemberJoined, -5},
+		{&rpc.NotificationRequest{Sender: 2, Receiver: -5, Op: rpc.NotificationRequest_OpType(entities.Add), ReceiveType: rpc.NotificationRequest_ReceiveType(entities.Group), IsHandledByAuth: true}, "", -5},
+		{&rpc.NotificationRequest{Sender: 2, Receiver: -5, Op: rpc.NotificationRequest_OpType(entities.Delete), ReceiveType: rpc.NotificationRequest_ReceiveType(entities.Group)}, EventGroupMemberLeft, -5},
+		{&rpc.NotificationRequest{Sender: -5, Receiver: 2, Op: rpc.NotificationRequest_OpType(entities.Delete), ReceiveType: rpc.NotificationRequest_ReceiveType(entities.Group), IsHandledByAuth: true}, "", -5},
+		{&rpc.NotificationRequest{Sender: 3, Receiver: -5, Op: rpc.NotificationRequest_OpType(entities.Transfer), ReceiveType: rpc.NotificationRequest_ReceiveType(entities.Group), IsHandledByAuth: true}, EventGroupOwnerTransferred, -5},
+		{&rpc.NotificationRequest{Sender: -5, Receiver: -5, Op: rpc.NotificationRequest_OpType(entities.Mute), ReceiveType: rpc.NotificationRequest_ReceiveType(entities.Group), IsHandledByAuth: true}, "", -5},
+	}
+
+	for _, c := range cases {
+		eventType, chatId := NotificationEvent(c.request)
+		if eventType != c.eventType || (eventType != "" && chatId != c.chatId) {
+			t.Errorf("NotificationEvent(%v) = %s, %d", c.request, eventType, chatId)
+		}
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	cases := map[string]bool{
+		"8.8.8.8":         true,
+		"2001:4860::8888": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fd00:ec2::254":   false,
+		"::ffff:10.0.0.1": false,
+	}
+
+	for address, expected := range cases {
+		if isPublicIP(net.ParseIP(address)) != expected {
+			t.Errorf("isPublicIP(%s) should be %v", address, expected)
+		}
+	}
+}
+
+type testBotTarget struct {
+	url  string
+	dead chan *entities.WebhookDelivery
+}
+
+func (b *testBotTarget) GetBotWebhook(int64) (string, string, error) {
+	return b.url, "secret", nil
+}
+
+func (b *testBotTarget) OnDeliveryDead(d *entities.WebhookDelivery) {
+	b.dead <- d
+}
+
+func TestBotDeliveryRejectsPrivateAddress(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	store := newMemoryStore()
+	d := newTestDispatcher(store, 2)
+	bots := &testBotTarget{url: server.URL, dead: make(chan *entities.WebhookDelivery, 1)}
+	d.SetBotTarget(bots)
+
+	if err := d.PublishToBot(7, EventMessageCreated, GenerateId(), []byte("{}")); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case delivery := <-bots.dead:
+		if delivery.BotId != 7 || delivery.Attempts != 2 {
+			t.Errorf("unexpected dead delivery: %+v", delivery)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("bot delivery did not reach dead status")
+	}
+	if atomic.LoadInt32(&calls) != 0 {
+		t.Error("bot webhook on loopback address should not be called")
+	}
+}